@@ -0,0 +1,15 @@
+// Package api holds the OpenAPI specification the server is generated from.
+package api
+
+import _ "embed"
+
+// Spec is api.yaml, embedded so that the server can check requests against it and serve it at run time.
+//
+//go:embed api.yaml
+var Spec []byte
+
+// DocsPage is docs.html, the documentation page for the spec. It is self-contained, so that it works offline,
+// and loads the spec from openapi.json next to it.
+//
+//go:embed docs.html
+var DocsPage []byte
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"os"
 
 	"github.com/shhesterka04/house-service/internal/app"
 	"github.com/shhesterka04/house-service/pkg/logger"
@@ -12,6 +14,8 @@ const (
 	development = true
 )
 
+const createAdminCmd = "create-admin"
+
 func main() {
 	ctx := context.Background()
 
@@ -21,7 +25,30 @@ func main() {
 			Development: development,
 		})
 
+	if len(os.Args) > 1 && os.Args[1] == createAdminCmd {
+		createAdmin(ctx, os.Args[2:])
+		return
+	}
+
 	if err := app.Run(ctx); err != nil {
 		logger.Fatalf(ctx, "app run error: %v", err)
 	}
 }
+
+// createAdmin handles `house-service create-admin -email admin@example.com -password ...`.
+// The password can also be passed through the HOUSE_ADMIN_PASSWORD env variable to keep it out of shell history.
+func createAdmin(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet(createAdminCmd, flag.ExitOnError)
+	email := fs.String("email", "", "admin email")
+	password := fs.String("password", os.Getenv("HOUSE_ADMIN_PASSWORD"), "admin password")
+	_ = fs.Parse(args)
+
+	if *email == "" || *password == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	if err := app.CreateAdmin(ctx, *email, *password); err != nil {
+		logger.Fatalf(ctx, "create admin error: %v", err)
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/api"
 	"github.com/shhesterka04/house-service/internal/config"
 	"github.com/shhesterka04/house-service/internal/handlers"
+	"github.com/shhesterka04/house-service/internal/middleware"
 	"github.com/shhesterka04/house-service/internal/repository"
 	"github.com/shhesterka04/house-service/internal/routes"
 	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/blobstore"
 	"github.com/shhesterka04/house-service/pkg/db"
 	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
 )
 
 const migrationDir = "/migrations"
@@ -25,30 +29,28 @@ func Run(ctx context.Context) error {
 		return errors.Wrap(err, "load config")
 	}
 
-	pgClient := db.NewClient(
-		cfg.DBName,
-		cfg.DBUser,
-		cfg.DBPassword,
-		cfg.DBHost,
-		cfg.DBPort,
-	)
-
-	dbConn, err := pgClient.Connect(ctx)
+	pgClient, dbConn, err := connect(ctx, cfg)
 	if err != nil {
-		return errors.Wrap(err, "connect to database")
+		return err
 	}
-	logger.Infof(ctx, "connected to database")
+	defer pgClient.Close()
 
-	if err = pgClient.MigrateUp(migrationDir); err != nil {
-		logger.Errorf(ctx, "migrate error: %v", err)
-		return errors.Wrap(err, "migrate")
+	passwordPolicy, err := newPasswordPolicy(cfg)
+	if err != nil {
+		return err
 	}
-	defer pgClient.Close()
 
 	userRepo := repository.NewUserRepository(dbConn.Cluster)
-	authService := service.NewAuthService(userRepo)
+	authService := service.NewAuthService(userRepo, passwordPolicy)
 	authHandlers := handlers.NewAuthHandlers(authService)
 
+	userService := service.NewUserService(userRepo)
+	userHandlers := handlers.NewUserHandler(userService)
+
+	apiKeyRepo := repository.NewAPIKeyRepository(dbConn.Cluster)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	apiKeyHandlers := handlers.NewAPIKeyHandler(apiKeyService)
+
 	houseRepo := repository.NewHouseRepository(dbConn.Cluster)
 	houseService := service.NewHouseService(houseRepo)
 	houseHandlers := handlers.NewHouseHandler(houseService)
@@ -57,12 +59,129 @@ func Run(ctx context.Context) error {
 	flatService := service.NewFlatService(flatRepo, houseRepo)
 	flatHandlers := handlers.NewFlatHandler(flatService)
 
-	mux := routes.NewRouter(authHandlers, houseHandlers, flatHandlers)
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	flatMediaRepo := repository.NewFlatMediaRepository(dbConn.Cluster)
+	flatMediaService := service.NewFlatMediaService(flatMediaRepo, flatRepo, houseRepo, blobs)
+	flatMediaHandlers := handlers.NewFlatMediaHandler(flatMediaService)
+
+	moderationRepo := repository.NewModerationRepository(dbConn.Cluster)
+	moderationService := service.NewModerationService(moderationRepo, flatRepo)
+	moderationHandlers := handlers.NewModerationHandler(moderationService)
+
+	auditService := service.NewAuditService(repository.NewAuditRepository(dbConn.Cluster))
+	auditHandlers := handlers.NewAuditHandler(auditService)
+
+	developerRepo := repository.NewDeveloperRepository(dbConn.Cluster)
+	developerService := service.NewDeveloperService(developerRepo)
+	developerHandlers := handlers.NewDeveloperHandler(developerService)
+
+	docsHandlers, err := handlers.NewDocsHandler(api.Spec, api.DocsPage)
+	if err != nil {
+		return errors.Wrap(err, "docs handler")
+	}
+
+	server := handlers.NewServer(authHandlers, houseHandlers, flatHandlers, flatMediaHandlers, moderationHandlers, auditHandlers, userHandlers, apiKeyHandlers, developerHandlers, docsHandlers)
+	mux := routes.NewRouter(cfg, server, apiKeyService, authService, repository.NewIdempotencyRepository(dbConn.Cluster), ratelimit.NewMemory())
 
 	logger.Infof(ctx, "starting server on %s", cfg.HostAddr)
-	if err = http.ListenAndServe(cfg.HostAddr, mux); err != nil {
+	if err = http.ListenAndServe(cfg.HostAddr, middleware.RequestID(mux)); err != nil {
 		return errors.Wrap(err, "listen and serve")
 	}
 
 	return nil
 }
+
+// CreateAdmin bootstraps an admin account, so that moderators can be provisioned through the API.
+func CreateAdmin(ctx context.Context, email, password string) error {
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		return errors.Wrap(err, "load config")
+	}
+
+	pgClient, dbConn, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pgClient.Close()
+
+	passwordPolicy, err := newPasswordPolicy(cfg)
+	if err != nil {
+		return err
+	}
+
+	authService := service.NewAuthService(repository.NewUserRepository(dbConn.Cluster), passwordPolicy)
+	id, err := authService.BootstrapAdmin(ctx, email, password)
+	if err != nil {
+		return errors.Wrap(err, "create admin")
+	}
+
+	logger.Infof(ctx, "admin %s created with id %s, log in with the id", email, id)
+	return nil
+}
+
+func connect(ctx context.Context, cfg *config.Config) (*db.Client, *db.Database, error) {
+	pgClient := db.NewClient(
+		cfg.DBName,
+		cfg.DBUser,
+		cfg.DBPassword,
+		cfg.DBHost,
+		cfg.DBPort,
+	)
+
+	dbConn, err := pgClient.Connect(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "connect to database")
+	}
+	logger.Infof(ctx, "connected to database")
+
+	if err = pgClient.MigrateUp(migrationDir); err != nil {
+		logger.Errorf(ctx, "migrate error: %v", err)
+		pgClient.Close()
+		return nil, nil, errors.Wrap(err, "migrate")
+	}
+
+	return pgClient, dbConn, nil
+}
+
+func newBlobStore(cfg *config.Config) (service.BlobStore, error) {
+	if cfg.BlobStore == config.BlobStoreS3 {
+		store, err := blobstore.NewS3(blobstore.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		}, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "s3 blob store")
+		}
+		return store, nil
+	}
+
+	store, err := blobstore.NewLocal(cfg.BlobDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "local blob store")
+	}
+	return store, nil
+}
+
+func newPasswordPolicy(cfg *config.Config) (service.PasswordPolicy, error) {
+	breachedPasswords, err := service.LoadBreachedPasswords(cfg.PasswordBreachedList)
+	if err != nil {
+		return service.PasswordPolicy{}, errors.Wrap(err, "load breached passwords")
+	}
+
+	return service.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MaxLength:      cfg.PasswordMaxLength,
+		RequireUpper:   cfg.PasswordRequireUpper,
+		RequireLower:   cfg.PasswordRequireLower,
+		RequireDigit:   cfg.PasswordRequireDigit,
+		RequireSpecial: cfg.PasswordRequireSpecial,
+		Breached:       breachedPasswords,
+	}, nil
+}
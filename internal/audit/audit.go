@@ -0,0 +1,65 @@
+// Package audit carries the actor and the ID of a request down to the repositories, which record every
+// state change in the audit log in the same transaction as the change itself.
+package audit
+
+import (
+	"context"
+	"reflect"
+)
+
+// Actor is the caller on whose behalf a change is made. APIKeyID is set for calls made with an API key,
+// Dummy for calls made with a token of /dummyLogin, whose UserID is synthetic.
+type Actor struct {
+	UserID   string
+	Role     string
+	APIKeyID string
+	Dummy    bool
+}
+
+type (
+	actorCtxKey     struct{}
+	requestIDCtxKey struct{}
+)
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx. Anonymous calls, such as registration, have none.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorCtxKey{}).(Actor)
+	return actor, ok
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, empty outside of HTTP requests.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// Diff drops the fields that are equal in both states, so only what changed is recorded. Nil states, as
+// before a creation, are kept nil.
+func Diff(before, after map[string]any) (map[string]any, map[string]any) {
+	if before == nil || after == nil {
+		return before, after
+	}
+
+	changedBefore := make(map[string]any, len(before))
+	changedAfter := make(map[string]any, len(after))
+	for k, v := range before {
+		if w, ok := after[k]; !ok || !reflect.DeepEqual(v, w) {
+			changedBefore[k] = v
+		}
+	}
+	for k, w := range after {
+		if v, ok := before[k]; !ok || !reflect.DeepEqual(v, w) {
+			changedAfter[k] = w
+		}
+	}
+
+	return changedBefore, changedAfter
+}
@@ -0,0 +1,74 @@
+//go:build unit
+// +build unit
+
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		before     map[string]any
+		after      map[string]any
+		wantBefore map[string]any
+		wantAfter  map[string]any
+	}{
+		{
+			name:      "creation keeps the whole state",
+			after:     map[string]any{"status": "created", "number": 1},
+			wantAfter: map[string]any{"status": "created", "number": 1},
+		},
+		{
+			name:       "equal fields are dropped",
+			before:     map[string]any{"status": "created", "number": 1},
+			after:      map[string]any{"status": "approved", "number": 1},
+			wantBefore: map[string]any{"status": "created"},
+			wantAfter:  map[string]any{"status": "approved"},
+		},
+		{
+			name:       "added and removed fields are kept",
+			before:     map[string]any{"status": "on moderation", "floor": 3},
+			after:      map[string]any{"status": "declined", "decline_reason": "wrong_price"},
+			wantBefore: map[string]any{"status": "on moderation", "floor": 3},
+			wantAfter:  map[string]any{"status": "declined", "decline_reason": "wrong_price"},
+		},
+		{
+			name:       "no change",
+			before:     map[string]any{"type": "client"},
+			after:      map[string]any{"type": "client"},
+			wantBefore: map[string]any{},
+			wantAfter:  map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			before, after := audit.Diff(tt.before, tt.after)
+			assert.Equal(t, tt.wantBefore, before)
+			assert.Equal(t, tt.wantAfter, after)
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := audit.ActorFromContext(ctx)
+	assert.False(t, ok)
+	assert.Empty(t, audit.RequestIDFromContext(ctx))
+
+	actor := audit.Actor{UserID: "cae36e0f-69e5-4fa8-a179-a52d083c5549", Role: "moderator"}
+	ctx = audit.WithRequestID(audit.WithActor(ctx, actor), "req-1")
+
+	got, ok := audit.ActorFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, actor, got)
+	assert.Equal(t, "req-1", audit.RequestIDFromContext(ctx))
+}
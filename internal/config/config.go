@@ -1,9 +1,14 @@
 package config
 
 import (
+	"net/netip"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
 	"github.com/spf13/viper"
 )
 
@@ -12,13 +17,94 @@ const (
 	configFile = "config.env"
 )
 
+// Supported values of BLOB_STORE.
+const (
+	BlobStoreLocal = "local"
+	BlobStoreS3    = "s3"
+)
+
+// Supported values of APP_ENV.
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
 type Config struct {
+	Env        string `mapstructure:"APP_ENV"`
 	HostAddr   string `mapstructure:"HOST_ADDR"`
 	DBHost     string `mapstructure:"DB_HOST"`
 	DBPort     int    `mapstructure:"DB_PORT"`
 	DBUser     string `mapstructure:"DB_USER"`
 	DBPassword string `mapstructure:"DB_PASSWORD"`
 	DBName     string `mapstructure:"DB_NAME"`
+
+	PasswordMinLength      int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength      int    `mapstructure:"PASSWORD_MAX_LENGTH"`
+	PasswordRequireUpper   bool   `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower   bool   `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireDigit   bool   `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSpecial bool   `mapstructure:"PASSWORD_REQUIRE_SPECIAL"`
+	PasswordBreachedList   string `mapstructure:"PASSWORD_BREACHED_LIST"`
+
+	BlobStore   string `mapstructure:"BLOB_STORE"`
+	BlobDir     string `mapstructure:"BLOB_DIR"`
+	S3Endpoint  string `mapstructure:"S3_ENDPOINT"`
+	S3Bucket    string `mapstructure:"S3_BUCKET"`
+	S3Region    string `mapstructure:"S3_REGION"`
+	S3AccessKey string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey string `mapstructure:"S3_SECRET_KEY"`
+
+	// IdempotencyTTL is how long responses are kept for their Idempotency-Key, e.g. "24h".
+	IdempotencyTTL time.Duration `mapstructure:"IDEMPOTENCY_TTL"`
+	// IdempotencyLockTimeout is how long a request holds its Idempotency-Key before a retry may take the key
+	// over, in case the request never completes. It should exceed the longest request, e.g. "1m".
+	IdempotencyLockTimeout time.Duration `mapstructure:"IDEMPOTENCY_LOCK_TIMEOUT"`
+
+	// RateLimit is the number of requests a caller may make to a route per period, e.g. "300/m", empty for
+	// none. RateLimitRoutes overrides it per route, e.g. "POST /login=10/m,POST /register=5/m".
+	RateLimit       string `mapstructure:"RATE_LIMIT"`
+	RateLimitRoutes string `mapstructure:"RATE_LIMIT_ROUTES"`
+	// RateLimits are RateLimit and RateLimitRoutes parsed.
+	RateLimits ratelimit.Limits `mapstructure:"-"`
+	// RateLimitIP is the number of requests a client IP may make to the protected routes per period, counted
+	// before authentication, so that callers guessing tokens or API keys are limited too.
+	RateLimitIP string `mapstructure:"RATE_LIMIT_IP"`
+	// RateLimitPerIP is RateLimitIP parsed.
+	RateLimitPerIP ratelimit.Limit `mapstructure:"-"`
+	// TrustedProxies are the addresses or CIDR ranges, e.g. "10.0.0.0/8", of the proxies in front of the
+	// service. The client IP of their requests is taken from X-Forwarded-For. None if empty.
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+	// TrustedProxyPrefixes are TrustedProxies parsed.
+	TrustedProxyPrefixes []netip.Prefix `mapstructure:"-"`
+
+	// UnversionedDeprecated and UnversionedSunset are the dates, e.g. "2024-09-04", the routes without a
+	// version prefix were deprecated in favour of /v1 and are to be removed. The deprecated routes tell them
+	// in the Deprecation and Sunset headers.
+	UnversionedDeprecated string `mapstructure:"UNVERSIONED_DEPRECATED"`
+	UnversionedSunset     string `mapstructure:"UNVERSIONED_SUNSET"`
+	// UnversionedDeprecatedAt and UnversionedSunsetAt are the dates above parsed.
+	UnversionedDeprecatedAt time.Time `mapstructure:"-"`
+	UnversionedSunsetAt     time.Time `mapstructure:"-"`
+
+	// CORSAllowedOrigins are the origins, e.g. "https://app.example.com", browsers may call the API from,
+	// "*" for any. None if empty. Credentials, that is cookies and Authorization, are only sent along with
+	// CORSAllowCredentials, which needs the origins listed. CORSMaxAge is how long browsers may cache a
+	// preflight.
+	CORSAllowedOrigins   []string      `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods   []string      `mapstructure:"CORS_ALLOWED_METHODS"`
+	CORSAllowedHeaders   []string      `mapstructure:"CORS_ALLOWED_HEADERS"`
+	CORSExposedHeaders   []string      `mapstructure:"CORS_EXPOSED_HEADERS"`
+	CORSAllowCredentials bool          `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           time.Duration `mapstructure:"CORS_MAX_AGE"`
+
+	// HSTSMaxAge is how long browsers are to reach the service over HTTPS only, 0 for no
+	// Strict-Transport-Security. FrameOptions is the X-Frame-Options of the responses, DENY or SAMEORIGIN.
+	// ContentSecurityPolicy is the policy of the API responses; the docs page has a policy of its own that
+	// allows only the page itself.
+	HSTSMaxAge            time.Duration `mapstructure:"HSTS_MAX_AGE"`
+	FrameOptions          string        `mapstructure:"FRAME_OPTIONS"`
+	ContentSecurityPolicy string        `mapstructure:"CONTENT_SECURITY_POLICY"`
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -29,6 +115,7 @@ func LoadConfig(path string) (*Config, error) {
 	filename := filepath.Base(path)
 	path = filepath.Dir(path)
 
+	viper.SetDefault("APP_ENV", EnvProduction)
 	viper.SetDefault("HOST_ADDR", "0.0.0.0:8080")
 	viper.SetDefault("DB_HOST", "postgres")
 	viper.SetDefault("DB_PORT", 5432)
@@ -36,6 +123,44 @@ func LoadConfig(path string) (*Config, error) {
 	viper.SetDefault("DB_PASSWORD", "postgres")
 	viper.SetDefault("DB_NAME", "postgres")
 
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_MAX_LENGTH", 72)
+	viper.SetDefault("PASSWORD_REQUIRE_UPPER", true)
+	viper.SetDefault("PASSWORD_REQUIRE_LOWER", true)
+	viper.SetDefault("PASSWORD_REQUIRE_DIGIT", true)
+	viper.SetDefault("PASSWORD_REQUIRE_SPECIAL", false)
+	viper.SetDefault("PASSWORD_BREACHED_LIST", "")
+
+	viper.SetDefault("BLOB_STORE", BlobStoreLocal)
+	viper.SetDefault("BLOB_DIR", "/var/lib/house-service/blobs")
+	viper.SetDefault("S3_ENDPOINT", "")
+	viper.SetDefault("S3_BUCKET", "")
+	viper.SetDefault("S3_REGION", "us-east-1")
+	viper.SetDefault("S3_ACCESS_KEY", "")
+	viper.SetDefault("S3_SECRET_KEY", "")
+
+	viper.SetDefault("IDEMPOTENCY_TTL", "24h")
+	viper.SetDefault("IDEMPOTENCY_LOCK_TIMEOUT", "1m")
+
+	viper.SetDefault("RATE_LIMIT", "300/m")
+	viper.SetDefault("RATE_LIMIT_ROUTES", "POST /login=10/m,POST /register=5/m,POST /flat/create=30/m")
+	viper.SetDefault("RATE_LIMIT_IP", "1000/m")
+	viper.SetDefault("TRUSTED_PROXIES", "")
+
+	viper.SetDefault("UNVERSIONED_DEPRECATED", "2024-09-04")
+	viper.SetDefault("UNVERSIONED_SUNSET", "2025-03-01")
+
+	viper.SetDefault("CORS_ALLOWED_ORIGINS", "")
+	viper.SetDefault("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE")
+	viper.SetDefault("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,X-API-Key,If-Match,If-None-Match,Idempotency-Key,X-Request-ID")
+	viper.SetDefault("CORS_EXPOSED_HEADERS", "ETag,Link,Deprecation,Sunset,Retry-After,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,X-Request-ID")
+	viper.SetDefault("CORS_ALLOW_CREDENTIALS", false)
+	viper.SetDefault("CORS_MAX_AGE", "10m")
+
+	viper.SetDefault("HSTS_MAX_AGE", "8760h")
+	viper.SetDefault("FRAME_OPTIONS", "DENY")
+	viper.SetDefault("CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'")
+
 	viper.AddConfigPath(path)
 	viper.SetConfigName(filename)
 	viper.SetConfigType("env")
@@ -49,5 +174,82 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, errors.Wrap(err, "failed to unmarshal config")
 	}
 
+	switch cfg.Env {
+	case EnvDevelopment, EnvTest, EnvProduction:
+	default:
+		return nil, errors.Errorf("unknown APP_ENV %q", cfg.Env)
+	}
+
+	switch cfg.BlobStore {
+	case BlobStoreLocal, BlobStoreS3:
+	default:
+		return nil, errors.Errorf("unknown BLOB_STORE %q", cfg.BlobStore)
+	}
+
+	if cfg.IdempotencyTTL <= 0 {
+		return nil, errors.Errorf("IDEMPOTENCY_TTL must be positive, got %s", cfg.IdempotencyTTL)
+	}
+	if cfg.IdempotencyLockTimeout <= 0 {
+		return nil, errors.Errorf("IDEMPOTENCY_LOCK_TIMEOUT must be positive, got %s", cfg.IdempotencyLockTimeout)
+	}
+
+	rateLimits, err := ratelimit.ParseLimits(cfg.RateLimit, cfg.RateLimitRoutes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid RATE_LIMIT or RATE_LIMIT_ROUTES")
+	}
+	cfg.RateLimits = rateLimits
+
+	if cfg.RateLimitPerIP, err = ratelimit.ParseLimit(cfg.RateLimitIP); err != nil {
+		return nil, errors.Wrap(err, "invalid RATE_LIMIT_IP")
+	}
+
+	for _, proxy := range cfg.TrustedProxies {
+		if proxy = strings.TrimSpace(proxy); proxy == "" {
+			continue
+		}
+		prefix, err := parsePrefix(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid TRUSTED_PROXIES entry %q", proxy)
+		}
+		cfg.TrustedProxyPrefixes = append(cfg.TrustedProxyPrefixes, prefix)
+	}
+
+	if cfg.UnversionedDeprecatedAt, err = time.Parse(time.DateOnly, cfg.UnversionedDeprecated); err != nil {
+		return nil, errors.Wrap(err, "invalid UNVERSIONED_DEPRECATED")
+	}
+	if cfg.UnversionedSunsetAt, err = time.Parse(time.DateOnly, cfg.UnversionedSunset); err != nil {
+		return nil, errors.Wrap(err, "invalid UNVERSIONED_SUNSET")
+	}
+
+	if cfg.CORSAllowCredentials && slices.Contains(cfg.CORSAllowedOrigins, "*") {
+		return nil, errors.New("CORS_ALLOW_CREDENTIALS needs CORS_ALLOWED_ORIGINS listed, not *")
+	}
+
+	switch cfg.FrameOptions {
+	case "DENY", "SAMEORIGIN":
+	default:
+		return nil, errors.Errorf("unknown FRAME_OPTIONS %q", cfg.FrameOptions)
+	}
+
 	return cfg, nil
 }
+
+// DummyLoginEnabled reports whether /dummyLogin may mint tokens. It is never exposed in production.
+func (c *Config) DummyLoginEnabled() bool {
+	return c.Env == EnvDevelopment || c.Env == EnvTest
+}
+
+// parsePrefix reads a CIDR range, or an address as the range of that address alone.
+func parsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix.Masked(), err
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
@@ -1,8 +1,16 @@
 package dto
 
-type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+import "time"
+
+// FlatAttributes are the optional layout details of a flat, shared by requests and responses.
+type FlatAttributes struct {
+	Floor         *int       `json:"floor,omitempty"`
+	TotalArea     *float64   `json:"total_area,omitempty"`
+	LivingArea    *float64   `json:"living_area,omitempty"`
+	Section       *string    `json:"section,omitempty"`
+	CeilingHeight *float64   `json:"ceiling_height,omitempty"`
+	Balcony       *Balcony   `json:"balcony,omitempty"`
+	Finishing     *Finishing `json:"finishing,omitempty"`
 }
 
 type DtoFlat struct {
@@ -12,6 +20,13 @@ type DtoFlat struct {
 	Number  int    `json:"number"`
 	Rooms   int    `json:"rooms"`
 	Price   int    `json:"price"`
+	FlatAttributes
+	PricePerSqm *int       `json:"price_per_sqm,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	Version     int        `json:"version,omitempty"`
+	// CreatedBy is the user who created the flat, nil for flats created before it was recorded.
+	CreatedBy *string `json:"-"`
 }
 
 type CreateFlatRequest struct {
@@ -19,4 +34,34 @@ type CreateFlatRequest struct {
 	Number  int `json:"number"`
 	Rooms   int `json:"rooms"`
 	Price   int `json:"price"`
+	FlatAttributes
+}
+
+type ValidationErrorResponse struct {
+	Message string        `json:"message"`
+	Errors  []ErrorDetail `json:"errors"`
+}
+
+type FlatSearchResult struct {
+	Flats      []*DtoFlat `json:"flats"`
+	NextCursor *string    `json:"next_cursor,omitempty"`
+}
+
+// ModerationQueueItem is a pending flat together with the time it entered its current status.
+type ModerationQueueItem struct {
+	DtoFlat
+	QueuedAt   time.Time `json:"queued_at"`
+	AgeSeconds int64     `json:"age_seconds"`
+}
+
+type ModerationQueue struct {
+	Flats      []*ModerationQueueItem `json:"flats"`
+	Counts     ModerationQueueCounts  `json:"counts"`
+	NextCursor *string                `json:"next_cursor,omitempty"`
+}
+
+// AuditLog is a page of GET /audit.
+type AuditLog struct {
+	Entries    []*AuditEntry `json:"entries"`
+	NextCursor *string       `json:"next_cursor,omitempty"`
 }
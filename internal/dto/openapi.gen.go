@@ -1,18 +1,103 @@
+//go:build go1.22
+
 // Package dto provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package dto
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AuditAction.
+const (
+	AuditAPIKeyCreate      AuditAction = "apikey.create"
+	AuditAPIKeyRevoke      AuditAction = "apikey.revoke"
+	AuditDeclineReasonSave AuditAction = "decline_reason.save"
+	AuditDeveloperCreate   AuditAction = "developer.create"
+	AuditFlatCreate        AuditAction = "flat.create"
+	AuditFlatDelete        AuditAction = "flat.delete"
+	AuditFlatStatus        AuditAction = "flat.status"
+	AuditHouseCreate       AuditAction = "house.create"
+	AuditUserCreate        AuditAction = "user.create"
+	AuditUserDisabled      AuditAction = "user.disabled"
+	AuditUserRole          AuditAction = "user.role"
+)
+
+// Defines values for AuditEntityType.
+const (
+	AuditEntityAPIKey        AuditEntityType = "api_key"
+	AuditEntityDeclineReason AuditEntityType = "decline_reason"
+	AuditEntityDeveloper     AuditEntityType = "developer"
+	AuditEntityFlat          AuditEntityType = "flat"
+	AuditEntityHouse         AuditEntityType = "house"
+	AuditEntityUser          AuditEntityType = "user"
+)
+
+// Defines values for Balcony.
+const (
+	NoBalcony            Balcony = "none"
+	WithBalcony          Balcony = "balcony"
+	WithBalconyAndLoggia Balcony = "balcony_and_loggia"
+	WithLoggia           Balcony = "loggia"
+)
+
+// Defines values for ExportFormat.
+const (
+	ExportCSV   ExportFormat = "csv"
+	ExportJSONL ExportFormat = "jsonl"
+	ExportTSV   ExportFormat = "tsv"
+)
+
+// Defines values for Finishing.
+const (
+	FullFinishing  Finishing = "finished"
+	NoFinishing    Finishing = "none"
+	PreFinishing   Finishing = "pre_finish"
+	RoughFinishing Finishing = "rough"
+)
+
+// Defines values for FlatImportRowStatus.
+const (
+	FlatImported   FlatImportRowStatus = "imported"
+	FlatNotApplied FlatImportRowStatus = "not_applied"
+	FlatRejected   FlatImportRowStatus = "rejected"
+)
+
+// Defines values for FlatMediaKind.
+const (
+	MediaFloorPlan FlatMediaKind = "floor_plan"
+	MediaPhoto     FlatMediaKind = "photo"
+)
+
+// Defines values for FlatSort.
+const (
+	SortByCreatedAt     FlatSort = "created_at"
+	SortByCreatedAtDesc FlatSort = "-created_at"
+	SortByID            FlatSort = "id"
+	SortByPrice         FlatSort = "price"
+	SortByPriceDesc     FlatSort = "-price"
+	SortByRooms         FlatSort = "rooms"
+	SortByRoomsDesc     FlatSort = "-rooms"
+	SortByUpdatedAt     FlatSort = "updated_at"
+	SortByUpdatedAtDesc FlatSort = "-updated_at"
+)
+
 // Defines values for Status.
 const (
 	Approved     Status = "approved"
@@ -23,29 +108,238 @@ const (
 
 // Defines values for UserType.
 const (
-	Client    UserType = "client"
-	Moderator UserType = "moderator"
+	Admin         UserType = "admin"
+	Client        UserType = "client"
+	DeveloperUser UserType = "developer"
+	Moderator     UserType = "moderator"
 )
 
+// APIKey API-ключ
+type APIKey struct {
+	// CreatedAt Дата + время
+	CreatedAt Date `json:"created_at"`
+
+	// Id Идентификатор API-ключа
+	Id APIKeyId `json:"id"`
+
+	// LastUsedAt Дата + время
+	LastUsedAt *Date `json:"last_used_at,omitempty"`
+
+	// Name Название ключа
+	Name string `json:"name"`
+
+	// Prefix Начало ключа, по которому его можно опознать
+	Prefix string `json:"prefix"`
+
+	// RevokedAt Дата + время
+	RevokedAt *Date `json:"revoked_at,omitempty"`
+
+	// Scopes Действия, разрешенные ключу. Не могут выходить за права владельца
+	Scopes APIKeyScopes `json:"scopes"`
+}
+
+// APIKeyCreated defines model for APIKeyCreated.
+type APIKeyCreated struct {
+	// CreatedAt Дата + время
+	CreatedAt Date `json:"created_at"`
+
+	// Id Идентификатор API-ключа
+	Id APIKeyId `json:"id"`
+
+	// Key Секретный ключ. Показывается один раз
+	Key string `json:"key"`
+
+	// LastUsedAt Дата + время
+	LastUsedAt *Date `json:"last_used_at,omitempty"`
+
+	// Name Название ключа
+	Name string `json:"name"`
+
+	// Prefix Начало ключа, по которому его можно опознать
+	Prefix string `json:"prefix"`
+
+	// RevokedAt Дата + время
+	RevokedAt *Date `json:"revoked_at,omitempty"`
+
+	// Scopes Действия, разрешенные ключу. Не могут выходить за права владельца
+	Scopes APIKeyScopes `json:"scopes"`
+}
+
+// APIKeyId Идентификатор API-ключа
+type APIKeyId = openapi_types.UUID
+
+// APIKeyScopes Действия, разрешенные ключу. Не могут выходить за права владельца
+type APIKeyScopes = []string
+
 // Address Адрес дома
 type Address = string
 
+// Area Площадь в квадратных метрах
+type Area = float64
+
+// AuditAction Действие, записанное в журнал
+type AuditAction string
+
+// AuditEntityType Тип измененного объекта
+type AuditEntityType string
+
+// AuditEntry Запись журнала изменений
+type AuditEntry struct {
+	// Action Действие, записанное в журнал
+	Action AuditAction `json:"action"`
+
+	// ActorDummy Действие выполнено с токеном /dummyLogin, actor_id при этом синтетический
+	ActorDummy *bool `json:"actor_dummy,omitempty"`
+
+	// ActorId Идентификатор пользователя
+	ActorId *UserId `json:"actor_id,omitempty"`
+
+	// ActorRole Роль пользователя в момент действия, отсутствует для регистрации
+	ActorRole *string `json:"actor_role,omitempty"`
+
+	// After Значения изменившихся полей после действия
+	After *map[string]interface{} `json:"after,omitempty"`
+
+	// ApiKeyId API-ключ, с которым выполнено действие
+	ApiKeyId *openapi_types.UUID `json:"api_key_id,omitempty"`
+
+	// Before Значения изменившихся полей до действия, отсутствует при создании
+	Before   *map[string]interface{} `json:"before,omitempty"`
+	EntityId string                  `json:"entity_id"`
+
+	// EntityType Тип измененного объекта
+	EntityType AuditEntityType `json:"entity_type"`
+	Id         int64           `json:"id"`
+
+	// OccurredAt Дата + время
+	OccurredAt Date `json:"occurred_at"`
+
+	// RequestId Идентификатор запроса из заголовка X-Request-ID
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// AuditPage Страница журнала изменений
+type AuditPage = AuditLog
+
+// Balcony Балкон или лоджия
+type Balcony string
+
+// Building Номер дома с корпусом или строением
+type Building = string
+
+// CeilingHeight Высота потолков в метрах
+type CeilingHeight = float64
+
+// City Город
+type City = string
+
 // Date Дата + время
 type Date = time.Time
 
+// DeclineReason defines model for DeclineReason.
+type DeclineReason struct {
+	// Active Можно ли выбрать причину при отклонении
+	Active bool `json:"active"`
+
+	// Code Код причины отклонения из справочника
+	Code  DeclineReasonCode `json:"code"`
+	Title string            `json:"title"`
+}
+
+// DeclineReasonCode Код причины отклонения из справочника
+type DeclineReasonCode = string
+
 // Developer Застройщик
 type Developer = string
 
+// DeveloperId Идентификатор застройщика
+type DeveloperId = int
+
+// DeveloperProfile Застройщик
+type DeveloperProfile struct {
+	// CreatedAt Дата + время
+	CreatedAt *Date `json:"created_at,omitempty"`
+
+	// Description Описание застройщика
+	Description *string `json:"description,omitempty"`
+
+	// Email Email застройщика
+	Email *string `json:"email,omitempty"`
+
+	// Id Идентификатор застройщика
+	Id int `json:"id"`
+
+	// Name Название застройщика
+	Name string `json:"name"`
+
+	// Phone Телефон застройщика
+	Phone *string `json:"phone,omitempty"`
+
+	// UserId Идентификатор пользователя
+	UserId *UserId `json:"user_id,omitempty"`
+
+	// Website Сайт застройщика
+	Website *string `json:"website,omitempty"`
+}
+
 // Email Email пользователя
 type Email = openapi_types.Email
 
+// Error Ошибка
+type Error struct {
+	// Code Код ошибки. Предназначен для классификации проблем и более быстрого решения проблем.
+	Code *int `json:"code,omitempty"`
+
+	// Message Описание ошибки
+	Message string `json:"message"`
+
+	// RequestId Идентификатор запроса. Предназначен для более быстрого поиска проблем.
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// ErrorDetail Нарушенное правило проверки
+type ErrorDetail struct {
+	// Field Поле, не прошедшее проверку
+	Field string `json:"field,omitempty"`
+
+	// Message Описание нарушения
+	Message string `json:"message"`
+
+	// Rule Код правила
+	Rule string `json:"rule"`
+}
+
+// ExportFormat Формат выгрузки
+type ExportFormat string
+
+// Finishing Отделка
+type Finishing string
+
 // Flat Квартира
-type Flat struct {
-	// HouseId Идентификатор дома
-	HouseId HouseId `json:"house_id"`
+type Flat = DtoFlat
 
-	// Id Идентификатор квартиры
-	Id FlatId `json:"id"`
+// FlatId Идентификатор квартиры
+type FlatId = int
+
+// FlatImportItem Квартира для импорта
+type FlatImportItem struct {
+	// Balcony Балкон или лоджия
+	Balcony *Balcony `json:"balcony,omitempty"`
+
+	// CeilingHeight Высота потолков в метрах
+	CeilingHeight *CeilingHeight `json:"ceiling_height,omitempty"`
+
+	// Finishing Отделка
+	Finishing *Finishing `json:"finishing,omitempty"`
+
+	// Floor Этаж квартиры, не выше количества этажей в доме
+	Floor *Floor `json:"floor,omitempty"`
+
+	// LivingArea Площадь в квадратных метрах
+	LivingArea *Area `json:"living_area,omitempty"`
+
+	// Number Номер квартиры в доме
+	Number FlatNumber `json:"number"`
 
 	// Price Цена квартиры в у.е.
 	Price Price `json:"price"`
@@ -53,71 +347,366 @@ type Flat struct {
 	// Rooms Количество комнат в квартире
 	Rooms Rooms `json:"rooms"`
 
-	// Status Статус квартиры
-	Status Status `json:"status"`
+	// Section Подъезд или секция
+	Section *Section `json:"section,omitempty"`
+
+	// TotalArea Площадь в квадратных метрах
+	TotalArea *Area `json:"total_area,omitempty"`
 }
 
-// FlatId Идентификатор квартиры
-type FlatId = int
+// FlatImportResult Результат импорта квартир
+type FlatImportResult struct {
+	// Atomic Импорт выполнялся по принципу все или ничего
+	Atomic bool `json:"atomic"`
+
+	// Imported Количество созданных квартир
+	Imported int `json:"imported"`
+
+	// Rejected Количество отклоненных строк
+	Rejected int             `json:"rejected"`
+	Rows     []FlatImportRow `json:"rows"`
+}
+
+// FlatImportRow Результат импорта одной строки
+type FlatImportRow struct {
+	Errors []ErrorDetail `json:"errors,omitempty"`
+
+	// FlatId Идентификатор квартиры
+	FlatId *FlatId `json:"flat_id,omitempty"`
+
+	// Number Номер квартиры в доме
+	Number *FlatNumber `json:"number,omitempty"`
+
+	// Row Номер строки во входных данных, начиная с 1
+	Row int `json:"row"`
+
+	// Status imported - квартира создана, rejected - строка отклонена, not_applied - строка корректна, но атомарный импорт был отменен
+	Status FlatImportRowStatus `json:"status"`
+}
+
+// FlatImportRowStatus imported - квартира создана, rejected - строка отклонена, not_applied - строка корректна, но атомарный импорт был отменен
+type FlatImportRowStatus string
+
+// FlatMedia defines model for FlatMedia.
+type FlatMedia struct {
+	ContentType string `json:"content_type"`
+
+	// CreatedAt Дата + время
+	CreatedAt Date `json:"created_at"`
+
+	// FlatId Идентификатор квартиры
+	FlatId FlatId `json:"flat_id"`
+
+	// HasThumbnail Есть ли превью, строится только для изображений
+	HasThumbnail bool `json:"has_thumbnail"`
+
+	// Id Идентификатор вложения
+	Id FlatMediaId `json:"id"`
+
+	// Kind Фотография или планировка
+	Kind FlatMediaKind `json:"kind"`
+
+	// Size Размер файла в байтах
+	Size int64 `json:"size"`
+}
+
+// FlatMediaId Идентификатор вложения
+type FlatMediaId = int
+
+// FlatMediaKind Фотография или планировка
+type FlatMediaKind string
+
+// FlatNumber Номер квартиры в доме
+type FlatNumber = int
+
+// FlatSearchPage Страница поиска квартир
+type FlatSearchPage = FlatSearchResult
+
+// FlatSort Поле сортировки, минус означает сортировку по убыванию
+type FlatSort string
+
+// Floor Этаж квартиры, не выше количества этажей в доме
+type Floor = int
+
+// Floors Количество этажей в доме
+type Floors = int
 
 // House Дом
 type House struct {
 	// Address Адрес дома
 	Address Address `json:"address"`
 
+	// Building Номер дома с корпусом или строением
+	Building *Building `json:"building,omitempty"`
+
+	// City Город
+	City *City `json:"city,omitempty"`
+
+	// CreatedAt Дата + время
+	CreatedAt *Date `json:"created_at,omitempty"`
+
+	// Developer Застройщик
+	Developer *Developer `json:"developer"`
+
+	// DeveloperId Идентификатор застройщика
+	DeveloperId *DeveloperId `json:"developer_id"`
+
+	// Floors Количество этажей в доме
+	Floors *Floors `json:"floors,omitempty"`
+
+	// Id Идентификатор дома
+	Id HouseId `json:"id"`
+
+	// Latitude Широта
+	Latitude *Latitude `json:"latitude,omitempty"`
+
+	// Longitude Долгота
+	Longitude *Longitude `json:"longitude,omitempty"`
+
+	// PostalCode Почтовый индекс
+	PostalCode *PostalCode `json:"postal_code,omitempty"`
+
+	// Street Улица
+	Street *Street `json:"street,omitempty"`
+
+	// UpdateAt Дата + время
+	UpdateAt *Date `json:"update_at,omitempty"`
+
+	// Version Версия объекта, увеличивается при каждом изменении. ETag объекта - версия в кавычках
+	Version *Version `json:"version,omitempty"`
+
+	// Year Год постройки дома
+	Year Year `json:"year"`
+}
+
+// HouseCreated defines model for HouseCreated.
+type HouseCreated struct {
+	// Address Адрес дома
+	Address Address `json:"address"`
+
+	// Building Номер дома с корпусом или строением
+	Building *Building `json:"building,omitempty"`
+
+	// City Город
+	City *City `json:"city,omitempty"`
+
 	// CreatedAt Дата + время
 	CreatedAt *Date `json:"created_at,omitempty"`
 
 	// Developer Застройщик
 	Developer *Developer `json:"developer"`
 
+	// DeveloperId Идентификатор застройщика
+	DeveloperId *DeveloperId `json:"developer_id"`
+
+	// Floors Количество этажей в доме
+	Floors *Floors `json:"floors,omitempty"`
+
 	// Id Идентификатор дома
 	Id HouseId `json:"id"`
 
+	// Latitude Широта
+	Latitude *Latitude `json:"latitude,omitempty"`
+
+	// Longitude Долгота
+	Longitude *Longitude `json:"longitude,omitempty"`
+
+	// PostalCode Почтовый индекс
+	PostalCode *PostalCode `json:"postal_code,omitempty"`
+
+	// Similar Существующие дома с почти совпадающим адресом
+	Similar []HouseMatch `json:"similar,omitempty"`
+
+	// Street Улица
+	Street *Street `json:"street,omitempty"`
+
 	// UpdateAt Дата + время
 	UpdateAt *Date `json:"update_at,omitempty"`
 
+	// Version Версия объекта, увеличивается при каждом изменении. ETag объекта - версия в кавычках
+	Version *Version `json:"version,omitempty"`
+
 	// Year Год постройки дома
 	Year Year `json:"year"`
 }
 
+// HouseFlats Квартиры дома
+type HouseFlats struct {
+	Flats []Flat `json:"flats"`
+}
+
 // HouseId Идентификатор дома
 type HouseId = int
 
+// HouseMatch Дом, найденный по адресу
+type HouseMatch struct {
+	// House Дом
+	House House `json:"house"`
+
+	// Score Релевантность от 0 до 1
+	Score float32 `json:"score"`
+}
+
+// HouseNearby Дом рядом с точкой
+type HouseNearby struct {
+	// DistanceKm Расстояние до точки в километрах
+	DistanceKm float64 `json:"distance_km"`
+
+	// House Дом
+	House House `json:"house"`
+}
+
+// Latitude Широта
+type Latitude = float64
+
+// Longitude Долгота
+type Longitude = float64
+
+// ModerationComment Комментарий модератора
+type ModerationComment = string
+
+// ModerationDecision Решение модератора по квартире
+type ModerationDecision struct {
+	// Comment Комментарий модератора
+	Comment *ModerationComment `json:"comment,omitempty"`
+
+	// CreatedAt Дата + время
+	CreatedAt Date `json:"created_at"`
+
+	// FlatId Идентификатор квартиры
+	FlatId FlatId `json:"flat_id"`
+	Id     int    `json:"id"`
+
+	// ModeratorId Идентификатор пользователя
+	ModeratorId *UserId        `json:"moderator_id,omitempty"`
+	Reason      *DeclineReason `json:"reason,omitempty"`
+
+	// Status Статус квартиры
+	Status Status `json:"status"`
+}
+
+// ModerationQueueCounts Количество квартир в очереди по статусам
+type ModerationQueueCounts struct {
+	Created      int `json:"created"`
+	OnModeration int `json:"on_moderation"`
+}
+
+// ModerationQueuePage Страница очереди модерации
+type ModerationQueuePage = ModerationQueue
+
 // Password Пароль пользователя
 type Password = string
 
+// PostalCode Почтовый индекс
+type PostalCode = string
+
 // Price Цена квартиры в у.е.
 type Price = int
 
 // Rooms Количество комнат в квартире
 type Rooms = int
 
+// Section Подъезд или секция
+type Section = string
+
 // Status Статус квартиры
 type Status string
 
+// Street Улица
+type Street = string
+
 // Token Авторизационный токен
 type Token = string
 
+// User Пользователь
+type User struct {
+	// Disabled Учетная запись заблокирована
+	Disabled bool `json:"disabled"`
+
+	// Email Email пользователя
+	Email Email `json:"email"`
+
+	// Id Идентификатор пользователя
+	Id UserId `json:"id"`
+
+	// UserType Тип пользователя
+	UserType UserType `json:"user_type"`
+}
+
 // UserId Идентификатор пользователя
 type UserId = openapi_types.UUID
 
 // UserType Тип пользователя
 type UserType string
 
+// Version Версия объекта, увеличивается при каждом изменении. ETag объекта - версия в кавычках
+type Version = int
+
 // Year Год постройки дома
 type Year = int
 
-// N5xx defines model for 5xx.
-type N5xx struct {
-	// Code Код ошибки. Предназначен для классификации проблем и более быстрого решения проблем.
-	Code *int `json:"code,omitempty"`
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
 
-	// Message Описание ошибки
-	Message string `json:"message"`
+// N429 Ошибка
+type N429 = Error
 
-	// RequestId Идентификатор запроса. Предназначен для более быстрого поиска проблем.
-	RequestId *string `json:"request_id,omitempty"`
+// N5xx Ошибка
+type N5xx = Error
+
+// PostAdminUsersIdRoleJSONBody defines parameters for PostAdminUsersIdRole.
+type PostAdminUsersIdRoleJSONBody struct {
+	// UserType Тип пользователя
+	UserType UserType `json:"user_type"`
+}
+
+// PostApiKeysJSONBody defines parameters for PostApiKeys.
+type PostApiKeysJSONBody struct {
+	// Name Название ключа
+	Name string `json:"name"`
+
+	// Scopes Действия, разрешенные ключу. Не могут выходить за права владельца
+	Scopes APIKeyScopes `json:"scopes"`
+}
+
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	ActorId    *UserId          `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+	Action     *AuditAction     `form:"action,omitempty" json:"action,omitempty"`
+	EntityType *AuditEntityType `form:"entity_type,omitempty" json:"entity_type,omitempty"`
+	EntityId   *string          `form:"entity_id,omitempty" json:"entity_id,omitempty"`
+	RequestId  *string          `form:"request_id,omitempty" json:"request_id,omitempty"`
+
+	// From Начало периода включительно
+	From *Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода, не включая
+	To    *Date `form:"to,omitempty" json:"to,omitempty"`
+	Limit *int  `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Значение next_cursor предыдущей страницы
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PostDevelopersJSONBody defines parameters for PostDevelopers.
+type PostDevelopersJSONBody struct {
+	// Description Описание застройщика
+	Description *string `json:"description,omitempty"`
+
+	// Email Email пользователя
+	Email *Email `json:"email,omitempty"`
+
+	// Name Название застройщика
+	Name string `json:"name"`
+
+	// Phone Телефон застройщика
+	Phone *string `json:"phone,omitempty"`
+
+	// UserId Идентификатор пользователя
+	UserId *UserId `json:"user_id,omitempty"`
+
+	// Website Сайт застройщика
+	Website *string `json:"website,omitempty"`
 }
 
 // GetDummyLoginParams defines parameters for GetDummyLogin.
@@ -126,44 +715,173 @@ type GetDummyLoginParams struct {
 }
 
 // PostFlatCreateJSONBody defines parameters for PostFlatCreate.
-type PostFlatCreateJSONBody struct {
-	// HouseId Идентификатор дома
-	HouseId HouseId `json:"house_id"`
-
-	// Price Цена квартиры в у.е.
-	Price Price `json:"price"`
+type PostFlatCreateJSONBody = CreateFlatRequest
 
-	// Rooms Количество комнат в квартире
-	Rooms *Rooms `json:"rooms,omitempty"`
+// PostFlatCreateParams defines parameters for PostFlatCreate.
+type PostFlatCreateParams struct {
+	// IdempotencyKey Ключ идемпотентности, уникальный для каждого нового запроса пользователя. Ответ на первый запрос хранится ограниченное время, повторы с тем же ключом и телом получают его с заголовком Idempotent-Replayed. Ответы 5xx не сохраняются
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
 // PostFlatUpdateJSONBody defines parameters for PostFlatUpdate.
 type PostFlatUpdateJSONBody struct {
+	// Comment Комментарий модератора
+	Comment *ModerationComment `json:"comment,omitempty"`
+
 	// Id Идентификатор квартиры
 	Id FlatId `json:"id"`
 
+	// Reason Код причины отклонения из справочника
+	Reason *DeclineReasonCode `json:"reason,omitempty"`
+
 	// Status Статус квартиры
 	Status *Status `json:"status,omitempty"`
 }
 
-// PostHouseCreateJSONBody defines parameters for PostHouseCreate.
-type PostHouseCreateJSONBody struct {
-	// Address Адрес дома
-	Address Address `json:"address"`
+// PostFlatUpdateParams defines parameters for PostFlatUpdate.
+type PostFlatUpdateParams struct {
+	// IfMatch ETag квартиры, полученный при чтении
+	IfMatch string `json:"If-Match"`
+}
 
-	// Developer Застройщик
-	Developer *Developer `json:"developer"`
+// PostFlatIdMediaParams defines parameters for PostFlatIdMedia.
+type PostFlatIdMediaParams struct {
+	Kind *FlatMediaKind `form:"kind,omitempty" json:"kind,omitempty"`
+}
 
-	// Year Год постройки дома
-	Year Year `json:"year"`
+// GetFlatIdMediaMediaIdParams defines parameters for GetFlatIdMediaMediaId.
+type GetFlatIdMediaMediaIdParams struct {
+	// Thumbnail Вернуть превью в JPEG вместо исходного файла
+	Thumbnail *bool `form:"thumbnail,omitempty" json:"thumbnail,omitempty"`
 }
 
-// PostHouseIdSubscribeJSONBody defines parameters for PostHouseIdSubscribe.
+// GetFlatsExportParams defines parameters for GetFlatsExport.
+type GetFlatsExportParams struct {
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetFlatsSearchParams defines parameters for GetFlatsSearch.
+type GetFlatsSearchParams struct {
+	Rooms *Rooms `form:"rooms,omitempty" json:"rooms,omitempty"`
+
+	// MinPrice Минимальная цена
+	MinPrice *int `form:"min_price,omitempty" json:"min_price,omitempty"`
+
+	// MaxPrice Максимальная цена
+	MaxPrice *int `form:"max_price,omitempty" json:"max_price,omitempty"`
+
+	// MinYear Год постройки дома не раньше
+	MinYear *int `form:"min_year,omitempty" json:"min_year,omitempty"`
+
+	// MaxYear Год постройки дома не позже
+	MaxYear     *int         `form:"max_year,omitempty" json:"max_year,omitempty"`
+	DeveloperId *DeveloperId `form:"developer_id,omitempty" json:"developer_id,omitempty"`
+
+	// MinArea Общая площадь не меньше
+	MinArea *Area `form:"min_area,omitempty" json:"min_area,omitempty"`
+
+	// MaxArea Общая площадь не больше
+	MaxArea *Area `form:"max_area,omitempty" json:"max_area,omitempty"`
+
+	// MinFloor Этаж не ниже
+	MinFloor *Floor `form:"min_floor,omitempty" json:"min_floor,omitempty"`
+
+	// MaxFloor Этаж не выше
+	MaxFloor  *Floor     `form:"max_floor,omitempty" json:"max_floor,omitempty"`
+	Balcony   *Balcony   `form:"balcony,omitempty" json:"balcony,omitempty"`
+	Finishing *Finishing `form:"finishing,omitempty" json:"finishing,omitempty"`
+	Status    *Status    `form:"status,omitempty" json:"status,omitempty"`
+	Sort      *FlatSort  `form:"sort,omitempty" json:"sort,omitempty"`
+	Limit     *int       `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PostHouseCreateJSONBody defines parameters for PostHouseCreate.
+type PostHouseCreateJSONBody struct {
+	// Address Адрес дома
+	Address Address `json:"address"`
+
+	// Building Номер дома с корпусом или строением
+	Building *Building `json:"building,omitempty"`
+
+	// City Город
+	City *City `json:"city,omitempty"`
+
+	// Developer Застройщик
+	Developer *Developer `json:"developer"`
+
+	// DeveloperId Идентификатор застройщика
+	DeveloperId *DeveloperId `json:"developer_id"`
+
+	// Floors Количество этажей в доме
+	Floors *Floors `json:"floors,omitempty"`
+
+	// Latitude Широта
+	Latitude *Latitude `json:"latitude,omitempty"`
+
+	// Longitude Долгота
+	Longitude *Longitude `json:"longitude,omitempty"`
+
+	// PostalCode Почтовый индекс
+	PostalCode *PostalCode `json:"postal_code,omitempty"`
+
+	// Street Улица
+	Street *Street `json:"street,omitempty"`
+
+	// Year Год постройки дома
+	Year Year `json:"year"`
+}
+
+// PostHouseCreateParams defines parameters for PostHouseCreate.
+type PostHouseCreateParams struct {
+	// IdempotencyKey Ключ идемпотентности, уникальный для каждого нового запроса пользователя. Ответ на первый запрос хранится ограниченное время, повторы с тем же ключом и телом получают его с заголовком Idempotent-Replayed. Ответы 5xx не сохраняются
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetHouseIdParams defines parameters for GetHouseId.
+type GetHouseIdParams struct {
+	// IfNoneMatch ETag, полученный в предыдущем ответе
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetHouseIdExportParams defines parameters for GetHouseIdExport.
+type GetHouseIdExportParams struct {
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// PostHouseIdFlatsImportJSONBody defines parameters for PostHouseIdFlatsImport.
+type PostHouseIdFlatsImportJSONBody = []FlatImportItem
+
+// PostHouseIdFlatsImportParams defines parameters for PostHouseIdFlatsImport.
+type PostHouseIdFlatsImportParams struct {
+	Atomic *bool `form:"atomic,omitempty" json:"atomic,omitempty"`
+}
+
+// PostHouseIdSubscribeJSONBody defines parameters for PostHouseIdSubscribe.
 type PostHouseIdSubscribeJSONBody struct {
 	// Email Email пользователя
 	Email Email `json:"email"`
 }
 
+// GetHousesNearbyParams defines parameters for GetHousesNearby.
+type GetHousesNearbyParams struct {
+	Lat Latitude  `form:"lat" json:"lat"`
+	Lon Longitude `form:"lon" json:"lon"`
+
+	// RadiusKm Радиус поиска в километрах
+	RadiusKm *float64 `form:"radius_km,omitempty" json:"radius_km,omitempty"`
+	Limit    *int     `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetHousesSearchParams defines parameters for GetHousesSearch.
+type GetHousesSearchParams struct {
+	Q     string `form:"q" json:"q"`
+	City  *City  `form:"city,omitempty" json:"city,omitempty"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // PostLoginJSONBody defines parameters for PostLogin.
 type PostLoginJSONBody struct {
 	// Id Идентификатор пользователя
@@ -173,6 +891,25 @@ type PostLoginJSONBody struct {
 	Password *Password `json:"password,omitempty"`
 }
 
+// PutModerationDeclineReasonsCodeJSONBody defines parameters for PutModerationDeclineReasonsCode.
+type PutModerationDeclineReasonsCodeJSONBody struct {
+	Active *bool  `json:"active,omitempty"`
+	Title  string `json:"title"`
+}
+
+// GetModerationQueueParams defines parameters for GetModerationQueue.
+type GetModerationQueueParams struct {
+	HouseId     *HouseId     `form:"house_id,omitempty" json:"house_id,omitempty"`
+	DeveloperId *DeveloperId `form:"developer_id,omitempty" json:"developer_id,omitempty"`
+
+	// Status created или on moderation, по умолчанию оба
+	Status *Status `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Значение next_cursor предыдущей страницы
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
 // PostRegisterJSONBody defines parameters for PostRegister.
 type PostRegisterJSONBody struct {
 	// Email Email пользователя
@@ -185,8 +922,17 @@ type PostRegisterJSONBody struct {
 	UserType *UserType `json:"user_type,omitempty"`
 }
 
+// PostAdminUsersIdRoleJSONRequestBody defines body for PostAdminUsersIdRole for application/json ContentType.
+type PostAdminUsersIdRoleJSONRequestBody PostAdminUsersIdRoleJSONBody
+
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody PostApiKeysJSONBody
+
+// PostDevelopersJSONRequestBody defines body for PostDevelopers for application/json ContentType.
+type PostDevelopersJSONRequestBody PostDevelopersJSONBody
+
 // PostFlatCreateJSONRequestBody defines body for PostFlatCreate for application/json ContentType.
-type PostFlatCreateJSONRequestBody PostFlatCreateJSONBody
+type PostFlatCreateJSONRequestBody = PostFlatCreateJSONBody
 
 // PostFlatUpdateJSONRequestBody defines body for PostFlatUpdate for application/json ContentType.
 type PostFlatUpdateJSONRequestBody PostFlatUpdateJSONBody
@@ -194,11 +940,5251 @@ type PostFlatUpdateJSONRequestBody PostFlatUpdateJSONBody
 // PostHouseCreateJSONRequestBody defines body for PostHouseCreate for application/json ContentType.
 type PostHouseCreateJSONRequestBody PostHouseCreateJSONBody
 
+// PostHouseIdFlatsImportJSONRequestBody defines body for PostHouseIdFlatsImport for application/json ContentType.
+type PostHouseIdFlatsImportJSONRequestBody = PostHouseIdFlatsImportJSONBody
+
 // PostHouseIdSubscribeJSONRequestBody defines body for PostHouseIdSubscribe for application/json ContentType.
 type PostHouseIdSubscribeJSONRequestBody PostHouseIdSubscribeJSONBody
 
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PutModerationDeclineReasonsCodeJSONRequestBody defines body for PutModerationDeclineReasonsCode for application/json ContentType.
+type PutModerationDeclineReasonsCodeJSONRequestBody PutModerationDeclineReasonsCodeJSONBody
+
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (POST /admin/users/{id}/disable)
+	PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request, id UserId)
+
+	// (POST /admin/users/{id}/enable)
+	PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request, id UserId)
+
+	// (POST /admin/users/{id}/role)
+	PostAdminUsersIdRole(w http.ResponseWriter, r *http.Request, id UserId)
+
+	// (GET /api-keys)
+	GetApiKeys(w http.ResponseWriter, r *http.Request)
+
+	// (POST /api-keys)
+	PostApiKeys(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id APIKeyId)
+
+	// (GET /audit)
+	GetAudit(w http.ResponseWriter, r *http.Request, params GetAuditParams)
+
+	// (GET /developers)
+	GetDevelopers(w http.ResponseWriter, r *http.Request)
+
+	// (POST /developers)
+	PostDevelopers(w http.ResponseWriter, r *http.Request)
+
+	// (GET /developers/{id}/houses)
+	GetDevelopersIdHouses(w http.ResponseWriter, r *http.Request, id DeveloperId)
+
+	// (GET /docs)
+	GetDocs(w http.ResponseWriter, r *http.Request)
+
+	// (GET /dummyLogin)
+	GetDummyLogin(w http.ResponseWriter, r *http.Request, params GetDummyLoginParams)
+
+	// (POST /flat/create)
+	PostFlatCreate(w http.ResponseWriter, r *http.Request, params PostFlatCreateParams)
+
+	// (POST /flat/update)
+	PostFlatUpdate(w http.ResponseWriter, r *http.Request, params PostFlatUpdateParams)
+
+	// (DELETE /flat/{id})
+	DeleteFlatId(w http.ResponseWriter, r *http.Request, id FlatId)
+
+	// (GET /flat/{id}/decisions)
+	GetFlatIdDecisions(w http.ResponseWriter, r *http.Request, id FlatId)
+
+	// (GET /flat/{id}/media)
+	GetFlatIdMedia(w http.ResponseWriter, r *http.Request, id FlatId)
+
+	// (POST /flat/{id}/media)
+	PostFlatIdMedia(w http.ResponseWriter, r *http.Request, id FlatId, params PostFlatIdMediaParams)
+
+	// (DELETE /flat/{id}/media/{media_id})
+	DeleteFlatIdMediaMediaId(w http.ResponseWriter, r *http.Request, id FlatId, mediaId FlatMediaId)
+
+	// (GET /flat/{id}/media/{media_id})
+	GetFlatIdMediaMediaId(w http.ResponseWriter, r *http.Request, id FlatId, mediaId FlatMediaId, params GetFlatIdMediaMediaIdParams)
+
+	// (GET /flats/export)
+	GetFlatsExport(w http.ResponseWriter, r *http.Request, params GetFlatsExportParams)
+
+	// (GET /flats/search)
+	GetFlatsSearch(w http.ResponseWriter, r *http.Request, params GetFlatsSearchParams)
+
+	// (POST /house/create)
+	PostHouseCreate(w http.ResponseWriter, r *http.Request, params PostHouseCreateParams)
+
+	// (GET /house/{id})
+	GetHouseId(w http.ResponseWriter, r *http.Request, id HouseId, params GetHouseIdParams)
+
+	// (GET /house/{id}/export)
+	GetHouseIdExport(w http.ResponseWriter, r *http.Request, id HouseId, params GetHouseIdExportParams)
+
+	// (POST /house/{id}/flats/import)
+	PostHouseIdFlatsImport(w http.ResponseWriter, r *http.Request, id HouseId, params PostHouseIdFlatsImportParams)
+
+	// (POST /house/{id}/subscribe)
+	PostHouseIdSubscribe(w http.ResponseWriter, r *http.Request, id HouseId)
+
+	// (GET /houses/nearby)
+	GetHousesNearby(w http.ResponseWriter, r *http.Request, params GetHousesNearbyParams)
+
+	// (GET /houses/search)
+	GetHousesSearch(w http.ResponseWriter, r *http.Request, params GetHousesSearchParams)
+
+	// (POST /login)
+	PostLogin(w http.ResponseWriter, r *http.Request)
+
+	// (GET /moderation/decline-reasons)
+	GetModerationDeclineReasons(w http.ResponseWriter, r *http.Request)
+
+	// (PUT /moderation/decline-reasons/{code})
+	PutModerationDeclineReasonsCode(w http.ResponseWriter, r *http.Request, code DeclineReasonCode)
+
+	// (GET /moderation/queue)
+	GetModerationQueue(w http.ResponseWriter, r *http.Request, params GetModerationQueueParams)
+
+	// (GET /openapi.json)
+	GetOpenapiJson(w http.ResponseWriter, r *http.Request)
+
+	// (GET /openapi.yaml)
+	GetOpenapiYaml(w http.ResponseWriter, r *http.Request)
+
+	// (POST /register)
+	PostRegister(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// PostAdminUsersIdDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdDisable(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminUsersIdEnable operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdEnable(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminUsersIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdRole(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiKeysId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id APIKeyId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiKeysId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAudit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditParams
+
+	// ------------- Optional query parameter "actor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor_id", r.URL.Query(), &params.ActorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "entity_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_type", r.URL.Query(), &params.EntityType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entity_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "entity_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_id", r.URL.Query(), &params.EntityId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entity_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "request_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "request_id", r.URL.Query(), &params.RequestId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "request_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAudit(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDevelopers operation middleware
+func (siw *ServerInterfaceWrapper) GetDevelopers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDevelopers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDevelopers operation middleware
+func (siw *ServerInterfaceWrapper) PostDevelopers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDevelopers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDevelopersIdHouses operation middleware
+func (siw *ServerInterfaceWrapper) GetDevelopersIdHouses(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id DeveloperId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDevelopersIdHouses(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDocs operation middleware
+func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDummyLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetDummyLogin(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDummyLoginParams
+
+	// ------------- Required query parameter "user_type" -------------
+
+	if paramValue := r.URL.Query().Get("user_type"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_type"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_type", r.URL.Query(), &params.UserType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDummyLogin(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostFlatCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostFlatCreate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostFlatCreateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostFlatCreate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostFlatUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PostFlatUpdate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostFlatUpdateParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	} else {
+		err := fmt.Errorf("Header parameter If-Match is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "If-Match", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostFlatUpdate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteFlatId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFlatId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id FlatId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFlatId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFlatIdDecisions operation middleware
+func (siw *ServerInterfaceWrapper) GetFlatIdDecisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id FlatId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlatIdDecisions(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFlatIdMedia operation middleware
+func (siw *ServerInterfaceWrapper) GetFlatIdMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id FlatId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlatIdMedia(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostFlatIdMedia operation middleware
+func (siw *ServerInterfaceWrapper) PostFlatIdMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id FlatId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostFlatIdMediaParams
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostFlatIdMedia(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteFlatIdMediaMediaId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFlatIdMediaMediaId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id FlatId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "media_id" -------------
+	var mediaId FlatMediaId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "media_id", r.PathValue("media_id"), &mediaId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "media_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFlatIdMediaMediaId(w, r, id, mediaId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFlatIdMediaMediaId operation middleware
+func (siw *ServerInterfaceWrapper) GetFlatIdMediaMediaId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id FlatId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "media_id" -------------
+	var mediaId FlatMediaId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "media_id", r.PathValue("media_id"), &mediaId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "media_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlatIdMediaMediaIdParams
+
+	// ------------- Optional query parameter "thumbnail" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "thumbnail", r.URL.Query(), &params.Thumbnail)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thumbnail", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlatIdMediaMediaId(w, r, id, mediaId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFlatsExport operation middleware
+func (siw *ServerInterfaceWrapper) GetFlatsExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlatsExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlatsExport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFlatsSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetFlatsSearch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFlatsSearchParams
+
+	// ------------- Optional query parameter "rooms" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rooms", r.URL.Query(), &params.Rooms)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rooms", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_price", r.URL.Query(), &params.MinPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_price", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_price", r.URL.Query(), &params.MaxPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_price", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_year", r.URL.Query(), &params.MinYear)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_year", r.URL.Query(), &params.MaxYear)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "developer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "developer_id", r.URL.Query(), &params.DeveloperId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "developer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_area" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_area", r.URL.Query(), &params.MinArea)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_area", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_area" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_area", r.URL.Query(), &params.MaxArea)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_area", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_floor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_floor", r.URL.Query(), &params.MinFloor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_floor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_floor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_floor", r.URL.Query(), &params.MaxFloor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_floor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "balcony" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "balcony", r.URL.Query(), &params.Balcony)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "balcony", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "finishing" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "finishing", r.URL.Query(), &params.Finishing)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "finishing", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFlatsSearch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostHouseCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostHouseCreate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostHouseCreateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHouseCreate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseId operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id HouseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHouseIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseIdExport operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseIdExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id HouseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHouseIdExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseIdExport(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostHouseIdFlatsImport operation middleware
+func (siw *ServerInterfaceWrapper) PostHouseIdFlatsImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id HouseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostHouseIdFlatsImportParams
+
+	// ------------- Optional query parameter "atomic" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "atomic", r.URL.Query(), &params.Atomic)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "atomic", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHouseIdFlatsImport(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostHouseIdSubscribe operation middleware
+func (siw *ServerInterfaceWrapper) PostHouseIdSubscribe(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id HouseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHouseIdSubscribe(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHousesNearby operation middleware
+func (siw *ServerInterfaceWrapper) GetHousesNearby(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHousesNearbyParams
+
+	// ------------- Required query parameter "lat" -------------
+
+	if paramValue := r.URL.Query().Get("lat"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "lat"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lat", r.URL.Query(), &params.Lat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lat", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "lon" -------------
+
+	if paramValue := r.URL.Query().Get("lon"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "lon"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "lon", r.URL.Query(), &params.Lon)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lon", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "radius_km" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radius_km", r.URL.Query(), &params.RadiusKm)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "radius_km", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHousesNearby(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHousesSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetHousesSearch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHousesSearchParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "city" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city", r.URL.Query(), &params.City)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "city", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHousesSearch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetModerationDeclineReasons operation middleware
+func (siw *ServerInterfaceWrapper) GetModerationDeclineReasons(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetModerationDeclineReasons(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutModerationDeclineReasonsCode operation middleware
+func (siw *ServerInterfaceWrapper) PutModerationDeclineReasonsCode(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code DeclineReasonCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", r.PathValue("code"), &code, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutModerationDeclineReasonsCode(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetModerationQueue operation middleware
+func (siw *ServerInterfaceWrapper) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModerationQueueParams
+
+	// ------------- Optional query parameter "house_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "house_id", r.URL.Query(), &params.HouseId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "house_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "developer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "developer_id", r.URL.Query(), &params.DeveloperId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "developer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetModerationQueue(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOpenapiJson operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenapiJson(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenapiJson(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOpenapiYaml operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenapiYaml(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenapiYaml(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("POST "+options.BaseURL+"/admin/users/{id}/disable", wrapper.PostAdminUsersIdDisable)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/users/{id}/enable", wrapper.PostAdminUsersIdEnable)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/users/{id}/role", wrapper.PostAdminUsersIdRole)
+	m.HandleFunc("GET "+options.BaseURL+"/api-keys", wrapper.GetApiKeys)
+	m.HandleFunc("POST "+options.BaseURL+"/api-keys", wrapper.PostApiKeys)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api-keys/{id}", wrapper.DeleteApiKeysId)
+	m.HandleFunc("GET "+options.BaseURL+"/audit", wrapper.GetAudit)
+	m.HandleFunc("GET "+options.BaseURL+"/developers", wrapper.GetDevelopers)
+	m.HandleFunc("POST "+options.BaseURL+"/developers", wrapper.PostDevelopers)
+	m.HandleFunc("GET "+options.BaseURL+"/developers/{id}/houses", wrapper.GetDevelopersIdHouses)
+	m.HandleFunc("GET "+options.BaseURL+"/docs", wrapper.GetDocs)
+	m.HandleFunc("GET "+options.BaseURL+"/dummyLogin", wrapper.GetDummyLogin)
+	m.HandleFunc("POST "+options.BaseURL+"/flat/create", wrapper.PostFlatCreate)
+	m.HandleFunc("POST "+options.BaseURL+"/flat/update", wrapper.PostFlatUpdate)
+	m.HandleFunc("DELETE "+options.BaseURL+"/flat/{id}", wrapper.DeleteFlatId)
+	m.HandleFunc("GET "+options.BaseURL+"/flat/{id}/decisions", wrapper.GetFlatIdDecisions)
+	m.HandleFunc("GET "+options.BaseURL+"/flat/{id}/media", wrapper.GetFlatIdMedia)
+	m.HandleFunc("POST "+options.BaseURL+"/flat/{id}/media", wrapper.PostFlatIdMedia)
+	m.HandleFunc("DELETE "+options.BaseURL+"/flat/{id}/media/{media_id}", wrapper.DeleteFlatIdMediaMediaId)
+	m.HandleFunc("GET "+options.BaseURL+"/flat/{id}/media/{media_id}", wrapper.GetFlatIdMediaMediaId)
+	m.HandleFunc("GET "+options.BaseURL+"/flats/export", wrapper.GetFlatsExport)
+	m.HandleFunc("GET "+options.BaseURL+"/flats/search", wrapper.GetFlatsSearch)
+	m.HandleFunc("POST "+options.BaseURL+"/house/create", wrapper.PostHouseCreate)
+	m.HandleFunc("GET "+options.BaseURL+"/house/{id}", wrapper.GetHouseId)
+	m.HandleFunc("GET "+options.BaseURL+"/house/{id}/export", wrapper.GetHouseIdExport)
+	m.HandleFunc("POST "+options.BaseURL+"/house/{id}/flats/import", wrapper.PostHouseIdFlatsImport)
+	m.HandleFunc("POST "+options.BaseURL+"/house/{id}/subscribe", wrapper.PostHouseIdSubscribe)
+	m.HandleFunc("GET "+options.BaseURL+"/houses/nearby", wrapper.GetHousesNearby)
+	m.HandleFunc("GET "+options.BaseURL+"/houses/search", wrapper.GetHousesSearch)
+	m.HandleFunc("POST "+options.BaseURL+"/login", wrapper.PostLogin)
+	m.HandleFunc("GET "+options.BaseURL+"/moderation/decline-reasons", wrapper.GetModerationDeclineReasons)
+	m.HandleFunc("PUT "+options.BaseURL+"/moderation/decline-reasons/{code}", wrapper.PutModerationDeclineReasonsCode)
+	m.HandleFunc("GET "+options.BaseURL+"/moderation/queue", wrapper.GetModerationQueue)
+	m.HandleFunc("GET "+options.BaseURL+"/openapi.json", wrapper.GetOpenapiJson)
+	m.HandleFunc("GET "+options.BaseURL+"/openapi.yaml", wrapper.GetOpenapiYaml)
+	m.HandleFunc("POST "+options.BaseURL+"/register", wrapper.PostRegister)
+
+	return m
+}
+
+type N400Response struct {
+}
+
+type N401Response struct {
+}
+
+type N403Response struct {
+}
+
+type N404Response struct {
+}
+
+type N412Response struct {
+}
+
+type N422Response struct {
+}
+
+type N428Response struct {
+}
+
+type N429ResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+type N429JSONResponse struct {
+	Body Error
+
+	Headers N429ResponseHeaders
+}
+
+type N5xxResponseHeaders struct {
+	RetryAfter int
+}
+type N5xxJSONResponse struct {
+	Body Error
+
+	Headers N5xxResponseHeaders
+}
+
+type PostAdminUsersIdDisableRequestObject struct {
+	Id UserId `json:"id"`
+}
+
+type PostAdminUsersIdDisableResponseObject interface {
+	VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUsersIdDisable200JSONResponse User
+
+func (response PostAdminUsersIdDisable200JSONResponse) VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersIdDisable401Response = N401Response
+
+func (response PostAdminUsersIdDisable401Response) VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminUsersIdDisable403Response = N403Response
+
+func (response PostAdminUsersIdDisable403Response) VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostAdminUsersIdDisable404Response = N404Response
+
+func (response PostAdminUsersIdDisable404Response) VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminUsersIdDisable429JSONResponse struct{ N429JSONResponse }
+
+func (response PostAdminUsersIdDisable429JSONResponse) VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAdminUsersIdDisable500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostAdminUsersIdDisable500JSONResponse) VisitPostAdminUsersIdDisableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAdminUsersIdEnableRequestObject struct {
+	Id UserId `json:"id"`
+}
+
+type PostAdminUsersIdEnableResponseObject interface {
+	VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUsersIdEnable200JSONResponse User
+
+func (response PostAdminUsersIdEnable200JSONResponse) VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersIdEnable401Response = N401Response
+
+func (response PostAdminUsersIdEnable401Response) VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminUsersIdEnable403Response = N403Response
+
+func (response PostAdminUsersIdEnable403Response) VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostAdminUsersIdEnable404Response = N404Response
+
+func (response PostAdminUsersIdEnable404Response) VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminUsersIdEnable429JSONResponse struct{ N429JSONResponse }
+
+func (response PostAdminUsersIdEnable429JSONResponse) VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAdminUsersIdEnable500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostAdminUsersIdEnable500JSONResponse) VisitPostAdminUsersIdEnableResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAdminUsersIdRoleRequestObject struct {
+	Id   UserId `json:"id"`
+	Body *PostAdminUsersIdRoleJSONRequestBody
+}
+
+type PostAdminUsersIdRoleResponseObject interface {
+	VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error
+}
+
+type PostAdminUsersIdRole200JSONResponse User
+
+func (response PostAdminUsersIdRole200JSONResponse) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAdminUsersIdRole400Response = N400Response
+
+func (response PostAdminUsersIdRole400Response) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostAdminUsersIdRole401Response = N401Response
+
+func (response PostAdminUsersIdRole401Response) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostAdminUsersIdRole403Response = N403Response
+
+func (response PostAdminUsersIdRole403Response) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostAdminUsersIdRole404Response = N404Response
+
+func (response PostAdminUsersIdRole404Response) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostAdminUsersIdRole429JSONResponse struct{ N429JSONResponse }
+
+func (response PostAdminUsersIdRole429JSONResponse) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostAdminUsersIdRole500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostAdminUsersIdRole500JSONResponse) VisitPostAdminUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiKeysRequestObject struct {
+}
+
+type GetApiKeysResponseObject interface {
+	VisitGetApiKeysResponse(w http.ResponseWriter) error
+}
+
+type GetApiKeys200JSONResponse []APIKey
+
+func (response GetApiKeys200JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys401Response = N401Response
+
+func (response GetApiKeys401Response) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetApiKeys429JSONResponse struct{ N429JSONResponse }
+
+func (response GetApiKeys429JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetApiKeys500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetApiKeys500JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostApiKeysRequestObject struct {
+	Body *PostApiKeysJSONRequestBody
+}
+
+type PostApiKeysResponseObject interface {
+	VisitPostApiKeysResponse(w http.ResponseWriter) error
+}
+
+type PostApiKeys201JSONResponse APIKeyCreated
+
+func (response PostApiKeys201JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys400Response = N400Response
+
+func (response PostApiKeys400Response) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostApiKeys401Response = N401Response
+
+func (response PostApiKeys401Response) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostApiKeys403Response = N403Response
+
+func (response PostApiKeys403Response) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostApiKeys429JSONResponse struct{ N429JSONResponse }
+
+func (response PostApiKeys429JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostApiKeys500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostApiKeys500JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteApiKeysIdRequestObject struct {
+	Id APIKeyId `json:"id"`
+}
+
+type DeleteApiKeysIdResponseObject interface {
+	VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiKeysId204Response struct {
+}
+
+func (response DeleteApiKeysId204Response) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteApiKeysId401Response = N401Response
+
+func (response DeleteApiKeysId401Response) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteApiKeysId404Response = N404Response
+
+func (response DeleteApiKeysId404Response) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteApiKeysId429JSONResponse struct{ N429JSONResponse }
+
+func (response DeleteApiKeysId429JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteApiKeysId500JSONResponse struct{ N5xxJSONResponse }
+
+func (response DeleteApiKeysId500JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuditRequestObject struct {
+	Params GetAuditParams
+}
+
+type GetAuditResponseObject interface {
+	VisitGetAuditResponse(w http.ResponseWriter) error
+}
+
+type GetAudit200JSONResponse AuditPage
+
+func (response GetAudit200JSONResponse) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAudit400Response = N400Response
+
+func (response GetAudit400Response) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetAudit401Response = N401Response
+
+func (response GetAudit401Response) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetAudit403Response = N403Response
+
+func (response GetAudit403Response) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetAudit429JSONResponse struct{ N429JSONResponse }
+
+func (response GetAudit429JSONResponse) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAudit500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetAudit500JSONResponse) VisitGetAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDevelopersRequestObject struct {
+}
+
+type GetDevelopersResponseObject interface {
+	VisitGetDevelopersResponse(w http.ResponseWriter) error
+}
+
+type GetDevelopers200JSONResponse []DeveloperProfile
+
+func (response GetDevelopers200JSONResponse) VisitGetDevelopersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDevelopers401Response = N401Response
+
+func (response GetDevelopers401Response) VisitGetDevelopersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetDevelopers429JSONResponse struct{ N429JSONResponse }
+
+func (response GetDevelopers429JSONResponse) VisitGetDevelopersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDevelopers500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetDevelopers500JSONResponse) VisitGetDevelopersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostDevelopersRequestObject struct {
+	Body *PostDevelopersJSONRequestBody
+}
+
+type PostDevelopersResponseObject interface {
+	VisitPostDevelopersResponse(w http.ResponseWriter) error
+}
+
+type PostDevelopers201JSONResponse DeveloperProfile
+
+func (response PostDevelopers201JSONResponse) VisitPostDevelopersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostDevelopers400Response = N400Response
+
+func (response PostDevelopers400Response) VisitPostDevelopersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostDevelopers401Response = N401Response
+
+func (response PostDevelopers401Response) VisitPostDevelopersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostDevelopers403Response = N403Response
+
+func (response PostDevelopers403Response) VisitPostDevelopersResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostDevelopers429JSONResponse struct{ N429JSONResponse }
+
+func (response PostDevelopers429JSONResponse) VisitPostDevelopersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostDevelopers500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostDevelopers500JSONResponse) VisitPostDevelopersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDevelopersIdHousesRequestObject struct {
+	Id DeveloperId `json:"id"`
+}
+
+type GetDevelopersIdHousesResponseObject interface {
+	VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error
+}
+
+type GetDevelopersIdHouses200JSONResponse []House
+
+func (response GetDevelopersIdHouses200JSONResponse) VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDevelopersIdHouses400Response = N400Response
+
+func (response GetDevelopersIdHouses400Response) VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetDevelopersIdHouses401Response = N401Response
+
+func (response GetDevelopersIdHouses401Response) VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetDevelopersIdHouses404Response = N404Response
+
+func (response GetDevelopersIdHouses404Response) VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetDevelopersIdHouses429JSONResponse struct{ N429JSONResponse }
+
+func (response GetDevelopersIdHouses429JSONResponse) VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDevelopersIdHouses500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetDevelopersIdHouses500JSONResponse) VisitGetDevelopersIdHousesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDocsRequestObject struct {
+}
+
+type GetDocsResponseObject interface {
+	VisitGetDocsResponse(w http.ResponseWriter) error
+}
+
+type GetDocs200TexthtmlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetDocs200TexthtmlResponse) VisitGetDocsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetDocs429JSONResponse struct{ N429JSONResponse }
+
+func (response GetDocs429JSONResponse) VisitGetDocsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDummyLoginRequestObject struct {
+	Params GetDummyLoginParams
+}
+
+type GetDummyLoginResponseObject interface {
+	VisitGetDummyLoginResponse(w http.ResponseWriter) error
+}
+
+type GetDummyLogin200JSONResponse struct {
+	// Token Авторизационный токен
+	Token *Token `json:"token,omitempty"`
+}
+
+func (response GetDummyLogin200JSONResponse) VisitGetDummyLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDummyLogin429JSONResponse struct{ N429JSONResponse }
+
+func (response GetDummyLogin429JSONResponse) VisitGetDummyLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDummyLogin500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetDummyLogin500JSONResponse) VisitGetDummyLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatCreateRequestObject struct {
+	Params PostFlatCreateParams
+	Body   *PostFlatCreateJSONRequestBody
+}
+
+type PostFlatCreateResponseObject interface {
+	VisitPostFlatCreateResponse(w http.ResponseWriter) error
+}
+
+type PostFlatCreate200ResponseHeaders struct {
+	ETag string
+}
+
+type PostFlatCreate200JSONResponse struct {
+	Body    Flat
+	Headers PostFlatCreate200ResponseHeaders
+}
+
+func (response PostFlatCreate200JSONResponse) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatCreate400Response = N400Response
+
+func (response PostFlatCreate400Response) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostFlatCreate401Response = N401Response
+
+func (response PostFlatCreate401Response) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostFlatCreate409Response struct {
+}
+
+func (response PostFlatCreate409Response) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostFlatCreate422Response = N422Response
+
+func (response PostFlatCreate422Response) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(422)
+	return nil
+}
+
+type PostFlatCreate429JSONResponse struct{ N429JSONResponse }
+
+func (response PostFlatCreate429JSONResponse) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatCreate500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostFlatCreate500JSONResponse) VisitPostFlatCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatUpdateRequestObject struct {
+	Params PostFlatUpdateParams
+	Body   *PostFlatUpdateJSONRequestBody
+}
+
+type PostFlatUpdateResponseObject interface {
+	VisitPostFlatUpdateResponse(w http.ResponseWriter) error
+}
+
+type PostFlatUpdate200ResponseHeaders struct {
+	ETag string
+}
+
+type PostFlatUpdate200JSONResponse struct {
+	Body    Flat
+	Headers PostFlatUpdate200ResponseHeaders
+}
+
+func (response PostFlatUpdate200JSONResponse) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatUpdate400Response = N400Response
+
+func (response PostFlatUpdate400Response) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostFlatUpdate401Response = N401Response
+
+func (response PostFlatUpdate401Response) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostFlatUpdate412Response = N412Response
+
+func (response PostFlatUpdate412Response) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
+}
+
+type PostFlatUpdate428Response = N428Response
+
+func (response PostFlatUpdate428Response) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(428)
+	return nil
+}
+
+type PostFlatUpdate429JSONResponse struct{ N429JSONResponse }
+
+func (response PostFlatUpdate429JSONResponse) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatUpdate500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostFlatUpdate500JSONResponse) VisitPostFlatUpdateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteFlatIdRequestObject struct {
+	Id FlatId `json:"id"`
+}
+
+type DeleteFlatIdResponseObject interface {
+	VisitDeleteFlatIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteFlatId204Response struct {
+}
+
+func (response DeleteFlatId204Response) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFlatId400Response = N400Response
+
+func (response DeleteFlatId400Response) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteFlatId401Response = N401Response
+
+func (response DeleteFlatId401Response) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteFlatId403Response = N403Response
+
+func (response DeleteFlatId403Response) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteFlatId404Response = N404Response
+
+func (response DeleteFlatId404Response) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteFlatId429JSONResponse struct{ N429JSONResponse }
+
+func (response DeleteFlatId429JSONResponse) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteFlatId500JSONResponse struct{ N5xxJSONResponse }
+
+func (response DeleteFlatId500JSONResponse) VisitDeleteFlatIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatIdDecisionsRequestObject struct {
+	Id FlatId `json:"id"`
+}
+
+type GetFlatIdDecisionsResponseObject interface {
+	VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error
+}
+
+type GetFlatIdDecisions200JSONResponse []ModerationDecision
+
+func (response GetFlatIdDecisions200JSONResponse) VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlatIdDecisions400Response = N400Response
+
+func (response GetFlatIdDecisions400Response) VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetFlatIdDecisions401Response = N401Response
+
+func (response GetFlatIdDecisions401Response) VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetFlatIdDecisions404Response = N404Response
+
+func (response GetFlatIdDecisions404Response) VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetFlatIdDecisions429JSONResponse struct{ N429JSONResponse }
+
+func (response GetFlatIdDecisions429JSONResponse) VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatIdDecisions500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetFlatIdDecisions500JSONResponse) VisitGetFlatIdDecisionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatIdMediaRequestObject struct {
+	Id FlatId `json:"id"`
+}
+
+type GetFlatIdMediaResponseObject interface {
+	VisitGetFlatIdMediaResponse(w http.ResponseWriter) error
+}
+
+type GetFlatIdMedia200JSONResponse []FlatMedia
+
+func (response GetFlatIdMedia200JSONResponse) VisitGetFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlatIdMedia400Response = N400Response
+
+func (response GetFlatIdMedia400Response) VisitGetFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetFlatIdMedia401Response = N401Response
+
+func (response GetFlatIdMedia401Response) VisitGetFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetFlatIdMedia404Response = N404Response
+
+func (response GetFlatIdMedia404Response) VisitGetFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetFlatIdMedia429JSONResponse struct{ N429JSONResponse }
+
+func (response GetFlatIdMedia429JSONResponse) VisitGetFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatIdMedia500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetFlatIdMedia500JSONResponse) VisitGetFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatIdMediaRequestObject struct {
+	Id          FlatId `json:"id"`
+	Params      PostFlatIdMediaParams
+	ContentType string
+	Body        io.Reader
+}
+
+type PostFlatIdMediaResponseObject interface {
+	VisitPostFlatIdMediaResponse(w http.ResponseWriter) error
+}
+
+type PostFlatIdMedia201JSONResponse FlatMedia
+
+func (response PostFlatIdMedia201JSONResponse) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostFlatIdMedia400Response = N400Response
+
+func (response PostFlatIdMedia400Response) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostFlatIdMedia401Response = N401Response
+
+func (response PostFlatIdMedia401Response) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostFlatIdMedia403Response = N403Response
+
+func (response PostFlatIdMedia403Response) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostFlatIdMedia404Response = N404Response
+
+func (response PostFlatIdMedia404Response) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostFlatIdMedia413Response struct {
+}
+
+func (response PostFlatIdMedia413Response) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(413)
+	return nil
+}
+
+type PostFlatIdMedia429JSONResponse struct{ N429JSONResponse }
+
+func (response PostFlatIdMedia429JSONResponse) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostFlatIdMedia500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostFlatIdMedia500JSONResponse) VisitPostFlatIdMediaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteFlatIdMediaMediaIdRequestObject struct {
+	Id      FlatId      `json:"id"`
+	MediaId FlatMediaId `json:"media_id"`
+}
+
+type DeleteFlatIdMediaMediaIdResponseObject interface {
+	VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteFlatIdMediaMediaId204Response struct {
+}
+
+func (response DeleteFlatIdMediaMediaId204Response) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteFlatIdMediaMediaId400Response = N400Response
+
+func (response DeleteFlatIdMediaMediaId400Response) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type DeleteFlatIdMediaMediaId401Response = N401Response
+
+func (response DeleteFlatIdMediaMediaId401Response) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type DeleteFlatIdMediaMediaId403Response = N403Response
+
+func (response DeleteFlatIdMediaMediaId403Response) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type DeleteFlatIdMediaMediaId404Response = N404Response
+
+func (response DeleteFlatIdMediaMediaId404Response) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type DeleteFlatIdMediaMediaId429JSONResponse struct{ N429JSONResponse }
+
+func (response DeleteFlatIdMediaMediaId429JSONResponse) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteFlatIdMediaMediaId500JSONResponse struct{ N5xxJSONResponse }
+
+func (response DeleteFlatIdMediaMediaId500JSONResponse) VisitDeleteFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatIdMediaMediaIdRequestObject struct {
+	Id      FlatId      `json:"id"`
+	MediaId FlatMediaId `json:"media_id"`
+	Params  GetFlatIdMediaMediaIdParams
+}
+
+type GetFlatIdMediaMediaIdResponseObject interface {
+	VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error
+}
+
+type GetFlatIdMediaMediaId200ApplicationpdfResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFlatIdMediaMediaId200ApplicationpdfResponse) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/pdf")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlatIdMediaMediaId200ImagejpegResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFlatIdMediaMediaId200ImagejpegResponse) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/jpeg")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlatIdMediaMediaId200ImagepngResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFlatIdMediaMediaId200ImagepngResponse) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlatIdMediaMediaId400Response = N400Response
+
+func (response GetFlatIdMediaMediaId400Response) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetFlatIdMediaMediaId401Response = N401Response
+
+func (response GetFlatIdMediaMediaId401Response) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetFlatIdMediaMediaId404Response = N404Response
+
+func (response GetFlatIdMediaMediaId404Response) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetFlatIdMediaMediaId429JSONResponse struct{ N429JSONResponse }
+
+func (response GetFlatIdMediaMediaId429JSONResponse) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatIdMediaMediaId500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetFlatIdMediaMediaId500JSONResponse) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatsExportRequestObject struct {
+	Params GetFlatsExportParams
+}
+
+type GetFlatsExportResponseObject interface {
+	VisitGetFlatsExportResponse(w http.ResponseWriter) error
+}
+
+type GetFlatsExport200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFlatsExport200ApplicationxNdjsonResponse) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlatsExport200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFlatsExport200TextcsvResponse) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlatsExport200TexttabSeparatedValuesResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFlatsExport200TexttabSeparatedValuesResponse) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/tab-separated-values")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFlatsExport400Response = N400Response
+
+func (response GetFlatsExport400Response) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetFlatsExport401Response = N401Response
+
+func (response GetFlatsExport401Response) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetFlatsExport403Response = N403Response
+
+func (response GetFlatsExport403Response) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetFlatsExport429JSONResponse struct{ N429JSONResponse }
+
+func (response GetFlatsExport429JSONResponse) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatsExport500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetFlatsExport500JSONResponse) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatsSearchRequestObject struct {
+	Params GetFlatsSearchParams
+}
+
+type GetFlatsSearchResponseObject interface {
+	VisitGetFlatsSearchResponse(w http.ResponseWriter) error
+}
+
+type GetFlatsSearch200JSONResponse FlatSearchPage
+
+func (response GetFlatsSearch200JSONResponse) VisitGetFlatsSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFlatsSearch400Response = N400Response
+
+func (response GetFlatsSearch400Response) VisitGetFlatsSearchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetFlatsSearch401Response = N401Response
+
+func (response GetFlatsSearch401Response) VisitGetFlatsSearchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetFlatsSearch403Response = N403Response
+
+func (response GetFlatsSearch403Response) VisitGetFlatsSearchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetFlatsSearch429JSONResponse struct{ N429JSONResponse }
+
+func (response GetFlatsSearch429JSONResponse) VisitGetFlatsSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetFlatsSearch500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetFlatsSearch500JSONResponse) VisitGetFlatsSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostHouseCreateRequestObject struct {
+	Params PostHouseCreateParams
+	Body   *PostHouseCreateJSONRequestBody
+}
+
+type PostHouseCreateResponseObject interface {
+	VisitPostHouseCreateResponse(w http.ResponseWriter) error
+}
+
+type PostHouseCreate200JSONResponse HouseCreated
+
+func (response PostHouseCreate200JSONResponse) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostHouseCreate400Response = N400Response
+
+func (response PostHouseCreate400Response) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostHouseCreate401Response = N401Response
+
+func (response PostHouseCreate401Response) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostHouseCreate409Response struct {
+}
+
+func (response PostHouseCreate409Response) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(409)
+	return nil
+}
+
+type PostHouseCreate422Response = N422Response
+
+func (response PostHouseCreate422Response) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(422)
+	return nil
+}
+
+type PostHouseCreate429JSONResponse struct{ N429JSONResponse }
+
+func (response PostHouseCreate429JSONResponse) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostHouseCreate500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostHouseCreate500JSONResponse) VisitPostHouseCreateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHouseIdRequestObject struct {
+	Id     HouseId `json:"id"`
+	Params GetHouseIdParams
+}
+
+type GetHouseIdResponseObject interface {
+	VisitGetHouseIdResponse(w http.ResponseWriter) error
+}
+
+type GetHouseId200ResponseHeaders struct {
+	ETag string
+	Vary string
+}
+
+type GetHouseId200JSONResponse struct {
+	Body    HouseFlats
+	Headers GetHouseId200ResponseHeaders
+}
+
+func (response GetHouseId200JSONResponse) VisitGetHouseIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHouseId304ResponseHeaders struct {
+	ETag string
+	Vary string
+}
+
+type GetHouseId304Response struct {
+	Headers GetHouseId304ResponseHeaders
+}
+
+func (response GetHouseId304Response) VisitGetHouseIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetHouseId400Response = N400Response
+
+func (response GetHouseId400Response) VisitGetHouseIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHouseId401Response = N401Response
+
+func (response GetHouseId401Response) VisitGetHouseIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetHouseId429JSONResponse struct{ N429JSONResponse }
+
+func (response GetHouseId429JSONResponse) VisitGetHouseIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHouseId500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetHouseId500JSONResponse) VisitGetHouseIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHouseIdExportRequestObject struct {
+	Id     HouseId `json:"id"`
+	Params GetHouseIdExportParams
+}
+
+type GetHouseIdExportResponseObject interface {
+	VisitGetHouseIdExportResponse(w http.ResponseWriter) error
+}
+
+type GetHouseIdExport200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetHouseIdExport200ApplicationxNdjsonResponse) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetHouseIdExport200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetHouseIdExport200TextcsvResponse) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetHouseIdExport200TexttabSeparatedValuesResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetHouseIdExport200TexttabSeparatedValuesResponse) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/tab-separated-values")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetHouseIdExport400Response = N400Response
+
+func (response GetHouseIdExport400Response) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHouseIdExport401Response = N401Response
+
+func (response GetHouseIdExport401Response) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetHouseIdExport404Response = N404Response
+
+func (response GetHouseIdExport404Response) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type GetHouseIdExport429JSONResponse struct{ N429JSONResponse }
+
+func (response GetHouseIdExport429JSONResponse) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHouseIdExport500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetHouseIdExport500JSONResponse) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostHouseIdFlatsImportRequestObject struct {
+	Id       HouseId `json:"id"`
+	Params   PostHouseIdFlatsImportParams
+	JSONBody *PostHouseIdFlatsImportJSONRequestBody
+	Body     io.Reader
+}
+
+type PostHouseIdFlatsImportResponseObject interface {
+	VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error
+}
+
+type PostHouseIdFlatsImport200JSONResponse FlatImportResult
+
+func (response PostHouseIdFlatsImport200JSONResponse) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostHouseIdFlatsImport400JSONResponse FlatImportResult
+
+func (response PostHouseIdFlatsImport400JSONResponse) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostHouseIdFlatsImport401Response = N401Response
+
+func (response PostHouseIdFlatsImport401Response) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostHouseIdFlatsImport403Response = N403Response
+
+func (response PostHouseIdFlatsImport403Response) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostHouseIdFlatsImport404Response = N404Response
+
+func (response PostHouseIdFlatsImport404Response) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostHouseIdFlatsImport429JSONResponse struct{ N429JSONResponse }
+
+func (response PostHouseIdFlatsImport429JSONResponse) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostHouseIdFlatsImport500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostHouseIdFlatsImport500JSONResponse) VisitPostHouseIdFlatsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostHouseIdSubscribeRequestObject struct {
+	Id   HouseId `json:"id"`
+	Body *PostHouseIdSubscribeJSONRequestBody
+}
+
+type PostHouseIdSubscribeResponseObject interface {
+	VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error
+}
+
+type PostHouseIdSubscribe200Response struct {
+}
+
+func (response PostHouseIdSubscribe200Response) VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostHouseIdSubscribe400Response = N400Response
+
+func (response PostHouseIdSubscribe400Response) VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostHouseIdSubscribe401Response = N401Response
+
+func (response PostHouseIdSubscribe401Response) VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PostHouseIdSubscribe404Response = N404Response
+
+func (response PostHouseIdSubscribe404Response) VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostHouseIdSubscribe429JSONResponse struct{ N429JSONResponse }
+
+func (response PostHouseIdSubscribe429JSONResponse) VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostHouseIdSubscribe500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostHouseIdSubscribe500JSONResponse) VisitPostHouseIdSubscribeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHousesNearbyRequestObject struct {
+	Params GetHousesNearbyParams
+}
+
+type GetHousesNearbyResponseObject interface {
+	VisitGetHousesNearbyResponse(w http.ResponseWriter) error
+}
+
+type GetHousesNearby200JSONResponse []HouseNearby
+
+func (response GetHousesNearby200JSONResponse) VisitGetHousesNearbyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHousesNearby400Response = N400Response
+
+func (response GetHousesNearby400Response) VisitGetHousesNearbyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHousesNearby401Response = N401Response
+
+func (response GetHousesNearby401Response) VisitGetHousesNearbyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetHousesNearby429JSONResponse struct{ N429JSONResponse }
+
+func (response GetHousesNearby429JSONResponse) VisitGetHousesNearbyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHousesNearby500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetHousesNearby500JSONResponse) VisitGetHousesNearbyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHousesSearchRequestObject struct {
+	Params GetHousesSearchParams
+}
+
+type GetHousesSearchResponseObject interface {
+	VisitGetHousesSearchResponse(w http.ResponseWriter) error
+}
+
+type GetHousesSearch200JSONResponse []HouseMatch
+
+func (response GetHousesSearch200JSONResponse) VisitGetHousesSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHousesSearch400Response = N400Response
+
+func (response GetHousesSearch400Response) VisitGetHousesSearchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetHousesSearch401Response = N401Response
+
+func (response GetHousesSearch401Response) VisitGetHousesSearchResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetHousesSearch429JSONResponse struct{ N429JSONResponse }
+
+func (response GetHousesSearch429JSONResponse) VisitGetHousesSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHousesSearch500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetHousesSearch500JSONResponse) VisitGetHousesSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostLoginRequestObject struct {
+	Body *PostLoginJSONRequestBody
+}
+
+type PostLoginResponseObject interface {
+	VisitPostLoginResponse(w http.ResponseWriter) error
+}
+
+type PostLogin200JSONResponse struct {
+	// Token Авторизационный токен
+	Token *Token `json:"token,omitempty"`
+}
+
+func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin400Response struct {
+}
+
+func (response PostLogin400Response) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostLogin403Response struct {
+}
+
+func (response PostLogin403Response) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PostLogin404Response struct {
+}
+
+func (response PostLogin404Response) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
+type PostLogin429JSONResponse struct{ N429JSONResponse }
+
+func (response PostLogin429JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostLogin500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostLogin500JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetModerationDeclineReasonsRequestObject struct {
+}
+
+type GetModerationDeclineReasonsResponseObject interface {
+	VisitGetModerationDeclineReasonsResponse(w http.ResponseWriter) error
+}
+
+type GetModerationDeclineReasons200JSONResponse []DeclineReason
+
+func (response GetModerationDeclineReasons200JSONResponse) VisitGetModerationDeclineReasonsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetModerationDeclineReasons401Response = N401Response
+
+func (response GetModerationDeclineReasons401Response) VisitGetModerationDeclineReasonsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetModerationDeclineReasons403Response = N403Response
+
+func (response GetModerationDeclineReasons403Response) VisitGetModerationDeclineReasonsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetModerationDeclineReasons429JSONResponse struct{ N429JSONResponse }
+
+func (response GetModerationDeclineReasons429JSONResponse) VisitGetModerationDeclineReasonsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetModerationDeclineReasons500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetModerationDeclineReasons500JSONResponse) VisitGetModerationDeclineReasonsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutModerationDeclineReasonsCodeRequestObject struct {
+	Code DeclineReasonCode `json:"code"`
+	Body *PutModerationDeclineReasonsCodeJSONRequestBody
+}
+
+type PutModerationDeclineReasonsCodeResponseObject interface {
+	VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error
+}
+
+type PutModerationDeclineReasonsCode200JSONResponse DeclineReason
+
+func (response PutModerationDeclineReasonsCode200JSONResponse) VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutModerationDeclineReasonsCode400Response = N400Response
+
+func (response PutModerationDeclineReasonsCode400Response) VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PutModerationDeclineReasonsCode401Response = N401Response
+
+func (response PutModerationDeclineReasonsCode401Response) VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type PutModerationDeclineReasonsCode403Response = N403Response
+
+func (response PutModerationDeclineReasonsCode403Response) VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type PutModerationDeclineReasonsCode429JSONResponse struct{ N429JSONResponse }
+
+func (response PutModerationDeclineReasonsCode429JSONResponse) VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutModerationDeclineReasonsCode500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PutModerationDeclineReasonsCode500JSONResponse) VisitPutModerationDeclineReasonsCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetModerationQueueRequestObject struct {
+	Params GetModerationQueueParams
+}
+
+type GetModerationQueueResponseObject interface {
+	VisitGetModerationQueueResponse(w http.ResponseWriter) error
+}
+
+type GetModerationQueue200JSONResponse ModerationQueuePage
+
+func (response GetModerationQueue200JSONResponse) VisitGetModerationQueueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetModerationQueue400Response = N400Response
+
+func (response GetModerationQueue400Response) VisitGetModerationQueueResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type GetModerationQueue401Response = N401Response
+
+func (response GetModerationQueue401Response) VisitGetModerationQueueResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type GetModerationQueue403Response = N403Response
+
+func (response GetModerationQueue403Response) VisitGetModerationQueueResponse(w http.ResponseWriter) error {
+	w.WriteHeader(403)
+	return nil
+}
+
+type GetModerationQueue429JSONResponse struct{ N429JSONResponse }
+
+func (response GetModerationQueue429JSONResponse) VisitGetModerationQueueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetModerationQueue500JSONResponse struct{ N5xxJSONResponse }
+
+func (response GetModerationQueue500JSONResponse) VisitGetModerationQueueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOpenapiJsonRequestObject struct {
+}
+
+type GetOpenapiJsonResponseObject interface {
+	VisitGetOpenapiJsonResponse(w http.ResponseWriter) error
+}
+
+type GetOpenapiJson200JSONResponse map[string]interface{}
+
+func (response GetOpenapiJson200JSONResponse) VisitGetOpenapiJsonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOpenapiJson429JSONResponse struct{ N429JSONResponse }
+
+func (response GetOpenapiJson429JSONResponse) VisitGetOpenapiJsonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOpenapiYamlRequestObject struct {
+}
+
+type GetOpenapiYamlResponseObject interface {
+	VisitGetOpenapiYamlResponse(w http.ResponseWriter) error
+}
+
+type GetOpenapiYaml200ApplicationyamlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetOpenapiYaml200ApplicationyamlResponse) VisitGetOpenapiYamlResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/yaml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetOpenapiYaml429JSONResponse struct{ N429JSONResponse }
+
+func (response GetOpenapiYaml429JSONResponse) VisitGetOpenapiYamlResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostRegisterRequestObject struct {
+	Body *PostRegisterJSONRequestBody
+}
+
+type PostRegisterResponseObject interface {
+	VisitPostRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostRegister200JSONResponse struct {
+	// UserId Идентификатор пользователя
+	UserId *UserId `json:"user_id,omitempty"`
+}
+
+func (response PostRegister200JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister400Response struct {
+}
+
+func (response PostRegister400Response) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.WriteHeader(400)
+	return nil
+}
+
+type PostRegister429JSONResponse struct{ N429JSONResponse }
+
+func (response PostRegister429JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostRegister500JSONResponse struct{ N5xxJSONResponse }
+
+func (response PostRegister500JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (POST /admin/users/{id}/disable)
+	PostAdminUsersIdDisable(ctx context.Context, request PostAdminUsersIdDisableRequestObject) (PostAdminUsersIdDisableResponseObject, error)
+
+	// (POST /admin/users/{id}/enable)
+	PostAdminUsersIdEnable(ctx context.Context, request PostAdminUsersIdEnableRequestObject) (PostAdminUsersIdEnableResponseObject, error)
+
+	// (POST /admin/users/{id}/role)
+	PostAdminUsersIdRole(ctx context.Context, request PostAdminUsersIdRoleRequestObject) (PostAdminUsersIdRoleResponseObject, error)
+
+	// (GET /api-keys)
+	GetApiKeys(ctx context.Context, request GetApiKeysRequestObject) (GetApiKeysResponseObject, error)
+
+	// (POST /api-keys)
+	PostApiKeys(ctx context.Context, request PostApiKeysRequestObject) (PostApiKeysResponseObject, error)
+
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(ctx context.Context, request DeleteApiKeysIdRequestObject) (DeleteApiKeysIdResponseObject, error)
+
+	// (GET /audit)
+	GetAudit(ctx context.Context, request GetAuditRequestObject) (GetAuditResponseObject, error)
+
+	// (GET /developers)
+	GetDevelopers(ctx context.Context, request GetDevelopersRequestObject) (GetDevelopersResponseObject, error)
+
+	// (POST /developers)
+	PostDevelopers(ctx context.Context, request PostDevelopersRequestObject) (PostDevelopersResponseObject, error)
+
+	// (GET /developers/{id}/houses)
+	GetDevelopersIdHouses(ctx context.Context, request GetDevelopersIdHousesRequestObject) (GetDevelopersIdHousesResponseObject, error)
+
+	// (GET /docs)
+	GetDocs(ctx context.Context, request GetDocsRequestObject) (GetDocsResponseObject, error)
+
+	// (GET /dummyLogin)
+	GetDummyLogin(ctx context.Context, request GetDummyLoginRequestObject) (GetDummyLoginResponseObject, error)
+
+	// (POST /flat/create)
+	PostFlatCreate(ctx context.Context, request PostFlatCreateRequestObject) (PostFlatCreateResponseObject, error)
+
+	// (POST /flat/update)
+	PostFlatUpdate(ctx context.Context, request PostFlatUpdateRequestObject) (PostFlatUpdateResponseObject, error)
+
+	// (DELETE /flat/{id})
+	DeleteFlatId(ctx context.Context, request DeleteFlatIdRequestObject) (DeleteFlatIdResponseObject, error)
+
+	// (GET /flat/{id}/decisions)
+	GetFlatIdDecisions(ctx context.Context, request GetFlatIdDecisionsRequestObject) (GetFlatIdDecisionsResponseObject, error)
+
+	// (GET /flat/{id}/media)
+	GetFlatIdMedia(ctx context.Context, request GetFlatIdMediaRequestObject) (GetFlatIdMediaResponseObject, error)
+
+	// (POST /flat/{id}/media)
+	PostFlatIdMedia(ctx context.Context, request PostFlatIdMediaRequestObject) (PostFlatIdMediaResponseObject, error)
+
+	// (DELETE /flat/{id}/media/{media_id})
+	DeleteFlatIdMediaMediaId(ctx context.Context, request DeleteFlatIdMediaMediaIdRequestObject) (DeleteFlatIdMediaMediaIdResponseObject, error)
+
+	// (GET /flat/{id}/media/{media_id})
+	GetFlatIdMediaMediaId(ctx context.Context, request GetFlatIdMediaMediaIdRequestObject) (GetFlatIdMediaMediaIdResponseObject, error)
+
+	// (GET /flats/export)
+	GetFlatsExport(ctx context.Context, request GetFlatsExportRequestObject) (GetFlatsExportResponseObject, error)
+
+	// (GET /flats/search)
+	GetFlatsSearch(ctx context.Context, request GetFlatsSearchRequestObject) (GetFlatsSearchResponseObject, error)
+
+	// (POST /house/create)
+	PostHouseCreate(ctx context.Context, request PostHouseCreateRequestObject) (PostHouseCreateResponseObject, error)
+
+	// (GET /house/{id})
+	GetHouseId(ctx context.Context, request GetHouseIdRequestObject) (GetHouseIdResponseObject, error)
+
+	// (GET /house/{id}/export)
+	GetHouseIdExport(ctx context.Context, request GetHouseIdExportRequestObject) (GetHouseIdExportResponseObject, error)
+
+	// (POST /house/{id}/flats/import)
+	PostHouseIdFlatsImport(ctx context.Context, request PostHouseIdFlatsImportRequestObject) (PostHouseIdFlatsImportResponseObject, error)
+
+	// (POST /house/{id}/subscribe)
+	PostHouseIdSubscribe(ctx context.Context, request PostHouseIdSubscribeRequestObject) (PostHouseIdSubscribeResponseObject, error)
+
+	// (GET /houses/nearby)
+	GetHousesNearby(ctx context.Context, request GetHousesNearbyRequestObject) (GetHousesNearbyResponseObject, error)
+
+	// (GET /houses/search)
+	GetHousesSearch(ctx context.Context, request GetHousesSearchRequestObject) (GetHousesSearchResponseObject, error)
+
+	// (POST /login)
+	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
+
+	// (GET /moderation/decline-reasons)
+	GetModerationDeclineReasons(ctx context.Context, request GetModerationDeclineReasonsRequestObject) (GetModerationDeclineReasonsResponseObject, error)
+
+	// (PUT /moderation/decline-reasons/{code})
+	PutModerationDeclineReasonsCode(ctx context.Context, request PutModerationDeclineReasonsCodeRequestObject) (PutModerationDeclineReasonsCodeResponseObject, error)
+
+	// (GET /moderation/queue)
+	GetModerationQueue(ctx context.Context, request GetModerationQueueRequestObject) (GetModerationQueueResponseObject, error)
+
+	// (GET /openapi.json)
+	GetOpenapiJson(ctx context.Context, request GetOpenapiJsonRequestObject) (GetOpenapiJsonResponseObject, error)
+
+	// (GET /openapi.yaml)
+	GetOpenapiYaml(ctx context.Context, request GetOpenapiYamlRequestObject) (GetOpenapiYamlResponseObject, error)
+
+	// (POST /register)
+	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// PostAdminUsersIdDisable operation middleware
+func (sh *strictHandler) PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request, id UserId) {
+	var request PostAdminUsersIdDisableRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersIdDisable(ctx, request.(PostAdminUsersIdDisableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersIdDisable")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminUsersIdDisableResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersIdDisableResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUsersIdEnable operation middleware
+func (sh *strictHandler) PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request, id UserId) {
+	var request PostAdminUsersIdEnableRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersIdEnable(ctx, request.(PostAdminUsersIdEnableRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersIdEnable")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminUsersIdEnableResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersIdEnableResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAdminUsersIdRole operation middleware
+func (sh *strictHandler) PostAdminUsersIdRole(w http.ResponseWriter, r *http.Request, id UserId) {
+	var request PostAdminUsersIdRoleRequestObject
+
+	request.Id = id
+
+	var body PostAdminUsersIdRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAdminUsersIdRole(ctx, request.(PostAdminUsersIdRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAdminUsersIdRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAdminUsersIdRoleResponseObject); ok {
+		if err := validResponse.VisitPostAdminUsersIdRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetApiKeys operation middleware
+func (sh *strictHandler) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	var request GetApiKeysRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiKeys(ctx, request.(GetApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetApiKeysResponseObject); ok {
+		if err := validResponse.VisitGetApiKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiKeys operation middleware
+func (sh *strictHandler) PostApiKeys(w http.ResponseWriter, r *http.Request) {
+	var request PostApiKeysRequestObject
+
+	var body PostApiKeysJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiKeys(ctx, request.(PostApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostApiKeysResponseObject); ok {
+		if err := validResponse.VisitPostApiKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiKeysId operation middleware
+func (sh *strictHandler) DeleteApiKeysId(w http.ResponseWriter, r *http.Request, id APIKeyId) {
+	var request DeleteApiKeysIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiKeysId(ctx, request.(DeleteApiKeysIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiKeysId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiKeysIdResponseObject); ok {
+		if err := validResponse.VisitDeleteApiKeysIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAudit operation middleware
+func (sh *strictHandler) GetAudit(w http.ResponseWriter, r *http.Request, params GetAuditParams) {
+	var request GetAuditRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAudit(ctx, request.(GetAuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAudit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAuditResponseObject); ok {
+		if err := validResponse.VisitGetAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDevelopers operation middleware
+func (sh *strictHandler) GetDevelopers(w http.ResponseWriter, r *http.Request) {
+	var request GetDevelopersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDevelopers(ctx, request.(GetDevelopersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDevelopers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDevelopersResponseObject); ok {
+		if err := validResponse.VisitGetDevelopersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostDevelopers operation middleware
+func (sh *strictHandler) PostDevelopers(w http.ResponseWriter, r *http.Request) {
+	var request PostDevelopersRequestObject
+
+	var body PostDevelopersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostDevelopers(ctx, request.(PostDevelopersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostDevelopers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostDevelopersResponseObject); ok {
+		if err := validResponse.VisitPostDevelopersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDevelopersIdHouses operation middleware
+func (sh *strictHandler) GetDevelopersIdHouses(w http.ResponseWriter, r *http.Request, id DeveloperId) {
+	var request GetDevelopersIdHousesRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDevelopersIdHouses(ctx, request.(GetDevelopersIdHousesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDevelopersIdHouses")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDevelopersIdHousesResponseObject); ok {
+		if err := validResponse.VisitGetDevelopersIdHousesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDocs operation middleware
+func (sh *strictHandler) GetDocs(w http.ResponseWriter, r *http.Request) {
+	var request GetDocsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocs(ctx, request.(GetDocsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocsResponseObject); ok {
+		if err := validResponse.VisitGetDocsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDummyLogin operation middleware
+func (sh *strictHandler) GetDummyLogin(w http.ResponseWriter, r *http.Request, params GetDummyLoginParams) {
+	var request GetDummyLoginRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDummyLogin(ctx, request.(GetDummyLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDummyLogin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDummyLoginResponseObject); ok {
+		if err := validResponse.VisitGetDummyLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostFlatCreate operation middleware
+func (sh *strictHandler) PostFlatCreate(w http.ResponseWriter, r *http.Request, params PostFlatCreateParams) {
+	var request PostFlatCreateRequestObject
+
+	request.Params = params
+
+	var body PostFlatCreateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostFlatCreate(ctx, request.(PostFlatCreateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostFlatCreate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostFlatCreateResponseObject); ok {
+		if err := validResponse.VisitPostFlatCreateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostFlatUpdate operation middleware
+func (sh *strictHandler) PostFlatUpdate(w http.ResponseWriter, r *http.Request, params PostFlatUpdateParams) {
+	var request PostFlatUpdateRequestObject
+
+	request.Params = params
+
+	var body PostFlatUpdateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostFlatUpdate(ctx, request.(PostFlatUpdateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostFlatUpdate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostFlatUpdateResponseObject); ok {
+		if err := validResponse.VisitPostFlatUpdateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFlatId operation middleware
+func (sh *strictHandler) DeleteFlatId(w http.ResponseWriter, r *http.Request, id FlatId) {
+	var request DeleteFlatIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFlatId(ctx, request.(DeleteFlatIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFlatId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFlatIdResponseObject); ok {
+		if err := validResponse.VisitDeleteFlatIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlatIdDecisions operation middleware
+func (sh *strictHandler) GetFlatIdDecisions(w http.ResponseWriter, r *http.Request, id FlatId) {
+	var request GetFlatIdDecisionsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlatIdDecisions(ctx, request.(GetFlatIdDecisionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlatIdDecisions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlatIdDecisionsResponseObject); ok {
+		if err := validResponse.VisitGetFlatIdDecisionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlatIdMedia operation middleware
+func (sh *strictHandler) GetFlatIdMedia(w http.ResponseWriter, r *http.Request, id FlatId) {
+	var request GetFlatIdMediaRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlatIdMedia(ctx, request.(GetFlatIdMediaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlatIdMedia")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlatIdMediaResponseObject); ok {
+		if err := validResponse.VisitGetFlatIdMediaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostFlatIdMedia operation middleware
+func (sh *strictHandler) PostFlatIdMedia(w http.ResponseWriter, r *http.Request, id FlatId, params PostFlatIdMediaParams) {
+	var request PostFlatIdMediaRequestObject
+
+	request.Id = id
+	request.Params = params
+	request.ContentType = r.Header.Get("Content-Type")
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostFlatIdMedia(ctx, request.(PostFlatIdMediaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostFlatIdMedia")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostFlatIdMediaResponseObject); ok {
+		if err := validResponse.VisitPostFlatIdMediaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFlatIdMediaMediaId operation middleware
+func (sh *strictHandler) DeleteFlatIdMediaMediaId(w http.ResponseWriter, r *http.Request, id FlatId, mediaId FlatMediaId) {
+	var request DeleteFlatIdMediaMediaIdRequestObject
+
+	request.Id = id
+	request.MediaId = mediaId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFlatIdMediaMediaId(ctx, request.(DeleteFlatIdMediaMediaIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFlatIdMediaMediaId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFlatIdMediaMediaIdResponseObject); ok {
+		if err := validResponse.VisitDeleteFlatIdMediaMediaIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlatIdMediaMediaId operation middleware
+func (sh *strictHandler) GetFlatIdMediaMediaId(w http.ResponseWriter, r *http.Request, id FlatId, mediaId FlatMediaId, params GetFlatIdMediaMediaIdParams) {
+	var request GetFlatIdMediaMediaIdRequestObject
+
+	request.Id = id
+	request.MediaId = mediaId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlatIdMediaMediaId(ctx, request.(GetFlatIdMediaMediaIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlatIdMediaMediaId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlatIdMediaMediaIdResponseObject); ok {
+		if err := validResponse.VisitGetFlatIdMediaMediaIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlatsExport operation middleware
+func (sh *strictHandler) GetFlatsExport(w http.ResponseWriter, r *http.Request, params GetFlatsExportParams) {
+	var request GetFlatsExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlatsExport(ctx, request.(GetFlatsExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlatsExport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlatsExportResponseObject); ok {
+		if err := validResponse.VisitGetFlatsExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFlatsSearch operation middleware
+func (sh *strictHandler) GetFlatsSearch(w http.ResponseWriter, r *http.Request, params GetFlatsSearchParams) {
+	var request GetFlatsSearchRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFlatsSearch(ctx, request.(GetFlatsSearchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFlatsSearch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetFlatsSearchResponseObject); ok {
+		if err := validResponse.VisitGetFlatsSearchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostHouseCreate operation middleware
+func (sh *strictHandler) PostHouseCreate(w http.ResponseWriter, r *http.Request, params PostHouseCreateParams) {
+	var request PostHouseCreateRequestObject
+
+	request.Params = params
+
+	var body PostHouseCreateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostHouseCreate(ctx, request.(PostHouseCreateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostHouseCreate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostHouseCreateResponseObject); ok {
+		if err := validResponse.VisitPostHouseCreateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHouseId operation middleware
+func (sh *strictHandler) GetHouseId(w http.ResponseWriter, r *http.Request, id HouseId, params GetHouseIdParams) {
+	var request GetHouseIdRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHouseId(ctx, request.(GetHouseIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHouseId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHouseIdResponseObject); ok {
+		if err := validResponse.VisitGetHouseIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHouseIdExport operation middleware
+func (sh *strictHandler) GetHouseIdExport(w http.ResponseWriter, r *http.Request, id HouseId, params GetHouseIdExportParams) {
+	var request GetHouseIdExportRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHouseIdExport(ctx, request.(GetHouseIdExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHouseIdExport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHouseIdExportResponseObject); ok {
+		if err := validResponse.VisitGetHouseIdExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostHouseIdFlatsImport operation middleware
+func (sh *strictHandler) PostHouseIdFlatsImport(w http.ResponseWriter, r *http.Request, id HouseId, params PostHouseIdFlatsImportParams) {
+	var request PostHouseIdFlatsImportRequestObject
+
+	request.Id = id
+	request.Params = params
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+
+		var body PostHouseIdFlatsImportJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		request.Body = r.Body
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostHouseIdFlatsImport(ctx, request.(PostHouseIdFlatsImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostHouseIdFlatsImport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostHouseIdFlatsImportResponseObject); ok {
+		if err := validResponse.VisitPostHouseIdFlatsImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostHouseIdSubscribe operation middleware
+func (sh *strictHandler) PostHouseIdSubscribe(w http.ResponseWriter, r *http.Request, id HouseId) {
+	var request PostHouseIdSubscribeRequestObject
+
+	request.Id = id
+
+	var body PostHouseIdSubscribeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostHouseIdSubscribe(ctx, request.(PostHouseIdSubscribeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostHouseIdSubscribe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostHouseIdSubscribeResponseObject); ok {
+		if err := validResponse.VisitPostHouseIdSubscribeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHousesNearby operation middleware
+func (sh *strictHandler) GetHousesNearby(w http.ResponseWriter, r *http.Request, params GetHousesNearbyParams) {
+	var request GetHousesNearbyRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHousesNearby(ctx, request.(GetHousesNearbyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHousesNearby")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHousesNearbyResponseObject); ok {
+		if err := validResponse.VisitGetHousesNearbyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHousesSearch operation middleware
+func (sh *strictHandler) GetHousesSearch(w http.ResponseWriter, r *http.Request, params GetHousesSearchParams) {
+	var request GetHousesSearchRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHousesSearch(ctx, request.(GetHousesSearchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHousesSearch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHousesSearchResponseObject); ok {
+		if err := validResponse.VisitGetHousesSearchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogin operation middleware
+func (sh *strictHandler) PostLogin(w http.ResponseWriter, r *http.Request) {
+	var request PostLoginRequestObject
+
+	var body PostLoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
+		if err := validResponse.VisitPostLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetModerationDeclineReasons operation middleware
+func (sh *strictHandler) GetModerationDeclineReasons(w http.ResponseWriter, r *http.Request) {
+	var request GetModerationDeclineReasonsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetModerationDeclineReasons(ctx, request.(GetModerationDeclineReasonsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetModerationDeclineReasons")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetModerationDeclineReasonsResponseObject); ok {
+		if err := validResponse.VisitGetModerationDeclineReasonsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutModerationDeclineReasonsCode operation middleware
+func (sh *strictHandler) PutModerationDeclineReasonsCode(w http.ResponseWriter, r *http.Request, code DeclineReasonCode) {
+	var request PutModerationDeclineReasonsCodeRequestObject
+
+	request.Code = code
+
+	var body PutModerationDeclineReasonsCodeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutModerationDeclineReasonsCode(ctx, request.(PutModerationDeclineReasonsCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutModerationDeclineReasonsCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutModerationDeclineReasonsCodeResponseObject); ok {
+		if err := validResponse.VisitPutModerationDeclineReasonsCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetModerationQueue operation middleware
+func (sh *strictHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request, params GetModerationQueueParams) {
+	var request GetModerationQueueRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetModerationQueue(ctx, request.(GetModerationQueueRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetModerationQueue")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetModerationQueueResponseObject); ok {
+		if err := validResponse.VisitGetModerationQueueResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOpenapiJson operation middleware
+func (sh *strictHandler) GetOpenapiJson(w http.ResponseWriter, r *http.Request) {
+	var request GetOpenapiJsonRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOpenapiJson(ctx, request.(GetOpenapiJsonRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOpenapiJson")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOpenapiJsonResponseObject); ok {
+		if err := validResponse.VisitGetOpenapiJsonResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOpenapiYaml operation middleware
+func (sh *strictHandler) GetOpenapiYaml(w http.ResponseWriter, r *http.Request) {
+	var request GetOpenapiYamlRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOpenapiYaml(ctx, request.(GetOpenapiYamlRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOpenapiYaml")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOpenapiYamlResponseObject); ok {
+		if err := validResponse.VisitGetOpenapiYamlResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRegister operation middleware
+func (sh *strictHandler) PostRegister(w http.ResponseWriter, r *http.Request) {
+	var request PostRegisterRequestObject
+
+	var body PostRegisterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRegister(ctx, request.(PostRegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRegister")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRegisterResponseObject); ok {
+		if err := validResponse.VisitPostRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
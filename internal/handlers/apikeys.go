@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+func (h *APIKeyHandler) PostApiKeys(ctx context.Context, request dto.PostApiKeysRequestObject) (dto.PostApiKeysResponseObject, error) {
+	key, err := h.apiKeyService.Create(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "API key %s created", key.Prefix)
+	return dto.PostApiKeys201JSONResponse(*key), nil
+}
+
+func (h *APIKeyHandler) GetApiKeys(ctx context.Context, _ dto.GetApiKeysRequestObject) (dto.GetApiKeysResponseObject, error) {
+	keys, err := h.apiKeyService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetApiKeys200JSONResponse(keys), nil
+}
+
+func (h *APIKeyHandler) DeleteApiKeysId(ctx context.Context, request dto.DeleteApiKeysIdRequestObject) (dto.DeleteApiKeysIdResponseObject, error) {
+	if err := h.apiKeyService.Revoke(ctx, request.Id.String()); err != nil {
+		return nil, err
+	}
+
+	return dto.DeleteApiKeysId204Response{}, nil
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/service"
+)
+
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+func (h *AuditHandler) GetAudit(ctx context.Context, request dto.GetAuditRequestObject) (dto.GetAuditResponseObject, error) {
+	page, err := h.auditService.Query(ctx, request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetAudit200JSONResponse(*page), nil
+}
@@ -1,9 +1,10 @@
 package handlers
 
 import (
-	"encoding/json"
-	"net/http"
+	"context"
 
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/shhesterka04/house-service/internal/dto"
 	"github.com/shhesterka04/house-service/internal/service"
 	"github.com/shhesterka04/house-service/pkg/logger"
@@ -17,60 +18,36 @@ func NewAuthHandlers(authService *service.AuthService) *AuthHandlers {
 	return &AuthHandlers{authService: authService}
 }
 
-func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
-	var req dto.PostRegisterJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Errorf(r.Context(), "Error decoding request: %v", err)
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
+func (h *AuthHandlers) PostRegister(ctx context.Context, request dto.PostRegisterRequestObject) (dto.PostRegisterResponseObject, error) {
+	id, err := h.authService.Register(ctx, *request.Body)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := h.authService.Register(r.Context(), req); err != nil {
-		logger.Errorf(r.Context(), "Error registering user: %v", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse user id")
 	}
 
-	logger.Debugf(r.Context(), "User %h registered", *req.Email)
-	w.WriteHeader(http.StatusCreated)
+	logger.Debugf(ctx, "User %s registered", id)
+	return dto.PostRegister200JSONResponse{UserId: &userID}, nil
 }
 
-func (h *AuthHandlers) DummyLogin(w http.ResponseWriter, r *http.Request) {
-	var req dto.GetDummyLoginParams
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Errorf(r.Context(), "Error decoding request: %v", err)
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
-	}
-
-	token, err := h.authService.DummyLogin(r.Context(), req)
+func (h *AuthHandlers) GetDummyLogin(ctx context.Context, request dto.GetDummyLoginRequestObject) (dto.GetDummyLoginResponseObject, error) {
+	token, err := h.authService.DummyLogin(ctx, request.Params)
 	if err != nil {
-		logger.Errorf(r.Context(), "Error dummy logging in: %v", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(token)
+	return dto.GetDummyLogin200JSONResponse{Token: &token}, nil
 }
 
-func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
-	var req dto.LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Errorf(r.Context(), "Error decoding request: %v", err)
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
-	}
-
-	token, err := h.authService.Login(r.Context(), req)
+func (h *AuthHandlers) PostLogin(ctx context.Context, request dto.PostLoginRequestObject) (dto.PostLoginResponseObject, error) {
+	token, err := h.authService.Login(ctx, *request.Body)
 	if err != nil {
-		logger.Errorf(r.Context(), "Error logging in: %v", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	logger.Debugf(r.Context(), "User %h logged in", req.Email)
-	json.NewEncoder(w).Encode(token)
+	logger.Debugf(ctx, "User %s logged in", request.Body.Id)
+	return dto.PostLogin200JSONResponse{Token: &token}, nil
 }
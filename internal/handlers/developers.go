@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/service"
+)
+
+type DeveloperHandler struct {
+	developerService *service.DeveloperService
+}
+
+func NewDeveloperHandler(developerService *service.DeveloperService) *DeveloperHandler {
+	return &DeveloperHandler{developerService: developerService}
+}
+
+func (h *DeveloperHandler) PostDevelopers(ctx context.Context, request dto.PostDevelopersRequestObject) (dto.PostDevelopersResponseObject, error) {
+	developer, err := h.developerService.CreateDeveloper(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostDevelopers201JSONResponse(*developer), nil
+}
+
+func (h *DeveloperHandler) GetDevelopers(ctx context.Context, _ dto.GetDevelopersRequestObject) (dto.GetDevelopersResponseObject, error) {
+	developers, err := h.developerService.ListDevelopers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(dto.GetDevelopers200JSONResponse, 0, len(developers))
+	for _, developer := range developers {
+		response = append(response, *developer)
+	}
+
+	return response, nil
+}
+
+func (h *DeveloperHandler) GetDevelopersIdHouses(ctx context.Context, request dto.GetDevelopersIdHousesRequestObject) (dto.GetDevelopersIdHousesResponseObject, error) {
+	houses, err := h.developerService.GetDeveloperHouses(ctx, strconv.Itoa(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	response := make(dto.GetDevelopersIdHouses200JSONResponse, 0, len(houses))
+	for _, house := range houses {
+		response = append(response, *house)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+)
+
+// inlinePattern matches the inline scripts and styles of the docs page.
+var inlinePattern = regexp.MustCompile(`(?s)<(script|style)>(.*?)</(?:script|style)>`)
+
+// DocsHandler serves the spec of the API and the page documenting it.
+type DocsHandler struct {
+	specYAML []byte
+	specJSON []byte
+	page     []byte
+	// pagePolicy is the Content-Security-Policy of the page.
+	pagePolicy string
+}
+
+// NewDocsHandler serves spec, the YAML the server is generated from, as is and converted to JSON, and page as
+// the docs. The page is expected to load the spec from openapi.json next to it.
+func NewDocsHandler(spec, page []byte) (*DocsHandler, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load OpenAPI spec")
+	}
+
+	specJSON, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert OpenAPI spec to JSON")
+	}
+
+	return &DocsHandler{specYAML: spec, specJSON: specJSON, page: page, pagePolicy: pagePolicy(page)}, nil
+}
+
+// pagePolicy allows page its own inline scripts and styles, by their hashes, and requests to the API, nothing
+// else. Framing is left to X-Frame-Options.
+func pagePolicy(page []byte) string {
+	sources := map[string][]string{}
+	for _, match := range inlinePattern.FindAllSubmatch(page, -1) {
+		sum := sha256.Sum256(match[2])
+		kind := string(match[1])
+		sources[kind] = append(sources[kind], "'sha256-"+base64.StdEncoding.EncodeToString(sum[:])+"'")
+	}
+
+	policy := []string{"default-src 'none'", "connect-src 'self'", "base-uri 'none'", "form-action 'none'"}
+	for _, kind := range []string{"script", "style"} {
+		if len(sources[kind]) > 0 {
+			policy = append(policy, kind+"-src "+strings.Join(sources[kind], " "))
+		}
+	}
+
+	return strings.Join(policy, "; ")
+}
+
+func (h *DocsHandler) GetOpenapiYaml(ctx context.Context, request dto.GetOpenapiYamlRequestObject) (dto.GetOpenapiYamlResponseObject, error) {
+	return dto.GetOpenapiYaml200ApplicationyamlResponse{
+		Body:          bytes.NewReader(h.specYAML),
+		ContentLength: int64(len(h.specYAML)),
+	}, nil
+}
+
+func (h *DocsHandler) GetOpenapiJson(ctx context.Context, request dto.GetOpenapiJsonRequestObject) (dto.GetOpenapiJsonResponseObject, error) {
+	return specJSONResponse(h.specJSON), nil
+}
+
+func (h *DocsHandler) GetDocs(ctx context.Context, request dto.GetDocsRequestObject) (dto.GetDocsResponseObject, error) {
+	return docsPageResponse{
+		GetDocs200TexthtmlResponse: dto.GetDocs200TexthtmlResponse{
+			Body:          bytes.NewReader(h.page),
+			ContentLength: int64(len(h.page)),
+		},
+		policy: h.pagePolicy,
+	}, nil
+}
+
+// docsPageResponse replaces the policy of the API responses with the one of the page.
+type docsPageResponse struct {
+	dto.GetDocs200TexthtmlResponse
+	policy string
+}
+
+func (r docsPageResponse) VisitGetDocsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Security-Policy", r.policy)
+	return r.GetDocs200TexthtmlResponse.VisitGetDocsResponse(w)
+}
+
+// specJSONResponse writes the spec converted once, rather than encoding it for every request as the generated
+// response would.
+type specJSONResponse []byte
+
+func (r specJSONResponse) VisitGetOpenapiJsonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(r)
+
+	return err
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	errPreconditionRequired = errors.New("If-Match header is required")
+	errPreconditionFailed   = errors.New("If-Match does not match the current version")
+)
+
+// versionETag is the strong entity tag of a versioned object: its version in quotes.
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// timeETag is the weak entity tag of a listing that changes whenever t does.
+func timeETag(t time.Time) string {
+	return `W/"` + strconv.FormatInt(t.UnixMicro(), 36) + `"`
+}
+
+// ifMatchVersion returns the version named by the If-Match header. An empty header gives
+// errPreconditionRequired, anything but a single version tag can not match and gives errPreconditionFailed.
+func ifMatchVersion(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, errPreconditionRequired
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, errPreconditionFailed
+	}
+
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version < 1 {
+		return 0, errPreconditionFailed
+	}
+
+	return version, nil
+}
+
+// noneMatch reports whether the If-None-Match header lists etag or is a wildcard. Tags are compared
+// weakly, as RFC 9110 requires for If-None-Match.
+func noneMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
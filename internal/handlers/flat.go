@@ -1,15 +1,27 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"mime"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
 	"github.com/shhesterka04/house-service/internal/service"
 	"github.com/shhesterka04/house-service/pkg/logger"
 )
 
+// MaxFlatImportBody limits the size of an import request body.
+const MaxFlatImportBody = 10 << 20
+
+// varyCaller names the request headers the caller is identified by, for responses that depend on it.
+const varyCaller = "Authorization, X-API-Key"
+
+var errUnsupportedImport = errors.New("unsupported content type, use application/json or text/csv")
+
 type FlatHandler struct {
 	flatService *service.FlatService
 }
@@ -18,61 +30,162 @@ func NewFlatHandler(flatService *service.FlatService) *FlatHandler {
 	return &FlatHandler{flatService: flatService}
 }
 
-func (h *FlatHandler) CreateFlat(w http.ResponseWriter, r *http.Request) {
-	var req dto.CreateFlatRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Errorf(r.Context(), "Error decoding request: %v", err)
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
+func (h *FlatHandler) PostFlatCreate(ctx context.Context, request dto.PostFlatCreateRequestObject) (dto.PostFlatCreateResponseObject, error) {
+	createdFlat, err := h.flatService.CreateFlat(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostFlatCreate200JSONResponse{
+		Body:    *createdFlat,
+		Headers: dto.PostFlatCreate200ResponseHeaders{ETag: versionETag(createdFlat.Version)},
+	}, nil
+}
+
+// PostFlatUpdate applies the update to the version of the flat named by the required If-Match header.
+func (h *FlatHandler) PostFlatUpdate(ctx context.Context, request dto.PostFlatUpdateRequestObject) (dto.PostFlatUpdateResponseObject, error) {
+	version, err := ifMatchVersion(request.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedFlat, err := h.flatService.UpdateFlat(ctx, *request.Body, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostFlatUpdate200JSONResponse{
+		Body:    *updatedFlat,
+		Headers: dto.PostFlatUpdate200ResponseHeaders{ETag: versionETag(updatedFlat.Version)},
+	}, nil
+}
+
+func (h *FlatHandler) DeleteFlatId(ctx context.Context, request dto.DeleteFlatIdRequestObject) (dto.DeleteFlatIdResponseObject, error) {
+	if err := h.flatService.DeleteFlat(ctx, strconv.Itoa(request.Id)); err != nil {
+		return nil, err
+	}
+
+	return dto.DeleteFlatId204Response{}, nil
+}
+
+// GetHouseId lists the flats of the house. The ETag follows the update time of the house, so a client
+// holding the current list gets 304 for If-None-Match. The list depends on the caller, hence Vary.
+func (h *FlatHandler) GetHouseId(ctx context.Context, request dto.GetHouseIdRequestObject) (dto.GetHouseIdResponseObject, error) {
+	houseID := strconv.Itoa(request.Id)
+
+	// An unknown house has no flats, its list is as fresh as the zero time.
+	var updatedAt time.Time
+	at, err := h.flatService.HouseFlatsUpdatedAt(ctx, houseID)
+	if err != nil && !errors.Is(err, repository.ErrHouseNotFound) {
+		return nil, err
+	} else if at != nil {
+		updatedAt = *at
 	}
 
-	createdFlat, err := h.flatService.CreateFlat(r.Context(), req)
+	etag := timeETag(updatedAt)
+	if noneMatch(value(request.Params.IfNoneMatch), etag) {
+		return dto.GetHouseId304Response{Headers: dto.GetHouseId304ResponseHeaders{ETag: etag, Vary: varyCaller}}, nil
+	}
+
+	flats, err := h.flatService.GetFlatsByHouseID(ctx, houseID)
 	if err != nil {
-		logger.Errorf(r.Context(), "Error creating flat: %v", err)
-		http.Error(w, "Failed to create flat", http.StatusInternalServerError)
-		return
+		return nil, err
+	}
+
+	body := dto.HouseFlats{Flats: make([]dto.Flat, 0, len(flats))}
+	for _, flat := range flats {
+		body.Flats = append(body.Flats, *flat)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(createdFlat)
+	return dto.GetHouseId200JSONResponse{
+		Body:    body,
+		Headers: dto.GetHouseId200ResponseHeaders{ETag: etag, Vary: varyCaller},
+	}, nil
 }
 
-func (h *FlatHandler) UpdateFlat(w http.ResponseWriter, r *http.Request) {
-	var req dto.PostFlatUpdateJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Errorf(r.Context(), "Error decoding request: %v", err)
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
+// PostHouseIdFlatsImport accepts a JSON array or a CSV document of flats for the house from the path.
+func (h *FlatHandler) PostHouseIdFlatsImport(ctx context.Context, request dto.PostHouseIdFlatsImportRequestObject) (dto.PostHouseIdFlatsImportResponseObject, error) {
+	var items []dto.FlatImportItem
+	switch {
+	case request.JSONBody != nil:
+		items = *request.JSONBody
+	case request.Body != nil:
+		var err error
+		if items, err = service.DecodeFlatImportCSV(request.Body); err != nil {
+			return nil, withStatus(err, http.StatusBadRequest)
+		}
+	default:
+		return nil, withStatus(errUnsupportedImport, http.StatusUnsupportedMediaType)
+	}
+
+	atomic := true
+	if request.Params.Atomic != nil {
+		atomic = *request.Params.Atomic
+	}
+
+	result, err := h.flatService.ImportFlats(ctx, strconv.Itoa(request.Id), items, atomic)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "Flats imported into house %d: %d imported, %d rejected", request.Id, result.Imported, result.Rejected)
+
+	if result.Atomic && result.Rejected > 0 {
+		return dto.PostHouseIdFlatsImport400JSONResponse(*result), nil
 	}
+	return dto.PostHouseIdFlatsImport200JSONResponse(*result), nil
+}
 
-	updatedFlat, err := h.flatService.UpdateFlat(r.Context(), req)
+func (h *FlatHandler) GetHouseIdExport(ctx context.Context, request dto.GetHouseIdExportRequestObject) (dto.GetHouseIdExportResponseObject, error) {
+	export, err := h.flatService.ExportFlats(ctx, strconv.Itoa(request.Id), value(request.Params.Format))
 	if err != nil {
-		logger.Errorf(r.Context(), "Error updating flat: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updatedFlat)
+	return flatExportResponse{ctx: ctx, export: export}, nil
 }
 
-func (h *FlatHandler) GetFlatsByHouseID(w http.ResponseWriter, r *http.Request) {
-	houseIDStr := strings.TrimPrefix(r.URL.Path, "/house/")
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		logger.Debugf(r.Context(), "Authorization header missing")
-		http.Error(w, "Authorization header missing", http.StatusUnauthorized)
-		return
+func (h *FlatHandler) GetFlatsExport(ctx context.Context, request dto.GetFlatsExportRequestObject) (dto.GetFlatsExportResponseObject, error) {
+	export, err := h.flatService.ExportAllFlats(ctx, value(request.Params.Format))
+	if err != nil {
+		return nil, err
 	}
 
-	token := strings.TrimPrefix(authHeader, "Bearer ")
-	flats, err := h.flatService.GetFlatsByHouseID(r.Context(), houseIDStr, token)
+	return flatExportResponse{ctx: ctx, export: export}, nil
+}
+
+func (h *FlatHandler) GetFlatsSearch(ctx context.Context, request dto.GetFlatsSearchRequestObject) (dto.GetFlatsSearchResponseObject, error) {
+	result, err := h.flatService.SearchFlats(ctx, request.Params)
 	if err != nil {
-		logger.Errorf(r.Context(), "Error getting flats by house ID: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, err
+	}
+
+	return dto.GetFlatsSearch200JSONResponse(*result), nil
+}
+
+// flatExportResponse streams an export in the content type of its format, which the generated responses
+// can not choose at run time.
+type flatExportResponse struct {
+	ctx    context.Context
+	export *service.FlatExport
+}
+
+func (r flatExportResponse) VisitGetHouseIdExportResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r flatExportResponse) VisitGetFlatsExportResponse(w http.ResponseWriter) error {
+	return r.write(w)
+}
+
+func (r flatExportResponse) write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", r.export.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": r.export.FileName}))
+
+	// The status is already sent once rows are written, so a failure midway can only be logged.
+	if err := r.export.WriteTo(r.ctx, w); err != nil {
+		logger.Errorf(r.ctx, "Error streaming flats export: %v", err)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(flats)
+	return nil
 }
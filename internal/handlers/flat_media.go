@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+type FlatMediaHandler struct {
+	mediaService *service.FlatMediaService
+}
+
+func NewFlatMediaHandler(mediaService *service.FlatMediaService) *FlatMediaHandler {
+	return &FlatMediaHandler{mediaService: mediaService}
+}
+
+func (h *FlatMediaHandler) PostFlatIdMedia(ctx context.Context, request dto.PostFlatIdMediaRequestObject) (dto.PostFlatIdMediaResponseObject, error) {
+	media, err := h.mediaService.Upload(ctx, strconv.Itoa(request.Id), value(request.Params.Kind), request.ContentType, request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostFlatIdMedia201JSONResponse(*media), nil
+}
+
+func (h *FlatMediaHandler) GetFlatIdMedia(ctx context.Context, request dto.GetFlatIdMediaRequestObject) (dto.GetFlatIdMediaResponseObject, error) {
+	media, err := h.mediaService.List(ctx, strconv.Itoa(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetFlatIdMedia200JSONResponse(media), nil
+}
+
+func (h *FlatMediaHandler) GetFlatIdMediaMediaId(ctx context.Context, request dto.GetFlatIdMediaMediaIdRequestObject) (dto.GetFlatIdMediaMediaIdResponseObject, error) {
+	content, err := h.mediaService.Open(ctx, strconv.Itoa(request.Id), strconv.Itoa(request.MediaId), value(request.Params.Thumbnail))
+	if err != nil {
+		return nil, err
+	}
+
+	return flatMediaResponse{ctx: ctx, content: content}, nil
+}
+
+func (h *FlatMediaHandler) DeleteFlatIdMediaMediaId(ctx context.Context, request dto.DeleteFlatIdMediaMediaIdRequestObject) (dto.DeleteFlatIdMediaMediaIdResponseObject, error) {
+	if err := h.mediaService.Delete(ctx, strconv.Itoa(request.Id), strconv.Itoa(request.MediaId)); err != nil {
+		return nil, err
+	}
+
+	return dto.DeleteFlatIdMediaMediaId204Response{}, nil
+}
+
+// flatMediaResponse streams a stored file in the content type it was sniffed as on upload.
+type flatMediaResponse struct {
+	ctx     context.Context
+	content *service.FlatMediaContent
+}
+
+func (r flatMediaResponse) VisitGetFlatIdMediaMediaIdResponse(w http.ResponseWriter) error {
+	defer r.content.Body.Close()
+
+	w.Header().Set("Content-Type", r.content.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := io.Copy(w, r.content.Body); err != nil {
+		logger.Errorf(r.ctx, "Error streaming flat media: %v", err)
+	}
+
+	return nil
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
+	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
 	"github.com/shhesterka04/house-service/internal/service"
 	"github.com/shhesterka04/house-service/pkg/logger"
 )
@@ -17,21 +20,44 @@ func NewHouseHandler(houseService *service.HouseService) *HouseHandler {
 	return &HouseHandler{houseService: houseService}
 }
 
-func (h *HouseHandler) CreateHouse(w http.ResponseWriter, r *http.Request) {
-	var req dto.PostHouseCreateJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Errorf(r.Context(), "Error decoding request: %v", err)
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
-		return
+func (h *HouseHandler) PostHouseCreate(ctx context.Context, request dto.PostHouseCreateRequestObject) (dto.PostHouseCreateResponseObject, error) {
+	house, err := h.houseService.CreateHouse(ctx, *request.Body)
+	if errors.Is(err, repository.ErrDeveloperNotFound) {
+		return nil, withStatus(err, http.StatusBadRequest)
+	} else if err != nil {
+		return nil, err
 	}
 
-	house, err := h.houseService.CreateHouse(r.Context(), req)
+	if len(house.Similar) > 0 {
+		logger.Infof(ctx, "House %d created with %d similar addresses", house.Id, len(house.Similar))
+	}
+
+	return dto.PostHouseCreate200JSONResponse(*house), nil
+}
+
+func (h *HouseHandler) GetHousesSearch(ctx context.Context, request dto.GetHousesSearchRequestObject) (dto.GetHousesSearchResponseObject, error) {
+	matches, err := h.houseService.SearchHouses(ctx, request.Params.Q, value(request.Params.City), value(request.Params.Limit))
 	if err != nil {
-		logger.Errorf(r.Context(), "Error creating house: %v", err)
-		http.Error(w, "Failed to create house", http.StatusInternalServerError)
-		return
+		return nil, err
+	}
+
+	return dto.GetHousesSearch200JSONResponse(matches), nil
+}
+
+func (h *HouseHandler) GetHousesNearby(ctx context.Context, request dto.GetHousesNearbyRequestObject) (dto.GetHousesNearbyResponseObject, error) {
+	params := request.Params
+	houses, err := h.houseService.NearbyHouses(ctx, params.Lat, params.Lon, value(params.RadiusKm), value(params.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetHousesNearby200JSONResponse(houses), nil
+}
+
+func (h *HouseHandler) PostHouseIdSubscribe(ctx context.Context, request dto.PostHouseIdSubscribeRequestObject) (dto.PostHouseIdSubscribeResponseObject, error) {
+	if err := h.houseService.Subscribe(ctx, strconv.Itoa(request.Id), string(request.Body.Email)); err != nil {
+		return nil, err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(house)
+	return dto.PostHouseIdSubscribe200Response{}, nil
 }
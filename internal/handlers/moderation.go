@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/service"
+)
+
+type ModerationHandler struct {
+	moderationService *service.ModerationService
+}
+
+func NewModerationHandler(moderationService *service.ModerationService) *ModerationHandler {
+	return &ModerationHandler{moderationService: moderationService}
+}
+
+func (h *ModerationHandler) GetModerationQueue(ctx context.Context, request dto.GetModerationQueueRequestObject) (dto.GetModerationQueueResponseObject, error) {
+	queue, err := h.moderationService.Queue(ctx, request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetModerationQueue200JSONResponse(*queue), nil
+}
+
+func (h *ModerationHandler) GetModerationDeclineReasons(ctx context.Context, _ dto.GetModerationDeclineReasonsRequestObject) (dto.GetModerationDeclineReasonsResponseObject, error) {
+	reasons, err := h.moderationService.DeclineReasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetModerationDeclineReasons200JSONResponse(reasons), nil
+}
+
+func (h *ModerationHandler) PutModerationDeclineReasonsCode(ctx context.Context, request dto.PutModerationDeclineReasonsCodeRequestObject) (dto.PutModerationDeclineReasonsCodeResponseObject, error) {
+	reason, err := h.moderationService.SaveDeclineReason(ctx, request.Code, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PutModerationDeclineReasonsCode200JSONResponse(*reason), nil
+}
+
+func (h *ModerationHandler) GetFlatIdDecisions(ctx context.Context, request dto.GetFlatIdDecisionsRequestObject) (dto.GetFlatIdDecisionsResponseObject, error) {
+	decisions, err := h.moderationService.FlatDecisions(ctx, strconv.Itoa(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.GetFlatIdDecisions200JSONResponse(decisions), nil
+}
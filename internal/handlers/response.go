@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/audit"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/blobstore"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+const (
+	// ruleFormat is the rule broken by a parameter or body that can not be parsed.
+	ruleFormat = "format"
+	// ruleUnknownField is the rule broken by a body field the spec does not define.
+	ruleUnknownField = "unknown_field"
+)
+
+// statusError makes WriteError respond with status, for errors whose status depends on the operation.
+type statusError struct {
+	error
+	status int
+}
+
+func (e *statusError) Unwrap() error {
+	return e.error
+}
+
+func withStatus(err error, status int) error {
+	return &statusError{error: err, status: status}
+}
+
+// errorStatuses maps the errors of the services to the status of the response. Errors not listed are
+// internal and give 500.
+var errorStatuses = []struct {
+	status int
+	errs   []error
+}{
+	{http.StatusUnauthorized, []error{service.ErrUnauthorized}},
+	{http.StatusForbidden, []error{service.ErrForbidden, service.ErrAdminImmutable, service.ErrUserDisabled}},
+	{http.StatusBadRequest, []error{
+		service.ErrInvalidHouseID, service.ErrInvalidFlatID, service.ErrInvalidFlatMediaID, service.ErrInvalidUserID,
+		service.ErrInvalidAPIKeyID, service.ErrInvalidDeveloperID, service.ErrInvalidCursor, service.ErrInvalidUserType,
+		service.ErrInValidEmail, service.ErrorInvalidLogin, service.ErrInvalidExportFormat,
+		repository.ErrUserExists, repository.ErrDeclineReasonNotFound,
+	}},
+	{http.StatusNotFound, []error{
+		repository.ErrHouseNotFound, repository.ErrFlatNotFound, repository.ErrFlatMediaNotFound, repository.ErrUserNotFound,
+		repository.ErrAPIKeyNotFound, repository.ErrDeveloperNotFound, service.ErrFlatMediaNoThumbnail, blobstore.ErrNotFound,
+	}},
+	{http.StatusConflict, []error{repository.ErrHouseExists, repository.ErrDeveloperExists}},
+	{http.StatusPreconditionFailed, []error{errPreconditionFailed, repository.ErrFlatModified}},
+	{http.StatusPreconditionRequired, []error{errPreconditionRequired}},
+	{http.StatusRequestEntityTooLarge, []error{service.ErrFlatMediaTooLarge}},
+}
+
+// WriteError responds to a request an operation failed with the status matching err. Validation errors
+// list the violated rules, internal errors only name the request, the error itself is logged.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	logger.Errorf(r.Context(), "%s %s: %v", r.Method, r.URL.Path, err)
+
+	var (
+		maxBytesErr *http.MaxBytesError
+		statusErr   *statusError
+	)
+	switch {
+	case errors.As(err, &maxBytesErr):
+		http.Error(w, "Request body is too large", http.StatusRequestEntityTooLarge)
+	case errors.As(err, &statusErr):
+		if !writeValidationError(w, statusErr.status, err) {
+			http.Error(w, statusErr.Error(), statusErr.status)
+		}
+	case writeValidationError(w, http.StatusBadRequest, err):
+	default:
+		for _, group := range errorStatuses {
+			for _, target := range group.errs {
+				if errors.Is(err, target) {
+					http.Error(w, target.Error(), group.status)
+					return
+				}
+			}
+		}
+
+		body := dto.Error{Message: "Internal server error"}
+		if id := audit.RequestIDFromContext(r.Context()); id != "" {
+			body.RequestId = &id
+		}
+		writeJSON(w, http.StatusInternalServerError, body)
+	}
+}
+
+// WriteRequestError responds to a request that does not match the spec: its parameters or body can not be
+// parsed or break the rules of the spec. A missing If-Match gives 428, a body over the limit of the route 413,
+// anything else 400, with the violated rules if the request was checked against the spec.
+func WriteRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	logger.Errorf(r.Context(), "%s %s: invalid request: %v", r.Method, r.URL.Path, err)
+
+	var (
+		headerErr   *dto.RequiredHeaderError
+		maxBytesErr *http.MaxBytesError
+		specErr     openapi3.MultiError
+	)
+	switch {
+	case errors.As(err, &headerErr) && http.CanonicalHeaderKey(headerErr.ParamName) == "If-Match",
+		missingIfMatch(err):
+		http.Error(w, errPreconditionRequired.Error(), http.StatusPreconditionRequired)
+	case errors.As(err, &maxBytesErr):
+		http.Error(w, "Request body is too large", http.StatusRequestEntityTooLarge)
+	case errors.As(err, &specErr):
+		writeJSON(w, http.StatusBadRequest, dto.ValidationErrorResponse{
+			Message: "Invalid request payload",
+			Errors:  specErrorDetails(specErr),
+		})
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// missingIfMatch reports whether the spec check of a request found the If-Match header missing.
+func missingIfMatch(err error) bool {
+	var specErr openapi3.MultiError
+	if !errors.As(err, &specErr) {
+		return false
+	}
+
+	for _, e := range specErr {
+		var requestErr *openapi3filter.RequestError
+		if errors.As(e, &requestErr) && requestErr.Parameter != nil &&
+			http.CanonicalHeaderKey(requestErr.Parameter.Name) == "If-Match" && errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// specErrorDetails lists the rules of the spec a request breaks. Parameters are named as in the spec, fields
+// of the body by their path, e.g. "0.price" for the price of the first flat of an import.
+func specErrorDetails(specErr openapi3.MultiError) []dto.ErrorDetail {
+	var details []dto.ErrorDetail
+	for _, e := range specErr {
+		var requestErr *openapi3filter.RequestError
+		if !errors.As(e, &requestErr) {
+			details = append(details, dto.ErrorDetail{Rule: ruleFormat, Message: e.Error()})
+			continue
+		}
+
+		var field string
+		if requestErr.Parameter != nil {
+			field = requestErr.Parameter.Name
+		}
+
+		schemaErrs := schemaErrors(requestErr.Err)
+		for _, schemaErr := range schemaErrs {
+			detail := dto.ErrorDetail{Field: field, Rule: schemaErr.SchemaField, Message: schemaErr.Reason}
+
+			// kin-openapi reports an unknown field at the object holding it, the name is only in the reason.
+			path := schemaErr.JSONPointer()
+			var name string
+			if _, err := fmt.Sscanf(schemaErr.Reason, "property %q is unsupported", &name); err == nil {
+				path = append(path, name)
+				detail.Rule = ruleUnknownField
+			}
+			if requestErr.Parameter == nil && len(path) > 0 {
+				detail.Field = strings.Join(path, ".")
+			}
+
+			details = append(details, detail)
+		}
+
+		if len(schemaErrs) > 0 {
+			continue
+		}
+
+		rule := ruleFormat
+		if errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired) || errors.Is(requestErr.Err, openapi3filter.ErrInvalidEmptyValue) {
+			rule = service.RuleRequired
+		}
+		details = append(details, dto.ErrorDetail{Field: field, Rule: rule, Message: requestErr.Error()})
+	}
+
+	return details
+}
+
+// schemaErrors unpacks the schema violations err consists of, if any.
+func schemaErrors(err error) []*openapi3.SchemaError {
+	if multiErr, ok := err.(openapi3.MultiError); ok {
+		var schemaErrs []*openapi3.SchemaError
+		for _, e := range multiErr {
+			schemaErrs = append(schemaErrs, schemaErrors(e)...)
+		}
+		return schemaErrs
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []*openapi3.SchemaError{schemaErr}
+	}
+
+	return nil
+}
+
+// writeValidationError responds with status and the list of violated rules if err is a validation error.
+// It reports whether the error was handled.
+func writeValidationError(w http.ResponseWriter, status int, err error) bool {
+	var validationErr *service.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	writeJSON(w, status, dto.ValidationErrorResponse{
+		Message: "Invalid request payload",
+		Errors:  validationErr.Details,
+	})
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
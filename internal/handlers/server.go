@@ -0,0 +1,45 @@
+package handlers
+
+import "github.com/shhesterka04/house-service/internal/dto"
+
+// Server implements the operations of api/api.yaml. Routing, parameter parsing and body decoding are
+// generated from the spec, see routes.NewRouter; errors returned by the operations are answered by WriteError.
+type Server struct {
+	*AuthHandlers
+	*HouseHandler
+	*FlatHandler
+	*FlatMediaHandler
+	*ModerationHandler
+	*AuditHandler
+	*UserHandler
+	*APIKeyHandler
+	*DeveloperHandler
+	*DocsHandler
+}
+
+var _ dto.StrictServerInterface = (*Server)(nil)
+
+func NewServer(auth *AuthHandlers, house *HouseHandler, flat *FlatHandler, flatMedia *FlatMediaHandler, moderation *ModerationHandler, audit *AuditHandler, user *UserHandler, apiKey *APIKeyHandler, developer *DeveloperHandler, docs *DocsHandler) *Server {
+	return &Server{
+		AuthHandlers:      auth,
+		HouseHandler:      house,
+		FlatHandler:       flat,
+		FlatMediaHandler:  flatMedia,
+		ModerationHandler: moderation,
+		AuditHandler:      audit,
+		UserHandler:       user,
+		APIKeyHandler:     apiKey,
+		DeveloperHandler:  developer,
+		DocsHandler:       docs,
+	}
+}
+
+// value returns what p points to, or the zero value for an omitted optional parameter.
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+
+	return *p
+}
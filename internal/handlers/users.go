@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+type UserHandler struct {
+	userService *service.UserService
+}
+
+func NewUserHandler(userService *service.UserService) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+func (h *UserHandler) PostAdminUsersIdRole(ctx context.Context, request dto.PostAdminUsersIdRoleRequestObject) (dto.PostAdminUsersIdRoleResponseObject, error) {
+	user, err := h.userService.SetRole(ctx, request.Id.String(), request.Body.UserType)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "User %s role set to %s", user.Id, user.UserType)
+	return dto.PostAdminUsersIdRole200JSONResponse(*user), nil
+}
+
+func (h *UserHandler) PostAdminUsersIdDisable(ctx context.Context, request dto.PostAdminUsersIdDisableRequestObject) (dto.PostAdminUsersIdDisableResponseObject, error) {
+	user, err := h.setDisabled(ctx, request.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostAdminUsersIdDisable200JSONResponse(*user), nil
+}
+
+func (h *UserHandler) PostAdminUsersIdEnable(ctx context.Context, request dto.PostAdminUsersIdEnableRequestObject) (dto.PostAdminUsersIdEnableResponseObject, error) {
+	user, err := h.setDisabled(ctx, request.Id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PostAdminUsersIdEnable200JSONResponse(*user), nil
+}
+
+func (h *UserHandler) setDisabled(ctx context.Context, id dto.UserId, disabled bool) (*dto.User, error) {
+	user, err := h.userService.SetDisabled(ctx, id.String(), disabled)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "User %s disabled=%v", user.Id, user.Disabled)
+	return user, nil
+}
@@ -1,44 +1,63 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v4"
-	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
 	"github.com/shhesterka04/house-service/internal/service"
 	"github.com/shhesterka04/house-service/pkg/logger"
 )
 
-func AuthMiddleware(requiredType dto.UserType) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			logger.Infof(r.Context(), "authHeader: %s", authHeader)
-			if authHeader == "" {
-				http.Error(w, "Authorization header missing", http.StatusUnauthorized)
-				return
-			}
+const apiKeyHeader = "X-API-Key"
 
-			tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-			claims := &jwt.RegisteredClaims{}
+type APIKeyResolver interface {
+	Resolve(ctx context.Context, key string) (*service.Claims, error)
+}
 
-			token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-				return service.JwtKey, nil
-			})
+// TokenResolver checks a bearer token against the current state of its account.
+type TokenResolver interface {
+	ResolveToken(ctx context.Context, token string) (*service.Claims, error)
+}
 
-			if err != nil || !token.Valid {
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
-				return
+// AuthMiddleware authenticates the caller by an X-API-Key header or a bearer token and lets
+// the request through only if the caller is allowed to perform action.
+func AuthMiddleware(apiKeys APIKeyResolver, tokens TokenResolver, action policy.Action) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var claims *service.Claims
+			var err error
+
+			if apiKey := r.Header.Get(apiKeyHeader); apiKey != "" {
+				claims, err = apiKeys.Resolve(r.Context(), apiKey)
+				if err != nil {
+					logger.Debugf(r.Context(), "api key rejected: %v", err)
+					http.Error(w, "Invalid API key", http.StatusUnauthorized)
+					return
+				}
+			} else {
+				authHeader := r.Header.Get("Authorization")
+				if authHeader == "" {
+					http.Error(w, "Authorization header missing", http.StatusUnauthorized)
+					return
+				}
+
+				tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
+				claims, err = tokens.ResolveToken(r.Context(), tokenStr)
+				if err != nil {
+					logger.Debugf(r.Context(), "token rejected: %v", err)
+					http.Error(w, "Invalid token", http.StatusUnauthorized)
+					return
+				}
 			}
 
-			userType := claims.Subject
-			if requiredType == dto.Moderator && userType != "moderator" {
+			if !claims.Allows(action) {
 				http.Error(w, "Insufficient permissions", http.StatusForbidden)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(service.ContextWithClaims(r.Context(), claims)))
 		})
 	}
 }
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const forwardedForHeader = "X-Forwarded-For"
+
+type clientIPCtxKey struct{}
+
+// ClientIP tags the request with the IP of the client. A request from one of trustedProxies is taken to be
+// made by the last address of its X-Forwarded-For that is not a trusted proxy itself, any other request by
+// the address it comes from, so that clients can not pick their address by sending the header themselves.
+func ClientIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r, trustedProxies)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPCtxKey{}, ip)))
+		})
+	}
+}
+
+// clientIPFromRequest returns the IP tagged by ClientIP, or the address r comes from outside of it.
+func clientIPFromRequest(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPCtxKey{}).(string); ok {
+		return ip
+	}
+
+	return remoteHost(r)
+}
+
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host := remoteHost(r)
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !trusted(addr, trustedProxies) {
+		return host
+	}
+
+	// Every proxy appends the address it got the request from, so the client is the first address from the
+	// right that no trusted proxy stands for.
+	hops := strings.Split(strings.Join(r.Header.Values(forwardedForHeader), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		addr = hop.Unmap()
+		if !trusted(addr, trustedProxies) {
+			break
+		}
+	}
+
+	return addr.String()
+}
+
+func trusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
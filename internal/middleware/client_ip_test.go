@@ -0,0 +1,91 @@
+//go:build unit
+// +build unit
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/shhesterka04/house-service/internal/middleware"
+	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		sameAs       string
+		wantSame     bool
+	}{
+		{
+			name:         "header of a trusted proxy is used",
+			remoteAddr:   "10.0.0.1:1",
+			forwardedFor: []string{"203.0.113.1"},
+			sameAs:       "203.0.113.1:1",
+			wantSame:     true,
+		},
+		{
+			name:         "header of an untrusted client is ignored",
+			remoteAddr:   "198.51.100.1:1",
+			forwardedFor: []string{"203.0.113.1"},
+			sameAs:       "198.51.100.1:2",
+			wantSame:     true,
+		},
+		{
+			name:         "addresses added by the client are skipped",
+			remoteAddr:   "10.0.0.1:1",
+			forwardedFor: []string{"192.0.2.7, 203.0.113.1", "10.0.0.2"},
+			sameAs:       "203.0.113.1:1",
+			wantSame:     true,
+		},
+		{
+			name:         "clients behind the proxy are told apart",
+			remoteAddr:   "10.0.0.1:1",
+			forwardedFor: []string{"203.0.113.1"},
+			sameAs:       "10.0.0.1:1",
+			wantSame:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The rate limit shares one bucket between two requests only if they are of the same client IP.
+			limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+			h := middleware.ClientIP(trusted)(middleware.RateLimit(ratelimit.NewMemory(), "POST /login", limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+			do := func(remoteAddr string, forwardedFor []string) int {
+				req := httptest.NewRequest(http.MethodPost, "/login", nil)
+				req.RemoteAddr = remoteAddr
+				for _, v := range forwardedFor {
+					req.Header.Add("X-Forwarded-For", v)
+				}
+
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, req.WithContext(logger.ToContext(req.Context(), zap.NewNop())))
+				return w.Code
+			}
+
+			assert.Equal(t, http.StatusOK, do(tt.remoteAddr, tt.forwardedFor))
+			second := do(tt.sameAs, nil)
+			if tt.wantSame {
+				assert.Equal(t, http.StatusTooManyRequests, second)
+			} else {
+				assert.Equal(t, http.StatusOK, second)
+			}
+		})
+	}
+}
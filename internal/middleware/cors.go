@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions say which browser pages may call the API and how.
+type CORSOptions struct {
+	// AllowedOrigins may call the API, "*" for any origin.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers the calling page may read besides the simple ones.
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long the browser may cache a preflight, 0 for the default of the browser.
+	MaxAge time.Duration
+}
+
+// CORS lets pages on the allowed origins call the routes it wraps. It answers preflight requests itself, so an
+// OPTIONS route of each path is to be wrapped as well. Requests from other origins are served without CORS
+// headers, which keeps the browser from showing the response to the page.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	anyOrigin := slices.Contains(opts.AllowedOrigins, "*")
+	methods := strings.Join(opts.AllowedMethods, ", ")
+	headers := strings.Join(opts.AllowedHeaders, ", ")
+	exposed := strings.Join(opts.ExposedHeaders, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !(anyOrigin || slices.Contains(opts.AllowedOrigins, origin)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if anyOrigin && !opts.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				// The response differs by origin, handlers setting Vary of their own must keep it so.
+				w = &varyOrigin{ResponseWriter: w}
+			}
+			if opts.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+				if opts.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if exposed != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposed)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// varyOrigin adds Origin to the Vary header of the response once the handler is done with the headers.
+type varyOrigin struct {
+	http.ResponseWriter
+	added bool
+}
+
+func (w *varyOrigin) WriteHeader(status int) {
+	w.addVary()
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *varyOrigin) Write(b []byte) (int, error) {
+	w.addVary()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *varyOrigin) addVary() {
+	if w.added {
+		return
+	}
+	w.added = true
+
+	for _, value := range w.Header().Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(name), "Origin") || strings.TrimSpace(name) == "*" {
+				return
+			}
+		}
+	}
+	w.Header().Add("Vary", "Origin")
+}
@@ -0,0 +1,119 @@
+//go:build unit
+// +build unit
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shhesterka04/house-service/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	listed := middleware.CORSOptions{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+	anyOrigin := middleware.CORSOptions{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}}
+
+	tests := []struct {
+		name        string
+		opts        middleware.CORSOptions
+		method      string
+		origin      string
+		preflight   bool
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name: "preflight from a listed origin", opts: listed, method: http.MethodOptions, origin: "https://app.example.com", preflight: true,
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, POST",
+				"Access-Control-Allow-Headers":     "Authorization, Content-Type",
+				"Access-Control-Max-Age":           "600",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name: "request from a listed origin", opts: listed, method: http.MethodGet, origin: "https://app.example.com",
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "https://app.example.com",
+				"Access-Control-Expose-Headers": "ETag",
+				"Access-Control-Allow-Methods":  "",
+				"Vary":                          "Origin",
+			},
+		},
+		{
+			name: "preflight from another origin", opts: listed, method: http.MethodOptions, origin: "https://evil.example.com", preflight: true,
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""},
+		},
+		{
+			name: "request without origin", opts: listed, method: http.MethodGet,
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name: "any origin", opts: anyOrigin, method: http.MethodGet, origin: "https://other.example.com",
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "*", "Access-Control-Allow-Credentials": "", "Vary": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/flat/create", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+
+			w := httptest.NewRecorder()
+			middleware.CORS(tt.opts)(ok).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			for name, want := range tt.wantHeaders {
+				assert.Equal(t, want, w.Header().Get(name), name)
+			}
+		})
+	}
+}
+
+func TestCORSKeepsVaryOfHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Authorization, X-API-Key")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/house/1", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+
+	w := httptest.NewRecorder()
+	middleware.CORS(middleware.CORSOptions{AllowedOrigins: []string{"https://app.example.com"}})(handler).ServeHTTP(w, req)
+
+	assert.Equal(t, []string{"Authorization, X-API-Key", "Origin"}, w.Header().Values("Vary"))
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Deprecated marks the responses of a deprecated route. Deprecation (RFC 9745) tells since when the route is
+// deprecated and Sunset (RFC 8594) until when it is served, either is left out if zero. Link points to the
+// same request under successorPrefix, the route to use instead.
+func Deprecated(since, sunset time.Time, successorPrefix string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !since.IsZero() {
+				w.Header().Set("Deprecation", "@"+strconv.FormatInt(since.Unix(), 10))
+			}
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			w.Header().Set("Link", "<"+successorPrefix+r.URL.EscapedPath()+`>; rel="successor-version"`)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotentRequestBody = 1 << 20
+)
+
+var idempotencyKeyPattern = regexp.MustCompile(`^[\x21-\x7e]{1,255}$`)
+
+// replayedHeaders are the response headers stored with an idempotency key and sent again on replay.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+type IdempotencyStore interface {
+	ReserveIdempotencyKey(ctx context.Context, record *repository.IdempotencyRecord, lockTimeout time.Duration) (*repository.IdempotencyRecord, error)
+	SaveIdempotentResponse(ctx context.Context, record *repository.IdempotencyRecord, ttl time.Duration) error
+	ReleaseIdempotencyKey(ctx context.Context, record *repository.IdempotencyRecord) error
+}
+
+// Idempotency makes retries of a request with the same Idempotency-Key safe. The first request runs and its
+// response is kept for ttl, identical retries by the same caller get that response again. A retry while the
+// first request runs gets 409, reusing the key for another request 422. Server errors, and responses that
+// could not be stored, are not kept, so the request runs again on retry. A request that neither completes nor
+// releases its key, as when the process dies, holds it for lockTimeout at most; once another request has taken
+// the key over, the late request changes nothing. Requests without the header are passed through. It must run
+// after AuthMiddleware, keys are scoped to the caller.
+func Idempotency(store IdempotencyStore, ttl, lockTimeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !idempotencyKeyPattern.MatchString(key) {
+				http.Error(w, "Invalid Idempotency-Key header", http.StatusBadRequest)
+				return
+			}
+
+			claims, ok := service.ClaimsFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBody))
+			if err != nil {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			record := &repository.IdempotencyRecord{Scope: claims.Principal(), Key: key, RequestHash: requestHash(r, body)}
+			existing, err := store.ReserveIdempotencyKey(r.Context(), record, lockTimeout)
+			if err != nil {
+				logger.Errorf(r.Context(), "reserve idempotency key: %v", err)
+				http.Error(w, "Failed to check Idempotency-Key", http.StatusInternalServerError)
+				return
+			}
+
+			switch {
+			case existing == nil:
+			case existing.RequestHash != record.RequestHash:
+				http.Error(w, "Idempotency-Key was used for another request", http.StatusUnprocessableEntity)
+				return
+			case existing.Status == 0:
+				http.Error(w, "A request with this Idempotency-Key is in progress", http.StatusConflict)
+				return
+			default:
+				replay(w, existing)
+				return
+			}
+
+			// The outcome is recorded even if the client has gone, its retry must see it.
+			ctx := context.WithoutCancel(r.Context())
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if err := store.ReleaseIdempotencyKey(ctx, record); err != nil {
+					logger.Errorf(ctx, "release idempotency key: %v", err)
+				}
+			}()
+
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.status >= http.StatusInternalServerError {
+				return
+			}
+
+			record.Status = rec.status
+			if record.Status == 0 {
+				record.Status = http.StatusOK
+			}
+			record.Header = make(map[string]string, len(replayedHeaders))
+			for _, name := range replayedHeaders {
+				if v := w.Header().Get(name); v != "" {
+					record.Header[name] = v
+				}
+			}
+			record.Body = rec.body.Bytes()
+			if err = store.SaveIdempotentResponse(ctx, record, ttl); err != nil {
+				logger.Errorf(ctx, "save idempotent response: %v", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// requestHash identifies a request by its method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, record *repository.IdempotencyRecord) {
+	for name, v := range record.Header {
+		w.Header().Set(name, v)
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(record.Status)
+	w.Write(record.Body)
+}
+
+// responseRecorder passes the response through and keeps a copy of its status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,241 @@
+//go:build unit
+// +build unit
+
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/middleware"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryIdempotencyStore keeps records in memory, they never expire. With failSave responses are not stored.
+type memoryIdempotencyStore struct {
+	mu       sync.Mutex
+	records  map[string]repository.IdempotencyRecord
+	tokens   int
+	failSave bool
+}
+
+func (s *memoryIdempotencyStore) ReserveIdempotencyKey(_ context.Context, record *repository.IdempotencyRecord, _ time.Duration) (*repository.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[record.Scope+"/"+record.Key]; ok {
+		return &existing, nil
+	}
+	s.tokens++
+	record.Token = strconv.Itoa(s.tokens)
+	s.records[record.Scope+"/"+record.Key] = *record
+
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) SaveIdempotentResponse(_ context.Context, record *repository.IdempotencyRecord, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failSave {
+		return errors.New("save failed")
+	}
+	if reserved := s.records[record.Scope+"/"+record.Key]; reserved.Token != record.Token || reserved.Status != 0 {
+		return repository.ErrIdempotencyKeyLost
+	}
+
+	s.records[record.Scope+"/"+record.Key] = *record
+
+	return nil
+}
+
+func (s *memoryIdempotencyStore) ReleaseIdempotencyKey(_ context.Context, record *repository.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reserved := s.records[record.Scope+"/"+record.Key]; reserved.Token == record.Token && reserved.Status == 0 {
+		delete(s.records, record.Scope+"/"+record.Key)
+	}
+
+	return nil
+}
+
+// takeOver gives the key to another request, as a reservation after the lock timeout of its request does.
+func (s *memoryIdempotencyStore) takeOver(scope, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.records[scope+"/"+key]
+	s.tokens++
+	record.Token = strconv.Itoa(s.tokens)
+	s.records[scope+"/"+key] = record
+}
+
+func TestIdempotency(t *testing.T) {
+	store := &memoryIdempotencyStore{records: map[string]repository.IdempotencyRecord{}}
+	calls := 0
+	status := http.StatusOK
+	handler := middleware.Idempotency(store, time.Hour, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"1"`)
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+
+	do := func(userID, key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newIdempotentRequest(userID, key, body))
+		return w
+	}
+	doDummy := func(token, key, body string) *httptest.ResponseRecorder {
+		claims, err := service.ParseJWT(token)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/flat/create", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", key)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(service.ContextWithClaims(logger.ToContext(req.Context(), zap.NewNop()), claims)))
+		return w
+	}
+
+	const user, otherUser = "cae36e0f-69e5-4fa8-a179-a52d083c5549", "5d0f3c1a-2b4e-4f6a-8c9d-0e1f2a3b4c5d"
+
+	t.Run("without a key every request runs", func(t *testing.T) {
+		do(user, "", `{"number":1}`)
+		do(user, "", `{"number":1}`)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("retry gets the stored response", func(t *testing.T) {
+		calls = 0
+		first := do(user, "key-1", `{"number":2}`)
+		retry := do(user, "key-1", `{"number":2}`)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, first.Code, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+		assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("key reused for another request", func(t *testing.T) {
+		w := do(user, "key-1", `{"number":3}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("keys are scoped to the user", func(t *testing.T) {
+		calls = 0
+		w := do(otherUser, "key-1", `{"number":3}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("request in progress", func(t *testing.T) {
+		started, release, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+		slow := middleware.Idempotency(store, time.Hour, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}))
+		go func() {
+			defer close(done)
+			slow.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(user, "key-2", `{"number":4}`))
+		}()
+		<-started
+
+		w := do(user, "key-2", `{"number":4}`)
+		close(release)
+		<-done
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("server errors are not stored", func(t *testing.T) {
+		calls = 0
+		status = http.StatusInternalServerError
+		do(user, "key-5", `{"number":5}`)
+		status = http.StatusOK
+		w := do(user, "key-5", `{"number":5}`)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("responses that are not stored are not kept", func(t *testing.T) {
+		calls = 0
+		store.failSave = true
+		do(user, "key-6", `{"number":6}`)
+		store.failSave = false
+		w := do(user, "key-6", `{"number":6}`)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("request that lost its key leaves it to the new holder", func(t *testing.T) {
+		for _, late := range []int{http.StatusCreated, http.StatusInternalServerError} {
+			key := "key-lost-" + strconv.Itoa(late)
+			stale := middleware.Idempotency(store, time.Hour, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				store.takeOver("user:"+user, key)
+				w.WriteHeader(late)
+			}))
+			stale.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(user, key, `{"number":8}`))
+
+			w := do(user, key, `{"number":8}`)
+			assert.Equal(t, http.StatusConflict, w.Code, "late response %d", late)
+		}
+	})
+
+	t.Run("keys are scoped to the dummy token", func(t *testing.T) {
+		first, err := service.GenerateDummyJWT(string(dto.Client))
+		require.NoError(t, err)
+		second, err := service.GenerateDummyJWT(string(dto.Client))
+		require.NoError(t, err)
+
+		calls = 0
+		doDummy(first, "key-7", `{"number":7}`)
+		w := doDummy(second, "key-7", `{"number":7}`)
+
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		w := do(user, strings.Repeat("k", 256), `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func newIdempotentRequest(userID, key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/flat/create", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	ctx := logger.ToContext(req.Context(), zap.NewNop())
+	return req.WithContext(service.ContextWithClaims(ctx, &service.Claims{
+		UserID:           userID,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: string(dto.Client)},
+	}))
+}
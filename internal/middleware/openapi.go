@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+// OpenAPI checks requests, and responses if asked to, against the operations of an OpenAPI spec.
+type OpenAPI struct {
+	spec              *openapi3.T
+	validateResponses bool
+}
+
+// NewOpenAPI loads spec. With validateResponses the responses are checked as well, which buffers them
+// whole, so it is meant for tests.
+func NewOpenAPI(spec []byte, validateResponses bool) (*OpenAPI, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load OpenAPI spec")
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, errors.Wrap(err, "invalid OpenAPI spec")
+	}
+
+	return &OpenAPI{spec: doc, validateResponses: validateResponses}, nil
+}
+
+// Operation returns middleware checking requests against the operation at pattern, e.g. "POST /flat/create".
+// A request that does not match is passed to onError with an openapi3.MultiError of everything wrong with it.
+// Authentication is left to AuthMiddleware, and bodies are read in full, so limit them first with LimitBody.
+//
+// Bodies the operation takes in a content type kin-openapi can not decode, such as images, are passed through
+// unchecked. A body without a content type is taken for JSON, as the handlers always did.
+func (v *OpenAPI) Operation(pattern string, onError func(http.ResponseWriter, *http.Request, error)) func(http.Handler) http.Handler {
+	method, path, _ := strings.Cut(pattern, " ")
+	pathItem := v.spec.Paths.Value(path)
+	if pathItem == nil || pathItem.GetOperation(method) == nil {
+		panic(fmt.Sprintf("middleware: %s is not an operation of the spec", pattern))
+	}
+	route := &routers.Route{
+		Spec:      v.spec,
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: pathItem.GetOperation(method),
+	}
+
+	var pathParams []string
+	for _, params := range []openapi3.Parameters{pathItem.Parameters, route.Operation.Parameters} {
+		for _, param := range params {
+			if param.Value != nil && param.Value.In == openapi3.ParameterInPath {
+				pathParams = append(pathParams, param.Value.Name)
+			}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: make(map[string]string, len(pathParams)),
+				Route:      route,
+				Options: &openapi3filter.Options{
+					MultiError:          true,
+					AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+					SkipSettingDefaults: true,
+				},
+			}
+			for _, name := range pathParams {
+				input.PathParams[name] = r.PathValue(name)
+			}
+
+			if r.Body != nil && r.Body != http.NoBody && r.Header.Get("Content-Type") == "" && accepts(route.Operation, "application/json") {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			if contentType := r.Header.Get("Content-Type"); accepts(route.Operation, contentType) && !decodable(contentType) {
+				input.Options.ExcludeRequestBody = true
+			}
+
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				onError(w, r, err)
+				return
+			}
+
+			if !v.validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := &responseBuffer{ResponseWriter: w}
+			next.ServeHTTP(buf, r)
+			if err := validateResponse(r.Context(), input, buf); err != nil {
+				logger.Errorf(r.Context(), "%s %s: response does not match the spec: %v", r.Method, r.URL.Path, err)
+				http.Error(w, "Response does not match the spec: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			buf.flush()
+		})
+	}
+}
+
+func validateResponse(ctx context.Context, input *openapi3filter.RequestValidationInput, buf *responseBuffer) error {
+	options := &openapi3filter.Options{MultiError: true}
+	if !decodable(buf.Header().Get("Content-Type")) {
+		options.ExcludeResponseBody = true
+	}
+
+	return openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 buf.statusCode(),
+		Header:                 buf.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(buf.body.Bytes())),
+		Options:                options,
+	})
+}
+
+// accepts reports whether op takes a request body of contentType.
+func accepts(op *openapi3.Operation, contentType string) bool {
+	return op.RequestBody != nil && op.RequestBody.Value != nil && op.RequestBody.Value.Content.Get(contentType) != nil
+}
+
+// decodable reports whether kin-openapi can decode a body of contentType to check it against a schema.
+func decodable(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && openapi3filter.RegisteredBodyDecoder(mediaType) != nil
+}
+
+// responseBuffer holds the response back until it is checked.
+type responseBuffer struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *responseBuffer) statusCode() int {
+	if b.status == 0 {
+		return http.StatusOK
+	}
+	return b.status
+}
+
+func (b *responseBuffer) flush() {
+	b.ResponseWriter.WriteHeader(b.statusCode())
+	b.ResponseWriter.Write(b.body.Bytes())
+}
@@ -0,0 +1,123 @@
+//go:build unit
+// +build unit
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/shhesterka04/house-service/internal/middleware"
+	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: test
+  version: 1.0.0
+paths:
+  /item/{id}:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              required: [name]
+              properties:
+                name:
+                  type: string
+          image/png:
+            schema:
+              type: string
+              format: binary
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: integer
+`
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		response    string
+		wantStatus  int
+		wantErr     bool
+	}{
+		{name: "valid request", target: "/item/1", contentType: "application/json", body: `{"name": "a"}`, response: `{"id": 1}`, wantStatus: http.StatusOK},
+		{name: "malformed path parameter", target: "/item/one", contentType: "application/json", body: `{"name": "a"}`, wantErr: true},
+		{name: "unknown field", target: "/item/1", contentType: "application/json", body: `{"name": "a", "size": 1}`, wantErr: true},
+		{name: "missing content type is JSON", target: "/item/1", body: `{"size": 1}`, wantErr: true},
+		{name: "unsupported content type", target: "/item/1", contentType: "text/plain", body: `name`, wantErr: true},
+		{name: "binary body is not checked", target: "/item/1", contentType: "image/png", body: "\x89PNG", response: `{"id": 1}`, wantStatus: http.StatusOK},
+		{name: "response breaks the spec", target: "/item/1", contentType: "application/json", body: `{"name": "a"}`, response: `{"id": "one"}`, wantStatus: http.StatusInternalServerError},
+	}
+
+	spec, err := middleware.NewOpenAPI([]byte(testSpec), true)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotErr error
+			onError := func(w http.ResponseWriter, r *http.Request, err error) {
+				gotErr = err
+				w.WriteHeader(http.StatusBadRequest)
+			}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			})
+
+			mux := http.NewServeMux()
+			mux.Handle("POST /item/{id}", spec.Operation("POST /item/{id}", onError)(handler))
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req = req.WithContext(logger.ToContext(req.Context(), zap.NewNop()))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if tt.wantErr {
+				var specErr openapi3.MultiError
+				assert.ErrorAs(t, gotErr, &specErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, tt.response, w.Body.String())
+			}
+		})
+	}
+}
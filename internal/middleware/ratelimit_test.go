@@ -0,0 +1,146 @@
+//go:build unit
+// +build unit
+
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/shhesterka04/house-service/internal/audit"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/middleware"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store is down")
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	do := func(h http.Handler, remoteAddr, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = remoteAddr
+		ctx := logger.ToContext(audit.WithRequestID(req.Context(), "req-1"), zap.NewNop())
+		if userID != "" {
+			ctx = service.ContextWithClaims(ctx, &service.Claims{
+				UserID:           userID,
+				RegisteredClaims: jwt.RegisteredClaims{Subject: string(dto.Client)},
+			})
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	t.Run("limits callers by IP", func(t *testing.T) {
+		t.Parallel()
+
+		h := middleware.RateLimit(ratelimit.NewMemory(), "POST /login", limit)(ok)
+
+		w := do(h, "10.0.0.1:1234", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+
+		assert.Equal(t, http.StatusOK, do(h, "10.0.0.1:5678", "").Code)
+
+		w = do(h, "10.0.0.1:1234", "")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "30", w.Header().Get("Retry-After"))
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var body dto.Error
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.NotEmpty(t, body.Message)
+		require.NotNil(t, body.RequestId)
+		assert.Equal(t, "req-1", *body.RequestId)
+
+		assert.Equal(t, http.StatusOK, do(h, "10.0.0.2:1234", "").Code)
+	})
+
+	t.Run("limits authenticated callers by user", func(t *testing.T) {
+		t.Parallel()
+
+		h := middleware.RateLimit(ratelimit.NewMemory(), "POST /flat/create", limit)(ok)
+
+		assert.Equal(t, http.StatusOK, do(h, "10.0.0.1:1", "cae36e0f-69e5-4fa8-a179-a52d083c5549").Code)
+		assert.Equal(t, http.StatusOK, do(h, "10.0.0.2:1", "cae36e0f-69e5-4fa8-a179-a52d083c5549").Code)
+		assert.Equal(t, http.StatusTooManyRequests, do(h, "10.0.0.3:1", "cae36e0f-69e5-4fa8-a179-a52d083c5549").Code)
+		assert.Equal(t, http.StatusOK, do(h, "10.0.0.3:1", "5d0f3c1a-2b4e-4f6a-8c9d-0e1f2a3b4c5d").Code)
+	})
+
+	t.Run("limits dummy tokens apart", func(t *testing.T) {
+		t.Parallel()
+
+		h := middleware.RateLimit(ratelimit.NewMemory(), "POST /flat/create", ratelimit.Limit{Requests: 1, Period: time.Minute})(ok)
+		doDummy := func() int {
+			token, err := service.GenerateDummyJWT(string(dto.Client))
+			require.NoError(t, err)
+			claims, err := service.ParseJWT(token)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/flat/create", nil)
+			ctx := service.ContextWithClaims(logger.ToContext(req.Context(), zap.NewNop()), claims)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req.WithContext(ctx))
+			return w.Code
+		}
+
+		assert.Equal(t, http.StatusOK, doDummy())
+		assert.Equal(t, http.StatusOK, doDummy())
+	})
+
+	t.Run("routes are limited apart", func(t *testing.T) {
+		t.Parallel()
+
+		store := ratelimit.NewMemory()
+		login := middleware.RateLimit(store, "POST /login", ratelimit.Limit{Requests: 1, Period: time.Minute})(ok)
+		register := middleware.RateLimit(store, "POST /register", ratelimit.Limit{Requests: 1, Period: time.Minute})(ok)
+
+		assert.Equal(t, http.StatusOK, do(login, "10.0.0.1:1", "").Code)
+		assert.Equal(t, http.StatusOK, do(register, "10.0.0.1:1", "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, do(login, "10.0.0.1:1", "").Code)
+	})
+
+	t.Run("unlimited route", func(t *testing.T) {
+		t.Parallel()
+
+		h := middleware.RateLimit(ratelimit.NewMemory(), "GET /audit", ratelimit.Limit{})(ok)
+
+		w := do(h, "10.0.0.1:1", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("store failure lets requests through", func(t *testing.T) {
+		t.Parallel()
+
+		h := middleware.RateLimit(failingRateLimitStore{}, "POST /login", limit)(ok)
+
+		assert.Equal(t, http.StatusOK, do(h, "10.0.0.1:1", "").Code)
+	})
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SecurityHeadersOptions are the security headers of the responses, each left out if zero.
+type SecurityHeadersOptions struct {
+	// HSTSMaxAge is how long browsers are to reach the host over HTTPS only.
+	HSTSMaxAge            time.Duration
+	FrameOptions          string
+	ContentSecurityPolicy string
+}
+
+// SecurityHeaders sets the headers that keep browsers from sniffing, framing or running the responses. They
+// are set before the handler runs, so a handler, such as the one of the docs page, may replace the policy.
+func SecurityHeaders(opts SecurityHeadersOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			if opts.HSTSMaxAge > 0 {
+				w.Header().Set("Strict-Transport-Security", "max-age="+strconv.Itoa(int(opts.HSTSMaxAge.Seconds())))
+			}
+			if opts.FrameOptions != "" {
+				w.Header().Set("X-Frame-Options", opts.FrameOptions)
+			}
+			if opts.ContentSecurityPolicy != "" {
+				w.Header().Set("Content-Security-Policy", opts.ContentSecurityPolicy)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
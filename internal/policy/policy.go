@@ -0,0 +1,147 @@
+// Package policy declares which roles may perform which actions. Middleware and services
+// consult it instead of comparing user types by hand.
+package policy
+
+import "github.com/shhesterka04/house-service/internal/dto"
+
+type Action string
+
+const (
+	HouseCreate         Action = "house:create"
+	HouseRead           Action = "house:read"
+	HouseSubscribe      Action = "house:subscribe"
+	FlatCreate          Action = "flat:create"
+	FlatRead            Action = "flat:read"
+	FlatDelete          Action = "flat:delete"
+	FlatReadPending     Action = "flat:read-pending"
+	FlatModerate        Action = "flat:moderate"
+	FlatExportAll       Action = "flat:export-all"
+	FlatMediaManage     Action = "flat:media-manage"
+	DeclineReasonManage Action = "decline-reason:manage"
+	AuditRead           Action = "audit:read"
+	UserManage          Action = "user:manage"
+	APIKeyManage        Action = "apikey:manage"
+	DeveloperRead       Action = "developer:read"
+	DeveloperManage     Action = "developer:manage"
+)
+
+// Roles lists every role known to the policy.
+var Roles = []dto.UserType{dto.Client, dto.DeveloperUser, dto.Moderator, dto.Admin}
+
+// Actions lists every action known to the policy.
+var Actions = []Action{
+	HouseCreate, HouseRead, HouseSubscribe,
+	FlatCreate, FlatRead, FlatDelete, FlatReadPending, FlatModerate, FlatExportAll, FlatMediaManage,
+	DeclineReasonManage, AuditRead,
+	UserManage, APIKeyManage,
+	DeveloperRead, DeveloperManage,
+}
+
+var permissions = map[dto.UserType]map[Action]struct{}{
+	dto.Client: set(
+		HouseRead,
+		HouseSubscribe,
+		FlatCreate,
+		FlatRead,
+		FlatDelete,
+		APIKeyManage,
+		DeveloperRead,
+	),
+	dto.DeveloperUser: set(
+		HouseRead,
+		HouseSubscribe,
+		FlatCreate,
+		FlatRead,
+		FlatDelete,
+		FlatMediaManage,
+		APIKeyManage,
+		DeveloperRead,
+	),
+	dto.Moderator: set(
+		HouseCreate,
+		HouseRead,
+		HouseSubscribe,
+		FlatCreate,
+		FlatRead,
+		FlatDelete,
+		FlatReadPending,
+		FlatModerate,
+		FlatExportAll,
+		FlatMediaManage,
+		AuditRead,
+		APIKeyManage,
+		DeveloperRead,
+		DeveloperManage,
+	),
+	dto.Admin: set(
+		HouseCreate,
+		HouseRead,
+		HouseSubscribe,
+		FlatCreate,
+		FlatRead,
+		FlatDelete,
+		FlatReadPending,
+		FlatModerate,
+		FlatExportAll,
+		FlatMediaManage,
+		DeclineReasonManage,
+		AuditRead,
+		UserManage,
+		APIKeyManage,
+		DeveloperRead,
+		DeveloperManage,
+	),
+}
+
+// ownHousesOnly lists actions a role may perform only on houses of the developer it is linked to.
+var ownHousesOnly = map[dto.UserType]map[Action]struct{}{
+	dto.DeveloperUser: set(
+		FlatCreate,
+		FlatMediaManage,
+	),
+}
+
+// Allowed reports whether role may perform action. Unknown roles and actions are denied.
+func Allowed(role dto.UserType, action Action) bool {
+	_, ok := permissions[role][action]
+	return ok
+}
+
+// OwnHousesOnly reports whether role may perform action only on its own houses.
+func OwnHousesOnly(role dto.UserType, action Action) bool {
+	_, ok := ownHousesOnly[role][action]
+	return ok
+}
+
+// IsAction reports whether s names a known action.
+func IsAction(s string) bool {
+	for _, a := range Actions {
+		if string(a) == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VisibleFlatStatuses returns the flat statuses role may see in listings.
+func VisibleFlatStatuses(role dto.UserType) []dto.Status {
+	var statuses []dto.Status
+	if Allowed(role, FlatRead) {
+		statuses = append(statuses, dto.Approved)
+	}
+	if Allowed(role, FlatReadPending) {
+		statuses = append(statuses, dto.Created, dto.OnModeration, dto.Declined)
+	}
+
+	return statuses
+}
+
+func set(actions ...Action) map[Action]struct{} {
+	m := make(map[Action]struct{}, len(actions))
+	for _, a := range actions {
+		m[a] = struct{}{}
+	}
+
+	return m
+}
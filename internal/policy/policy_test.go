@@ -0,0 +1,93 @@
+//go:build unit
+// +build unit
+
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	// Every role/action pair must be listed, so adding a role or an action without deciding
+	// on its permissions fails the test.
+	matrix := map[policy.Action]map[dto.UserType]bool{
+		policy.HouseCreate:         {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: true, dto.Admin: true},
+		policy.HouseRead:           {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.HouseSubscribe:      {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.FlatCreate:          {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.FlatRead:            {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.FlatDelete:          {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.FlatReadPending:     {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: true, dto.Admin: true},
+		policy.FlatModerate:        {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: true, dto.Admin: true},
+		policy.FlatExportAll:       {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: true, dto.Admin: true},
+		policy.FlatMediaManage:     {dto.Client: false, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.DeclineReasonManage: {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: false, dto.Admin: true},
+		policy.AuditRead:           {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: true, dto.Admin: true},
+		policy.UserManage:          {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: false, dto.Admin: true},
+		policy.APIKeyManage:        {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.DeveloperRead:       {dto.Client: true, dto.DeveloperUser: true, dto.Moderator: true, dto.Admin: true},
+		policy.DeveloperManage:     {dto.Client: false, dto.DeveloperUser: false, dto.Moderator: true, dto.Admin: true},
+	}
+
+	assert.Len(t, matrix, len(policy.Actions))
+	for _, action := range policy.Actions {
+		roles, ok := matrix[action]
+		if !assert.True(t, ok, "action %s is missing from the matrix", action) {
+			continue
+		}
+		assert.Len(t, roles, len(policy.Roles), "action %s", action)
+
+		for _, role := range policy.Roles {
+			want, ok := roles[role]
+			if !assert.True(t, ok, "role %s is missing for action %s", role, action) {
+				continue
+			}
+			assert.Equal(t, want, policy.Allowed(role, action), "role %s, action %s", role, action)
+		}
+	}
+}
+
+func TestOwnHousesOnly(t *testing.T) {
+	for _, role := range policy.Roles {
+		for _, action := range policy.Actions {
+			want := role == dto.DeveloperUser && (action == policy.FlatCreate || action == policy.FlatMediaManage)
+			assert.Equal(t, want, policy.OwnHousesOnly(role, action), "role %s, action %s", role, action)
+		}
+	}
+}
+
+func TestAllowed_Unknown(t *testing.T) {
+	assert.False(t, policy.Allowed(dto.UserType("guest"), policy.FlatRead))
+	assert.False(t, policy.Allowed(dto.Admin, policy.Action("house:delete")))
+}
+
+func TestIsAction(t *testing.T) {
+	for _, action := range policy.Actions {
+		assert.True(t, policy.IsAction(string(action)))
+	}
+	assert.False(t, policy.IsAction("house:delete"))
+}
+
+func TestVisibleFlatStatuses(t *testing.T) {
+	tests := []struct {
+		role dto.UserType
+		want []dto.Status
+	}{
+		{role: dto.Client, want: []dto.Status{dto.Approved}},
+		{role: dto.DeveloperUser, want: []dto.Status{dto.Approved}},
+		{role: dto.Moderator, want: []dto.Status{dto.Approved, dto.Created, dto.OnModeration, dto.Declined}},
+		{role: dto.Admin, want: []dto.Status{dto.Approved, dto.Created, dto.OnModeration, dto.Declined}},
+		{role: dto.UserType("guest"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, policy.VisibleFlatStatuses(tt.role))
+		})
+	}
+}
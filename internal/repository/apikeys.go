@@ -0,0 +1,144 @@
+//go:generate mockgen -source ./apikeys.go -destination=./mocks/apikeys_db.go -package=mocks
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+type DBAPIKey interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// ResolvedAPIKey is an active key together with the current state of its owner.
+type ResolvedAPIKey struct {
+	ID           string
+	UserID       string
+	Scopes       []string
+	UserType     string
+	UserDisabled bool
+}
+
+type APIKeyRepository struct {
+	db DBAPIKey
+}
+
+func NewAPIKeyRepository(db DBAPIKey) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// CreateAPIKey stores the key and records it in the audit log in one transaction. The hash is not audited.
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *APIKey) (*APIKey, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin create api key")
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, "INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at",
+		key.UserID, key.Name, key.Prefix, key.Hash, key.Scopes)
+	if err = row.Scan(&key.ID, &key.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "create api key")
+	}
+
+	after := map[string]any{"user_id": key.UserID, "name": key.Name, "prefix": key.Prefix, "scopes": key.Scopes}
+	if err = writeAudit(ctx, tx, dto.AuditAPIKeyCreate, dto.AuditEntityAPIKey, key.ID, nil, after); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit create api key")
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, user_id, name, prefix, scopes, created_at, last_used_at, revoked_at FROM api_keys WHERE user_id = $1 ORDER BY created_at", userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list api keys")
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		var key APIKey
+		if err = rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.Scopes, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt); err != nil {
+			return nil, errors.Wrap(err, "scan api keys")
+		}
+		keys = append(keys, &key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey revokes the key if it belongs to userID and records it in the audit log in one transaction.
+// Revoking an already revoked key is a no-op.
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin revoke api key")
+	}
+	defer tx.Rollback(ctx)
+
+	var revokedAt *time.Time
+	err = tx.QueryRow(ctx, "SELECT revoked_at FROM api_keys WHERE id = $1 AND user_id = $2 FOR UPDATE", id, userID).Scan(&revokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAPIKeyNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "get api key")
+	}
+	if revokedAt != nil {
+		return nil
+	}
+
+	if err = tx.QueryRow(ctx, "UPDATE api_keys SET revoked_at = now() WHERE id = $1 RETURNING revoked_at", id).Scan(&revokedAt); err != nil {
+		return errors.Wrap(err, "revoke api key")
+	}
+
+	if err = writeAudit(ctx, tx, dto.AuditAPIKeyRevoke, dto.AuditEntityAPIKey, id, map[string]any{"revoked_at": nil}, map[string]any{"revoked_at": revokedAt}); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "commit revoke api key")
+}
+
+// ResolveAPIKey looks up an active key by its hash and records that it was used.
+func (r *APIKeyRepository) ResolveAPIKey(ctx context.Context, hash string) (*ResolvedAPIKey, error) {
+	row := r.db.QueryRow(ctx, `WITH k AS (
+		UPDATE api_keys SET last_used_at = now() WHERE key_hash = $1 AND revoked_at IS NULL RETURNING id, user_id, scopes
+	)
+	SELECT k.id, k.user_id, k.scopes, u.type, u.disabled FROM k JOIN users u ON u.id = k.user_id`, hash)
+
+	var key ResolvedAPIKey
+	err := row.Scan(&key.ID, &key.UserID, &key.Scopes, &key.UserType, &key.UserDisabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "resolve api key")
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,164 @@
+//go:generate mockgen -source ./audit.go -destination=./mocks/audit_db.go -package=mocks
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/audit"
+	"github.com/shhesterka04/house-service/internal/dto"
+)
+
+// querier is implemented by the pool and by transactions, so helpers run within the caller's transaction.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// AuditFilter selects audit log entries. Empty filters are not applied. Entries are ordered from the
+// newest, After is the ID of the last entry of the previous page.
+type AuditFilter struct {
+	ActorID    *string
+	Action     *dto.AuditAction
+	EntityType *dto.AuditEntityType
+	EntityID   *string
+	RequestID  *string
+	From       *time.Time
+	To         *time.Time
+	After      *int64
+	Limit      int
+}
+
+type DBAudit interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+type AuditRepository struct {
+	db DBAudit
+}
+
+func NewAuditRepository(db DBAudit) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// AuditLog returns up to filter.Limit entries matching the filter, newest first.
+func (r *AuditRepository) AuditLog(ctx context.Context, filter AuditFilter) ([]*dto.AuditEntry, error) {
+	var (
+		args  []any
+		conds = []string{"true"}
+	)
+	where := func(cond string, value any) {
+		args = append(args, value)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.ActorID != nil {
+		where("actor_id = $%d", *filter.ActorID)
+	}
+	if filter.Action != nil {
+		where("action = $%d", string(*filter.Action))
+	}
+	if filter.EntityType != nil {
+		where("entity_type = $%d", string(*filter.EntityType))
+	}
+	if filter.EntityID != nil {
+		where("entity_id = $%d", *filter.EntityID)
+	}
+	if filter.RequestID != nil {
+		where("request_id = $%d", *filter.RequestID)
+	}
+	if filter.From != nil {
+		where("occurred_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where("occurred_at < $%d", *filter.To)
+	}
+	if filter.After != nil {
+		where("id < $%d", *filter.After)
+	}
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`SELECT id, occurred_at, actor_id, actor_role, api_key_id, actor_dummy, action, entity_type, entity_id, before, after, request_id
+		FROM audit_log
+		WHERE %s
+		ORDER BY id DESC
+		LIMIT $%d`, strings.Join(conds, " AND "), len(args)), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "audit log")
+	}
+	defer rows.Close()
+
+	var entries []*dto.AuditEntry
+	for rows.Next() {
+		entry := &dto.AuditEntry{}
+		err = rows.Scan(&entry.Id, &entry.OccurredAt, &entry.ActorId, &entry.ActorRole, &entry.ApiKeyId, &entry.ActorDummy,
+			&entry.Action, &entry.EntityType, &entry.EntityId, &entry.Before, &entry.After, &entry.RequestId)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan audit log")
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, errors.Wrap(rows.Err(), "audit log")
+}
+
+// writeAudit appends an entry for the change to the audit log through q, which must be the transaction
+// making the change. The actor and the request ID are taken from ctx, only changed fields are stored.
+func writeAudit(ctx context.Context, q querier, action dto.AuditAction, entityType dto.AuditEntityType, entityID any, before, after map[string]any) error {
+	before, after = audit.Diff(before, after)
+
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		return err
+	}
+
+	actor, _ := audit.ActorFromContext(ctx)
+	_, err = q.Exec(ctx, `INSERT INTO audit_log (actor_id, actor_role, api_key_id, actor_dummy, action, entity_type, entity_id, before, after, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		nullString(actor.UserID), nullString(actor.Role), nullString(actor.APIKeyID), actor.Dummy,
+		string(action), string(entityType), fmt.Sprint(entityID), beforeJSON, afterJSON, nullString(audit.RequestIDFromContext(ctx)))
+
+	return errors.Wrap(err, "write audit log")
+}
+
+// auditState turns an entity into the field map stored in the audit log, using its JSON representation.
+func auditState(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal audit state")
+	}
+
+	var state map[string]any
+	if err = json.Unmarshal(b, &state); err != nil {
+		return nil, errors.Wrap(err, "unmarshal audit state")
+	}
+
+	return state, nil
+}
+
+func auditJSON(state map[string]any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(state)
+	return b, errors.Wrap(err, "marshal audit state")
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,142 @@
+//go:generate mockgen -source ./developers.go -destination=./mocks/developers_db.go -package=mocks
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+)
+
+var (
+	ErrDeveloperExists = errors.New("developer already exists")
+	// ErrDeveloperUserRole is returned for linking a developer to an account of a moderator or an admin.
+	ErrDeveloperUserRole = errors.New("only a client or developer account can be linked to a developer")
+)
+
+const uniqueViolation = "23505"
+
+type DBDeveloper interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type DeveloperRepository struct {
+	db DBDeveloper
+}
+
+func NewDeveloperRepository(db DBDeveloper) *DeveloperRepository {
+	return &DeveloperRepository{db: db}
+}
+
+// CreateDeveloper stores the developer profile. A linked client account is switched to the developer role
+// in the same transaction, so it can start adding flats to the developer's houses. Both are recorded in the
+// audit log. Linking an account that does not exist gives ErrUserNotFound, one of a moderator or an admin
+// ErrDeveloperUserRole.
+func (r *DeveloperRepository) CreateDeveloper(ctx context.Context, developer *dto.DeveloperProfile) (*dto.DeveloperProfile, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin create developer")
+	}
+	defer tx.Rollback(ctx)
+
+	var userType string
+	if developer.UserId != nil {
+		err = tx.QueryRow(ctx, "SELECT type FROM users WHERE id = $1 FOR UPDATE", developer.UserId).Scan(&userType)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		} else if err != nil {
+			return nil, errors.Wrap(err, "get developer user")
+		}
+		if userType != string(dto.Client) && userType != string(dto.DeveloperUser) {
+			return nil, ErrDeveloperUserRole
+		}
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO developers (name, normalized_name, website, email, phone, description, user_id)
+		VALUES (btrim($1), COALESCE(normalize_developer_name($1), lower(btrim($1))), $2, $3, $4, $5, $6)
+		RETURNING id, name, created_at`,
+		developer.Name, developer.Website, developer.Email, developer.Phone, developer.Description, developer.UserId).Scan(&developer.Id, &developer.Name, &developer.CreatedAt)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation:
+		return nil, ErrDeveloperExists
+	case errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation:
+		return nil, ErrUserNotFound
+	case err != nil:
+		return nil, errors.Wrap(err, "create developer")
+	}
+
+	after, err := auditState(developer)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeAudit(ctx, tx, dto.AuditDeveloperCreate, dto.AuditEntityDeveloper, developer.Id, nil, after); err != nil {
+		return nil, err
+	}
+
+	if userType == string(dto.Client) {
+		if _, err = tx.Exec(ctx, "UPDATE users SET type = $1 WHERE id = $2", string(dto.DeveloperUser), developer.UserId); err != nil {
+			return nil, errors.Wrap(err, "promote developer user")
+		}
+		before, after := map[string]any{"type": string(dto.Client)}, map[string]any{"type": string(dto.DeveloperUser)}
+		if err = writeAudit(ctx, tx, dto.AuditUserRole, dto.AuditEntityUser, *developer.UserId, before, after); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit create developer")
+	}
+
+	return developer, nil
+}
+
+func (r *DeveloperRepository) ListDevelopers(ctx context.Context) ([]*dto.DeveloperProfile, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, name, website, email, phone, description, user_id, created_at FROM developers ORDER BY name")
+	if err != nil {
+		return nil, errors.Wrap(err, "list developers")
+	}
+	defer rows.Close()
+
+	var developers []*dto.DeveloperProfile
+	for rows.Next() {
+		var d dto.DeveloperProfile
+		if err = rows.Scan(&d.Id, &d.Name, &d.Website, &d.Email, &d.Phone, &d.Description, &d.UserId, &d.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "scan developers")
+		}
+		developers = append(developers, &d)
+	}
+
+	return developers, rows.Err()
+}
+
+func (r *DeveloperRepository) GetHousesByDeveloperID(ctx context.Context, developerID int) ([]*dto.House, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM developers WHERE id = $1)", developerID).Scan(&exists); err != nil {
+		return nil, errors.Wrap(err, "get developer")
+	}
+	if !exists {
+		return nil, ErrDeveloperNotFound
+	}
+
+	rows, err := r.db.Query(ctx, houseSelect+" WHERE h.developer_id = $1 ORDER BY h.id", developerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get developer houses")
+	}
+	defer rows.Close()
+
+	var houses []*dto.House
+	for rows.Next() {
+		house, err := scanHouse(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan houses")
+		}
+		houses = append(houses, house)
+	}
+
+	return houses, rows.Err()
+}
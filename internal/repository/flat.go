@@ -4,6 +4,9 @@ package repository
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -12,12 +15,69 @@ import (
 	"github.com/shhesterka04/house-service/pkg/logger"
 )
 
-var ErrFlatExists = errors.New("flat already exists")
+var (
+	ErrFlatExists            = errors.New("flat already exists")
+	ErrFlatNotFound          = errors.New("flat not found")
+	ErrFlatModified          = errors.New("flat was modified")
+	ErrDeclineReasonNotFound = errors.New("decline reason not found")
+)
+
+const foreignKeyViolation = "23503"
+
+// flatColumns are the columns read by scanFlat, flats are always aliased as f. Queries reading flats
+// skip soft-deleted ones with f.deleted_at IS NULL.
+const flatColumns = "f.id, f.house_id, f.status, f.number, f.rooms, f.price, f.floor, f.total_area, f.living_area, f.section, f.ceiling_height, f.balcony, f.finishing, f.created_by, f.created_at, f.updated_at, f.version"
+
+// flatSortColumn is a column SearchFlats can order by. Timestamp columns are paged by FlatCursor.At.
+type flatSortColumn struct {
+	name      string
+	timestamp bool
+}
+
+// flatSortColumns maps the sort keys accepted by SearchFlats to columns.
+var flatSortColumns = map[string]flatSortColumn{
+	"id":         {name: "f.id"},
+	"price":      {name: "f.price"},
+	"rooms":      {name: "f.rooms"},
+	"created_at": {name: "f.created_at", timestamp: true},
+	"updated_at": {name: "f.updated_at", timestamp: true},
+}
+
+// FlatSearch filters flats across houses. Nil filters are not applied. Flats are ordered by Sort and
+// then by id, both in the same direction, and After is the position of the last flat of the previous page.
+type FlatSearch struct {
+	Rooms       *int
+	MinPrice    *int
+	MaxPrice    *int
+	MinYear     *int
+	MaxYear     *int
+	DeveloperID *int
+	MinArea     *float64
+	MaxArea     *float64
+	MinFloor    *int
+	MaxFloor    *int
+	Balcony     *dto.Balcony
+	Finishing   *dto.Finishing
+	Statuses    []dto.Status
+	Sort        string
+	Desc        bool
+	After       *FlatCursor
+	Limit       int
+}
+
+// FlatCursor is a keyset position: the value of the sort column and the id of a flat. At holds the
+// value of timestamp columns.
+type FlatCursor struct {
+	Value int
+	At    time.Time
+	ID    int
+}
 
 type DBFlat interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type RowDBFlat interface {
@@ -38,59 +98,312 @@ func NewFlatRepository(db DBFlat) *FlatRepository {
 	return &FlatRepository{db: db}
 }
 
+// CreateFlat creates the flat, records it in the audit log and touches its house in one transaction.
 func (r *FlatRepository) CreateFlat(ctx context.Context, flat *dto.DtoFlat) (*dto.DtoFlat, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin create flat")
+	}
+	defer tx.Rollback(ctx)
+
 	var existingFlat dto.DtoFlat
-	err := r.db.QueryRow(ctx, "SELECT id FROM flats WHERE house_id = $1 AND number = $2", flat.HouseID, flat.Number).Scan(&existingFlat.ID)
+	err = tx.QueryRow(ctx, "SELECT id FROM flats WHERE house_id = $1 AND number = $2 AND deleted_at IS NULL", flat.HouseID, flat.Number).Scan(&existingFlat.ID)
 	if err == nil {
 		return nil, errors.Wrap(ErrFlatExists, "flat already exists")
 	} else if !errors.Is(err, pgx.ErrNoRows) {
 		return nil, errors.Wrap(err, "query row")
 	}
 
-	if _, err = r.db.Exec(ctx, "INSERT INTO flats (house_id, status, number, rooms, price) VALUES ($1, $2, $3, $4, $5)", flat.HouseID, flat.Status, flat.Number, flat.Rooms, flat.Price); err != nil {
+	var id int
+	if err = tx.QueryRow(ctx, `INSERT INTO flats (house_id, status, number, rooms, price, floor, total_area, living_area, section, ceiling_height, balcony, finishing, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`,
+		flat.HouseID, flat.Status, flat.Number, flat.Rooms, flat.Price,
+		flat.Floor, flat.TotalArea, flat.LivingArea, flat.Section, flat.CeilingHeight, flat.Balcony, flat.Finishing, flat.CreatedBy).Scan(&id); err != nil {
 		return nil, errors.Wrap(err, "create flat")
 	}
 
-	row := r.db.QueryRow(ctx, "SELECT id FROM flats WHERE house_id = $1 AND number = $2", flat.HouseID, flat.Number)
-	if err = row.Scan(&flat.ID); err != nil {
+	flat, err = scanFlat(tx.QueryRow(ctx, "SELECT "+flatColumns+" FROM flats f WHERE f.id = $1", id))
+	if err != nil {
 		return nil, errors.Wrap(err, "get flat")
 	}
 
+	if err = auditFlatCreate(ctx, tx, flat); err != nil {
+		return nil, err
+	}
+
+	if err = touchHouse(ctx, tx, flat.HouseID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit create flat")
+	}
+
 	logger.Infof(ctx, "Flat created: %v", flat)
 
 	return flat, nil
 }
 
+// ImportFlats inserts the flats in one transaction, each row under its own savepoint, and sets their IDs.
+// The returned slice holds the error of every flat, nil for inserted ones. If atomic is set and any flat
+// failed, the whole transaction is rolled back. ErrHouseNotFound aborts the import. The houses of the inserted
+// flats are touched in the same transaction.
+func (r *FlatRepository) ImportFlats(ctx context.Context, flats []*dto.DtoFlat, atomic bool) ([]error, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin import")
+	}
+	defer tx.Rollback(ctx)
+
+	rowErrs := make([]error, len(flats))
+	failed := false
+	houses := map[int]struct{}{}
+	for i, flat := range flats {
+		err = importFlat(ctx, tx, flat)
+		switch {
+		case errors.Is(err, ErrFlatExists):
+			rowErrs[i], failed = err, true
+		case err != nil:
+			return nil, err
+		default:
+			houses[flat.HouseID] = struct{}{}
+		}
+	}
+
+	if atomic && failed {
+		return rowErrs, nil
+	}
+
+	for houseID := range houses {
+		if err = touchHouse(ctx, tx, houseID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit import")
+	}
+
+	logger.Infof(ctx, "Flats imported: %d", len(flats))
+
+	return rowErrs, nil
+}
+
+// importFlat inserts a single flat under a savepoint, so a conflicting flat does not break the transaction.
+func importFlat(ctx context.Context, tx pgx.Tx, flat *dto.DtoFlat) error {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "savepoint")
+	}
+	defer sp.Rollback(ctx)
+
+	err = sp.QueryRow(ctx, `INSERT INTO flats (house_id, status, number, rooms, price, floor, total_area, living_area, section, ceiling_height, balcony, finishing, created_by)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		WHERE NOT EXISTS (SELECT 1 FROM flats WHERE house_id = $1 AND number = $3 AND deleted_at IS NULL)
+		RETURNING id, created_at, updated_at, version`,
+		flat.HouseID, flat.Status, flat.Number, flat.Rooms, flat.Price,
+		flat.Floor, flat.TotalArea, flat.LivingArea, flat.Section, flat.CeilingHeight, flat.Balcony, flat.Finishing, flat.CreatedBy).Scan(&flat.ID, &flat.CreatedAt, &flat.UpdatedAt, &flat.Version)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrFlatExists
+	case errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation:
+		return ErrHouseNotFound
+	case err != nil:
+		return errors.Wrap(err, "import flat")
+	}
+
+	if err = auditFlatCreate(ctx, sp, flat); err != nil {
+		return err
+	}
+
+	return errors.Wrap(sp.Commit(ctx), "release savepoint")
+}
+
+func auditFlatCreate(ctx context.Context, q querier, flat *dto.DtoFlat) error {
+	after, err := auditState(flat)
+	if err != nil {
+		return err
+	}
+
+	return writeAudit(ctx, q, dto.AuditFlatCreate, dto.AuditEntityFlat, flat.ID, nil, after)
+}
+
+// touchHouse records a change of the flats of the house, setting its update time and incrementing its version.
+// It runs in the transaction that changed the flats, so the house version moves with them.
+func touchHouse(ctx context.Context, q querier, houseID int) error {
+	if _, err := q.Exec(ctx, "UPDATE house SET updated_at = now(), version = version + 1 WHERE id = $1", houseID); err != nil {
+		return errors.Wrap(err, "touch house")
+	}
+
+	return nil
+}
+
+// UpdateFlat sets the status of the flat and records the change in the audit log in one transaction.
+// The flat is updated only if it is still at flat.Version, otherwise ErrFlatModified is returned.
 func (r *FlatRepository) UpdateFlat(ctx context.Context, flat *dto.DtoFlat) (*dto.DtoFlat, error) {
-	if _, err := r.db.Exec(ctx, "UPDATE flats SET status = $1 WHERE id = $2", flat.Status, flat.ID); err != nil {
-		return nil, errors.Wrap(err, "update flat")
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin update flat")
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setFlatStatus(ctx, tx, flat, nil); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit update flat")
 	}
 
 	return flat, nil
 }
 
+// setFlatStatus writes the status of the flat if it is still at flat.Version and audits the change, with
+// details added to the new state. The flat gets its new version and update time, and its house is touched.
+func setFlatStatus(ctx context.Context, q querier, flat *dto.DtoFlat, details map[string]any) error {
+	var (
+		previous string
+		version  int
+	)
+	err := q.QueryRow(ctx, "SELECT status, version FROM flats WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", flat.ID).Scan(&previous, &version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrFlatNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "get flat status")
+	}
+
+	if version != flat.Version {
+		return errors.Wrapf(ErrFlatModified, "flat %d is at version %d, not %d", flat.ID, version, flat.Version)
+	}
+
+	if err = q.QueryRow(ctx, `UPDATE flats SET status = $1, updated_at = now(), version = version + 1,
+		status_changed_at = CASE WHEN status = $1::flat_status THEN status_changed_at ELSE now() END
+		WHERE id = $2 RETURNING house_id, updated_at, version`, flat.Status, flat.ID).Scan(&flat.HouseID, &flat.UpdatedAt, &flat.Version); err != nil {
+		return errors.Wrap(err, "update flat")
+	}
+
+	if err = touchHouse(ctx, q, flat.HouseID); err != nil {
+		return err
+	}
+
+	after := map[string]any{"status": flat.Status}
+	for k, v := range details {
+		after[k] = v
+	}
+
+	return writeAudit(ctx, q, dto.AuditFlatStatus, dto.AuditEntityFlat, flat.ID, map[string]any{"status": previous}, after)
+}
+
+// ModerateFlat sets the status of the flat and records the decision in one transaction, filling in its ID,
+// time and the title of its reason. A reason missing from the catalog or inactive gives ErrDeclineReasonNotFound,
+// a flat no longer at flat.Version gives ErrFlatModified.
+func (r *FlatRepository) ModerateFlat(ctx context.Context, flat *dto.DtoFlat, decision *ModerationDecision) (*dto.DtoFlat, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin moderation")
+	}
+	defer tx.Rollback(ctx)
+
+	var reasonCode *string
+	details := map[string]any{}
+	if decision.Reason != nil {
+		err = tx.QueryRow(ctx, "SELECT title, active FROM decline_reasons WHERE code = $1 AND active", decision.Reason.Code).
+			Scan(&decision.Reason.Title, &decision.Reason.Active)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeclineReasonNotFound
+		} else if err != nil {
+			return nil, errors.Wrap(err, "get decline reason")
+		}
+		reasonCode = &decision.Reason.Code
+		details["decline_reason"] = decision.Reason.Code
+	}
+	if decision.Comment != nil {
+		details["comment"] = *decision.Comment
+	}
+
+	if err = setFlatStatus(ctx, tx, flat, details); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO moderation_decisions (flat_id, status, reason_code, comment, moderator_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`,
+		flat.ID, string(decision.Status), reasonCode, decision.Comment, decision.ModeratorID).Scan(&decision.ID, &decision.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "create moderation decision")
+	}
+	decision.FlatID = flat.ID
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit moderation")
+	}
+
+	logger.Infof(ctx, "Flat %d moderated: %s", flat.ID, decision.Status)
+
+	return flat, nil
+}
+
+// DeleteFlat soft-deletes the flat if allow, called with the flat locked, returns nil, and records it in the
+// audit log in one transaction, so that the flat can not change between the check and the deletion. A flat
+// that does not exist or is already deleted gives ErrFlatNotFound, and its house is touched along with it.
+func (r *FlatRepository) DeleteFlat(ctx context.Context, id int, allow func(*dto.DtoFlat) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin delete flat")
+	}
+	defer tx.Rollback(ctx)
+
+	flat, err := scanFlat(tx.QueryRow(ctx, "SELECT "+flatColumns+" FROM flats f WHERE f.id = $1 AND f.deleted_at IS NULL FOR UPDATE", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrFlatNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "get flat")
+	}
+
+	if err = allow(flat); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE flats SET deleted_at = now(), updated_at = now(), version = version + 1 WHERE id = $1", id); err != nil {
+		return errors.Wrap(err, "delete flat")
+	}
+
+	if err = writeAudit(ctx, tx, dto.AuditFlatDelete, dto.AuditEntityFlat, id, map[string]any{"status": flat.Status}, nil); err != nil {
+		return err
+	}
+
+	if err = touchHouse(ctx, tx, flat.HouseID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "commit delete flat")
+	}
+
+	logger.Infof(ctx, "Flat %d deleted", id)
+
+	return nil
+}
+
 func (r *FlatRepository) GetFlatByID(ctx context.Context, id int) (*dto.DtoFlat, error) {
-	row := r.db.QueryRow(ctx, "SELECT id, house_id, status, number, rooms, price FROM flats WHERE id = $1", id)
-	flat := &dto.DtoFlat{}
-	if err := row.Scan(&flat.ID, &flat.HouseID, &flat.Status, &flat.Number, &flat.Rooms, &flat.Price); err != nil {
+	flat, err := scanFlat(r.db.QueryRow(ctx, "SELECT "+flatColumns+" FROM flats f WHERE f.id = $1 AND f.deleted_at IS NULL", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFlatNotFound
+	} else if err != nil {
 		return nil, errors.Wrap(err, "get flat")
 	}
 
 	return flat, nil
 }
 
-func (r *FlatRepository) GetFlatByHouseID(ctx context.Context, houseId int, userType string) ([]*dto.DtoFlat, error) {
-	var rows pgx.Rows
-	var err error
-
-	switch userType {
-	case string(dto.Client):
-		rows, err = r.db.Query(ctx, "SELECT id, house_id, status, number, rooms, price FROM flats WHERE house_id = $1 AND status = 'approved'", houseId)
-	case string(dto.Moderator):
-		rows, err = r.db.Query(ctx, "SELECT id, house_id, status, number, rooms, price FROM flats WHERE house_id = $1 AND status != 'on moderation'", houseId)
-	default:
-		return nil, fmt.Errorf("invalid user type")
+// GetFlatByHouseID returns the flats of the house whose status is one of statuses.
+func (r *FlatRepository) GetFlatByHouseID(ctx context.Context, houseId int, statuses []dto.Status) ([]*dto.DtoFlat, error) {
+	if len(statuses) == 0 {
+		return nil, nil
 	}
+
+	rows, err := r.db.Query(ctx, "SELECT "+flatColumns+" FROM flats f WHERE f.house_id = $1 AND f.status = ANY($2::flat_status[]) AND f.deleted_at IS NULL ORDER BY f.id", houseId, statusStrings(statuses))
 	if err != nil {
 		return nil, errors.Wrap(err, "get flats")
 	}
@@ -98,12 +411,175 @@ func (r *FlatRepository) GetFlatByHouseID(ctx context.Context, houseId int, user
 
 	var flats []*dto.DtoFlat
 	for rows.Next() {
-		var flat dto.DtoFlat
-		if err = rows.Scan(&flat.ID, &flat.HouseID, &flat.Status, &flat.Number, &flat.Rooms, &flat.Price); err != nil {
+		flat, err := scanFlat(rows)
+		if err != nil {
 			return nil, errors.Wrap(err, "scan flats")
 		}
-		flats = append(flats, &flat)
+		flats = append(flats, flat)
+	}
+
+	return flats, errors.Wrap(rows.Err(), "get flats")
+}
+
+// StreamFlats calls fn for every flat of the house, or of all houses if houseID is 0, whose status is one
+// of statuses. Rows are read from the connection while fn consumes them, so the result is never held in memory.
+func (r *FlatRepository) StreamFlats(ctx context.Context, houseID int, statuses []dto.Status, fn func(*dto.DtoFlat) error) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	rows, err := r.db.Query(ctx, "SELECT "+flatColumns+" FROM flats f WHERE ($1 = 0 OR f.house_id = $1) AND f.status = ANY($2::flat_status[]) AND f.deleted_at IS NULL ORDER BY f.house_id, f.id", houseID, statusStrings(statuses))
+	if err != nil {
+		return errors.Wrap(err, "stream flats")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		flat, err := scanFlat(rows)
+		if err != nil {
+			return errors.Wrap(err, "scan flats")
+		}
+		if err = fn(flat); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(rows.Err(), "stream flats")
+}
+
+func statusStrings(statuses []dto.Status) []string {
+	values := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+
+	return values
+}
+
+// SearchFlats returns up to search.Limit flats matching the search, ordered for keyset pagination.
+func (r *FlatRepository) SearchFlats(ctx context.Context, search FlatSearch) ([]*dto.DtoFlat, error) {
+	column, ok := flatSortColumns[search.Sort]
+	if !ok {
+		return nil, errors.Errorf("unknown sort %q", search.Sort)
+	}
+
+	if len(search.Statuses) == 0 {
+		return nil, nil
+	}
+
+	args := []any{statusStrings(search.Statuses)}
+	conds := []string{"f.status = ANY($1::flat_status[])", "f.deleted_at IS NULL"}
+	where := func(cond string, values ...any) {
+		placeholders := make([]any, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = len(args)
+		}
+		conds = append(conds, fmt.Sprintf(cond, placeholders...))
+	}
+
+	if search.Rooms != nil {
+		where("f.rooms = $%d", *search.Rooms)
+	}
+	if search.MinPrice != nil {
+		where("f.price >= $%d", *search.MinPrice)
+	}
+	if search.MaxPrice != nil {
+		where("f.price <= $%d", *search.MaxPrice)
+	}
+	if search.MinYear != nil {
+		where("h.year >= $%d", *search.MinYear)
+	}
+	if search.MaxYear != nil {
+		where("h.year <= $%d", *search.MaxYear)
+	}
+	if search.DeveloperID != nil {
+		where("h.developer_id = $%d", *search.DeveloperID)
+	}
+	if search.MinArea != nil {
+		where("f.total_area >= $%d", *search.MinArea)
+	}
+	if search.MaxArea != nil {
+		where("f.total_area <= $%d", *search.MaxArea)
+	}
+	if search.MinFloor != nil {
+		where("f.floor >= $%d", *search.MinFloor)
+	}
+	if search.MaxFloor != nil {
+		where("f.floor <= $%d", *search.MaxFloor)
+	}
+	if search.Balcony != nil {
+		where("f.balcony = $%d", string(*search.Balcony))
+	}
+	if search.Finishing != nil {
+		where("f.finishing = $%d", string(*search.Finishing))
+	}
+
+	direction, op := "ASC", ">"
+	if search.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	order := "f.id " + direction
+	if column.name != "f.id" {
+		order = column.name + " " + direction + ", " + order
+	}
+
+	if search.After != nil {
+		switch {
+		case column.name == "f.id":
+			where("f.id "+op+" $%d", search.After.ID)
+		case column.timestamp:
+			where("("+column.name+", f.id) "+op+" ($%d, $%d)", search.After.At, search.After.ID)
+		default:
+			where("("+column.name+", f.id) "+op+" ($%d, $%d)", search.After.Value, search.After.ID)
+		}
+	}
+
+	args = append(args, search.Limit)
+	sql := fmt.Sprintf("SELECT "+flatColumns+" FROM flats f JOIN house h ON h.id = f.house_id WHERE %s ORDER BY %s LIMIT $%d",
+		strings.Join(conds, " AND "), order, len(args))
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "search flats")
+	}
+	defer rows.Close()
+
+	var flats []*dto.DtoFlat
+	for rows.Next() {
+		flat, err := scanFlat(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan flats")
+		}
+		flats = append(flats, flat)
+	}
+
+	return flats, errors.Wrap(rows.Err(), "search flats")
+}
+
+// scanFlat reads the columns of flatColumns followed by extra ones and fills in the price per square meter.
+func scanFlat(row RowDBFlat, extra ...any) (*dto.DtoFlat, error) {
+	flat := &dto.DtoFlat{}
+	dest := append([]any{
+		&flat.ID, &flat.HouseID, &flat.Status, &flat.Number, &flat.Rooms, &flat.Price,
+		&flat.Floor, &flat.TotalArea, &flat.LivingArea, &flat.Section, &flat.CeilingHeight, &flat.Balcony, &flat.Finishing,
+		&flat.CreatedBy, &flat.CreatedAt, &flat.UpdatedAt, &flat.Version,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	flat.PricePerSqm = pricePerSqm(flat.Price, flat.TotalArea)
+
+	return flat, nil
+}
+
+// pricePerSqm is the price of a square meter of total area rounded to a whole unit, nil if the area is unknown.
+func pricePerSqm(price int, totalArea *float64) *int {
+	if totalArea == nil || *totalArea <= 0 {
+		return nil
 	}
 
-	return flats, nil
+	v := int(math.Round(float64(price) / *totalArea))
+	return &v
 }
@@ -0,0 +1,104 @@
+//go:generate mockgen -source ./flat_media.go -destination=./mocks/flat_media_db.go -package=mocks
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+var ErrFlatMediaNotFound = errors.New("flat media not found")
+
+type DBFlatMedia interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// FlatMedia is a photo or a floor plan of a flat. The file and its thumbnail are kept in the blob store
+// under BlobKey and ThumbnailKey.
+type FlatMedia struct {
+	ID           int
+	FlatID       int
+	Kind         string
+	ContentType  string
+	Size         int64
+	BlobKey      string
+	ThumbnailKey *string
+	CreatedAt    time.Time
+}
+
+const flatMediaColumns = "id, flat_id, kind, content_type, size, blob_key, thumbnail_key, created_at"
+
+type FlatMediaRepository struct {
+	db DBFlatMedia
+}
+
+func NewFlatMediaRepository(db DBFlatMedia) *FlatMediaRepository {
+	return &FlatMediaRepository{db: db}
+}
+
+func (r *FlatMediaRepository) CreateFlatMedia(ctx context.Context, media *FlatMedia) (*FlatMedia, error) {
+	row := r.db.QueryRow(ctx, `INSERT INTO flat_media (flat_id, kind, content_type, size, blob_key, thumbnail_key)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`,
+		media.FlatID, media.Kind, media.ContentType, media.Size, media.BlobKey, media.ThumbnailKey)
+	if err := row.Scan(&media.ID, &media.CreatedAt); err != nil {
+		return nil, errors.Wrap(err, "create flat media")
+	}
+
+	return media, nil
+}
+
+func (r *FlatMediaRepository) ListFlatMedia(ctx context.Context, flatID int) ([]*FlatMedia, error) {
+	rows, err := r.db.Query(ctx, "SELECT "+flatMediaColumns+" FROM flat_media WHERE flat_id = $1 ORDER BY id", flatID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list flat media")
+	}
+	defer rows.Close()
+
+	var media []*FlatMedia
+	for rows.Next() {
+		m, err := scanFlatMedia(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan flat media")
+		}
+		media = append(media, m)
+	}
+
+	return media, errors.Wrap(rows.Err(), "list flat media")
+}
+
+func (r *FlatMediaRepository) GetFlatMedia(ctx context.Context, flatID, id int) (*FlatMedia, error) {
+	media, err := scanFlatMedia(r.db.QueryRow(ctx, "SELECT "+flatMediaColumns+" FROM flat_media WHERE flat_id = $1 AND id = $2", flatID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFlatMediaNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get flat media")
+	}
+
+	return media, nil
+}
+
+// DeleteFlatMedia deletes the row and returns it, so the caller can remove its blobs.
+func (r *FlatMediaRepository) DeleteFlatMedia(ctx context.Context, flatID, id int) (*FlatMedia, error) {
+	media, err := scanFlatMedia(r.db.QueryRow(ctx, "DELETE FROM flat_media WHERE flat_id = $1 AND id = $2 RETURNING "+flatMediaColumns, flatID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFlatMediaNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "delete flat media")
+	}
+
+	return media, nil
+}
+
+func scanFlatMedia(row RowDBFlat) (*FlatMedia, error) {
+	media := &FlatMedia{}
+	if err := row.Scan(&media.ID, &media.FlatID, &media.Kind, &media.ContentType, &media.Size, &media.BlobKey, &media.ThumbnailKey, &media.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
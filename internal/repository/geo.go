@@ -0,0 +1,37 @@
+package repository
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// box is a latitude/longitude rectangle that contains every point within some radius of its center.
+type box struct {
+	minLat, maxLat float64
+	minLon, maxLon float64
+}
+
+// boundingBox returns the smallest box containing the circle of radiusKm around the point. When the circle
+// covers a pole or crosses the antimeridian the longitude range can not be one interval, so it spans all longitudes.
+func boundingBox(lat, lon, radiusKm float64) box {
+	angular := radiusKm / earthRadiusKm
+	dLat := angular * 180 / math.Pi
+
+	b := box{
+		minLat: math.Max(lat-dLat, -90),
+		maxLat: math.Min(lat+dLat, 90),
+		minLon: -180,
+		maxLon: 180,
+	}
+
+	sinLon := math.Sin(angular) / math.Cos(lat*math.Pi/180)
+	if b.minLat == -90 || b.maxLat == 90 || sinLon >= 1 {
+		return b
+	}
+
+	dLon := math.Asin(sinLon) * 180 / math.Pi
+	if lon-dLon >= -180 && lon+dLon <= 180 {
+		b.minLon, b.maxLon = lon-dLon, lon+dLon
+	}
+
+	return b
+}
@@ -3,7 +3,6 @@ package repository
 
 import (
 	"context"
-	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -12,11 +11,25 @@ import (
 	"github.com/shhesterka04/house-service/pkg/logger"
 )
 
-var ErrHouseExists = errors.New("house already exists")
+var (
+	ErrHouseExists        = errors.New("house already exists")
+	ErrHouseNotFound      = errors.New("house not found")
+	ErrDeveloperNotFound  = errors.New("developer not found")
+	errDeveloperNameEmpty = errors.New("developer name is empty after normalization")
+)
+
+// houseSelect returns houses together with the display name of their developer, scanned by scanHouse.
+const (
+	houseColumns = "h.id, h.address, h.year, d.name, h.developer_id, h.floors, h.city, h.street, h.building, h.postal_code, h.latitude, h.longitude, h.created_at, h.updated_at, h.version"
+	houseFrom    = "house h LEFT JOIN developers d ON d.id = h.developer_id"
+	houseSelect  = "SELECT " + houseColumns + " FROM " + houseFrom
+)
 
 type DBHouse interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type RowDBHouse interface {
@@ -31,36 +44,214 @@ func NewHouseRepository(db DBHouse) *HouseRepository {
 	return &HouseRepository{db: db}
 }
 
+// CreateHouse creates the house and records it in the audit log in one transaction.
 func (r *HouseRepository) CreateHouse(ctx context.Context, house *dto.House) (*dto.House, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin create house")
+	}
+	defer tx.Rollback(ctx)
+
 	var existingHouse dto.House
-	err := r.db.QueryRow(ctx, "SELECT id FROM house WHERE address = $1", house.Address).Scan(&existingHouse.Id)
+	err = tx.QueryRow(ctx, "SELECT id FROM house WHERE normalized_address = normalize_address($1)", house.Address).Scan(&existingHouse.Id)
 	if err == nil {
 		return nil, errors.Wrap(ErrHouseExists, "house already exists")
 	} else if !errors.Is(err, pgx.ErrNoRows) {
 		return nil, errors.Wrap(err, "query row")
 	}
 
-	if _, err = r.db.Exec(ctx, "INSERT INTO house (address, year, developer) VALUES ($1, $2, $3)", house.Address, house.Year, house.Developer); err != nil {
+	developerID, err := resolveDeveloper(ctx, tx, house)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = tx.QueryRow(ctx, `INSERT INTO house (address, year, developer_id, floors, city, street, building, postal_code, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		house.Address, house.Year, developerID, house.Floors, house.City, house.Street, house.Building, house.PostalCode, house.Latitude, house.Longitude).Scan(&id)
+	if err != nil {
 		return nil, errors.Wrap(err, "create house")
 	}
 
-	_ = r.db.QueryRow(ctx, "SELECT * FROM house WHERE address = $1", house.Address).Scan(&house.Id, &house.Address, &house.Year, &house.Developer, &house.CreatedAt, &house.UpdateAt)
+	created, err := scanHouse(tx.QueryRow(ctx, houseSelect+" WHERE h.id = $1", id))
+	if err != nil {
+		return nil, errors.Wrap(err, "get house")
+	}
 
-	return house, nil
+	after, err := auditState(created)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeAudit(ctx, tx, dto.AuditHouseCreate, dto.AuditEntityHouse, id, nil, after); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit create house")
+	}
+
+	return created, nil
 }
 
-func (r *HouseRepository) UpdateHouse(ctx context.Context, id int, updAt time.Time) (*dto.House, error) {
-	if _, err := r.db.Exec(ctx, "UPDATE house SET updated_at = $1 WHERE id = $2", updAt, id); err != nil {
-		return nil, errors.Wrap(err, "update house")
+// resolveDeveloper returns the developer the house should reference: the one given by ID, or the one whose
+// normalized name matches the free-text developer, creating it on first use.
+func resolveDeveloper(ctx context.Context, q querier, house *dto.House) (*int, error) {
+	if house.DeveloperId != nil {
+		var id int
+		err := q.QueryRow(ctx, "SELECT id FROM developers WHERE id = $1", *house.DeveloperId).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeveloperNotFound
+		} else if err != nil {
+			return nil, errors.Wrap(err, "get developer")
+		}
+
+		return &id, nil
 	}
 
-	row := r.db.QueryRow(ctx, "SELECT id, address, year, developer, created_at, updated_at FROM house WHERE id = $1", id)
-	house := &dto.House{}
-	if err := row.Scan(&house.Id, &house.Address, &house.Year, &house.Developer, &house.CreatedAt, &house.UpdateAt); err != nil {
+	if house.Developer == nil || *house.Developer == "" {
+		return nil, nil
+	}
+
+	var id int
+	err := q.QueryRow(ctx, `INSERT INTO developers (name, normalized_name)
+		SELECT btrim($1), n FROM normalize_developer_name($1) n WHERE n IS NOT NULL
+		ON CONFLICT (normalized_name) DO UPDATE SET normalized_name = EXCLUDED.normalized_name
+		RETURNING id`, *house.Developer).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		logger.Infof(ctx, "house %q: %v", house.Address, errDeveloperNameEmpty)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "resolve developer")
+	}
+
+	return &id, nil
+}
+
+func (r *HouseRepository) GetHouse(ctx context.Context, id int) (*dto.House, error) {
+	house, err := scanHouse(r.db.QueryRow(ctx, houseSelect+" WHERE h.id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrHouseNotFound
+	} else if err != nil {
 		return nil, errors.Wrap(err, "get house")
 	}
 
-	logger.Infof(ctx, "house updated: %v", house)
+	return house, nil
+}
+
+// IsHouseDeveloper reports whether the house belongs to the developer linked to userID.
+func (r *HouseRepository) IsHouseDeveloper(ctx context.Context, houseID int, userID string) (bool, error) {
+	var owned bool
+	err := r.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM house h JOIN developers d ON d.id = h.developer_id WHERE h.id = $1 AND d.user_id = $2)", houseID, userID).Scan(&owned)
+	if err != nil {
+		return false, errors.Wrap(err, "check house developer")
+	}
+
+	return owned, nil
+}
+
+// Subscribe subscribes email to news about the house. Subscribing twice is not an error.
+func (r *HouseRepository) Subscribe(ctx context.Context, houseID int, email string) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO house_subscriptions (house_id, email) VALUES ($1, $2) ON CONFLICT DO NOTHING", houseID, email)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation:
+		return ErrHouseNotFound
+	case err != nil:
+		return errors.Wrap(err, "subscribe to house")
+	}
+
+	return nil
+}
+
+// SearchHouses returns up to limit houses whose address matches the query either as full text or by
+// trigram similarity of the normalized address, best matches first. A non-empty city restricts the search.
+func (r *HouseRepository) SearchHouses(ctx context.Context, query, city string, limit int) ([]dto.HouseMatch, error) {
+	return r.queryHouseMatches(ctx, `WITH q AS (SELECT websearch_to_tsquery('russian', $1) AS ts, normalize_address($1) AS norm)
+		SELECT `+houseColumns+`, GREATEST(ts_rank(h.address_search, q.ts), similarity(h.normalized_address, q.norm)) AS score
+		FROM `+houseFrom+`, q
+		WHERE (h.address_search @@ q.ts OR h.normalized_address % q.norm) AND ($2 = '' OR lower(h.city) = lower($2))
+		ORDER BY score DESC, h.id
+		LIMIT $3`, query, city, limit)
+}
+
+// NearbyHouses returns up to limit houses within radiusKm of the point, nearest first. A bounding box on
+// the indexed coordinates selects the candidates, the haversine formula gives the exact distance. Rounding can
+// push the argument of asin a little over 1 for antipodal points, so it is capped.
+func (r *HouseRepository) NearbyHouses(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]dto.HouseNearby, error) {
+	box := boundingBox(lat, lon, radiusKm)
+
+	rows, err := r.db.Query(ctx, `SELECT `+houseColumns+`, dist.km
+		FROM `+houseFrom+`,
+		LATERAL (SELECT 2 * $3::float8 * asin(LEAST(1, sqrt(
+			power(sin(radians(h.latitude - $1::float8) / 2), 2) +
+			cos(radians($1::float8)) * cos(radians(h.latitude)) * power(sin(radians(h.longitude - $2::float8) / 2), 2)
+		))) AS km) dist
+		WHERE h.latitude BETWEEN $4 AND $5 AND h.longitude BETWEEN $6 AND $7 AND dist.km <= $8
+		ORDER BY dist.km, h.id
+		LIMIT $9`,
+		lat, lon, earthRadiusKm, box.minLat, box.maxLat, box.minLon, box.maxLon, radiusKm, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "nearby houses")
+	}
+	defer rows.Close()
+
+	var houses []dto.HouseNearby
+	for rows.Next() {
+		var nearby dto.HouseNearby
+		house, err := scanHouse(rows, &nearby.DistanceKm)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan houses")
+		}
+		nearby.House = *house
+		houses = append(houses, nearby)
+	}
+
+	return houses, errors.Wrap(rows.Err(), "nearby houses")
+}
+
+// SimilarHouses returns up to limit houses whose normalized address is at least minScore similar to address.
+func (r *HouseRepository) SimilarHouses(ctx context.Context, address string, minScore float32, limit int) ([]dto.HouseMatch, error) {
+	return r.queryHouseMatches(ctx, `WITH q AS (SELECT normalize_address($1) AS norm)
+		SELECT `+houseColumns+`, similarity(h.normalized_address, q.norm) AS score
+		FROM `+houseFrom+`, q
+		WHERE h.normalized_address % q.norm AND similarity(h.normalized_address, q.norm) >= $2
+		ORDER BY score DESC, h.id
+		LIMIT $3`, address, minScore, limit)
+}
+
+func (r *HouseRepository) queryHouseMatches(ctx context.Context, sql string, args ...any) ([]dto.HouseMatch, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "search houses")
+	}
+	defer rows.Close()
+
+	var matches []dto.HouseMatch
+	for rows.Next() {
+		var match dto.HouseMatch
+		house, err := scanHouse(rows, &match.Score)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan houses")
+		}
+		match.House = *house
+		matches = append(matches, match)
+	}
+
+	return matches, errors.Wrap(rows.Err(), "search houses")
+}
+
+// scanHouse reads the columns of houseSelect followed by extra ones.
+func scanHouse(row RowDBHouse, extra ...any) (*dto.House, error) {
+	house := &dto.House{}
+	dest := append([]any{
+		&house.Id, &house.Address, &house.Year, &house.Developer, &house.DeveloperId, &house.Floors,
+		&house.City, &house.Street, &house.Building, &house.PostalCode, &house.Latitude, &house.Longitude,
+		&house.CreatedAt, &house.UpdateAt, &house.Version,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
 
 	return house, nil
 }
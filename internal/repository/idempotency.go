@@ -0,0 +1,109 @@
+//go:generate mockgen -source ./idempotency.go -destination=./mocks/idempotency_db.go -package=mocks
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+// idempotencyCleanupBatch bounds the number of expired keys removed by one reservation.
+const idempotencyCleanupBatch = 100
+
+// ErrIdempotencyKeyLost is returned when a request has lost its key to a later reservation.
+var ErrIdempotencyKeyLost = errors.New("idempotency key is reserved by another request")
+
+// IdempotencyRecord is a request made with an Idempotency-Key and, once it is complete, its response.
+// Status is zero while the request is in progress. Token identifies the reservation of the request holding
+// the key, a request that lost the key can neither save its response nor release it.
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	Token       string
+	Status      int
+	Header      map[string]string
+	Body        []byte
+}
+
+type DBIdempotency interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type IdempotencyRepository struct {
+	db DBIdempotency
+}
+
+func NewIdempotencyRepository(db DBIdempotency) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// ReserveIdempotencyKey claims the key of the record for lockTimeout, the time its request has to complete,
+// and sets the token of the reservation. If an unexpired record holds the key, it is returned and nothing
+// changes, otherwise the result is nil. A
+// request that has not completed in lockTimeout loses the key to the next reservation. Expired records are
+// removed on the way.
+func (r *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, record *IdempotencyRecord, lockTimeout time.Duration) (*IdempotencyRecord, error) {
+	if _, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE (scope, key) IN (
+		SELECT scope, key FROM idempotency_keys WHERE expires_at <= now() LIMIT $1)`, idempotencyCleanupBatch); err != nil {
+		return nil, errors.Wrap(err, "delete expired idempotency keys")
+	}
+
+	// The key may be released by a failed request between the two statements, then it is claimed again.
+	for attempt := 0; attempt < 2; attempt++ {
+		err := r.db.QueryRow(ctx, `INSERT INTO idempotency_keys (scope, key, request_hash, expires_at)
+			VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+			ON CONFLICT (scope, key) DO UPDATE SET request_hash = EXCLUDED.request_hash, token = EXCLUDED.token, status = 0,
+				headers = NULL, body = NULL, created_at = now(), expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= now()
+			RETURNING token::text`,
+			record.Scope, record.Key, record.RequestHash, lockTimeout.Seconds()).Scan(&record.Token)
+		if err == nil {
+			return nil, nil
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.Wrap(err, "reserve idempotency key")
+		}
+
+		existing := &IdempotencyRecord{Scope: record.Scope, Key: record.Key}
+		err = r.db.QueryRow(ctx, "SELECT request_hash, status, headers, body FROM idempotency_keys WHERE scope = $1 AND key = $2",
+			record.Scope, record.Key).Scan(&existing.RequestHash, &existing.Status, &existing.Header, &existing.Body)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "get idempotency key")
+		}
+
+		return existing, nil
+	}
+
+	return nil, errors.New("idempotency key is contended")
+}
+
+// SaveIdempotentResponse stores the response of the request holding the key of the record and keeps it for ttl.
+// If the reservation of the record no longer holds the key, ErrIdempotencyKeyLost is returned.
+func (r *IdempotencyRepository) SaveIdempotentResponse(ctx context.Context, record *IdempotencyRecord, ttl time.Duration) error {
+	tag, err := r.db.Exec(ctx, `UPDATE idempotency_keys SET status = $4, headers = $5, body = $6, expires_at = now() + make_interval(secs => $7)
+		WHERE scope = $1 AND key = $2 AND token = $3 AND status = 0`,
+		record.Scope, record.Key, record.Token, record.Status, record.Header, record.Body, ttl.Seconds())
+	if err != nil {
+		return errors.Wrap(err, "save idempotent response")
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrIdempotencyKeyLost
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey frees the key of a record whose request did not complete, so that a retry runs it
+// again. A key reserved by another request since is left alone.
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, record *IdempotencyRecord) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND token = $3 AND status = 0",
+		record.Scope, record.Key, record.Token)
+
+	return errors.Wrap(err, "release idempotency key")
+}
@@ -0,0 +1,233 @@
+//go:generate mockgen -source ./moderation.go -destination=./mocks/moderation_db.go -package=mocks
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+)
+
+// ModerationQueueFilter selects pending flats. Nil filters are not applied. Flats are ordered by the time
+// they entered their status and then by id, After is the position of the last flat of the previous page.
+type ModerationQueueFilter struct {
+	HouseID     *int
+	DeveloperID *int
+	// Statuses narrow down the pending statuses, flats in other statuses are never in the queue.
+	Statuses []dto.Status
+	After    *QueueCursor
+	Limit    int
+}
+
+// QueueCursor is a keyset position in the moderation queue.
+type QueueCursor struct {
+	QueuedAt time.Time
+	ID       int
+}
+
+// ModerationDecision is an approval or a decline of a flat. Declines carry a reason from the catalog.
+// ModeratorID is nil when the moderator has no account, e.g. a dummy login.
+type ModerationDecision struct {
+	ID          int
+	FlatID      int
+	Status      dto.Status
+	Reason      *dto.DeclineReason
+	Comment     *string
+	ModeratorID *string
+	CreatedAt   time.Time
+}
+
+type DBModeration interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type ModerationRepository struct {
+	db DBModeration
+}
+
+func NewModerationRepository(db DBModeration) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+// ModerationQueue returns up to filter.Limit pending flats, oldest first. The age is computed by the
+// database, so it does not depend on the clock of the service.
+func (r *ModerationRepository) ModerationQueue(ctx context.Context, filter ModerationQueueFilter) ([]*dto.ModerationQueueItem, error) {
+	if len(filter.Statuses) == 0 {
+		return nil, nil
+	}
+
+	conds, args := moderationQueueConditions(filter)
+	if filter.After != nil {
+		args = append(args, filter.After.QueuedAt, filter.After.ID)
+		conds = append(conds, fmt.Sprintf("(f.status_changed_at, f.id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`SELECT %s, f.status_changed_at, EXTRACT(EPOCH FROM now() - f.status_changed_at)::bigint
+		FROM flats f JOIN house h ON h.id = f.house_id
+		WHERE %s
+		ORDER BY f.status_changed_at, f.id
+		LIMIT $%d`, flatColumns, strings.Join(conds, " AND "), len(args)), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "moderation queue")
+	}
+	defer rows.Close()
+
+	var items []*dto.ModerationQueueItem
+	for rows.Next() {
+		item := &dto.ModerationQueueItem{}
+		flat, err := scanFlat(rows, &item.QueuedAt, &item.AgeSeconds)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan moderation queue")
+		}
+		item.DtoFlat = *flat
+		items = append(items, item)
+	}
+
+	return items, errors.Wrap(rows.Err(), "moderation queue")
+}
+
+// ModerationQueueCounts returns the number of flats per status matching the filter, its cursor and limit aside.
+func (r *ModerationRepository) ModerationQueueCounts(ctx context.Context, filter ModerationQueueFilter) (map[dto.Status]int, error) {
+	counts := make(map[dto.Status]int, len(filter.Statuses))
+	if len(filter.Statuses) == 0 {
+		return counts, nil
+	}
+
+	conds, args := moderationQueueConditions(filter)
+	rows, err := r.db.Query(ctx, `SELECT f.status, count(*)
+		FROM flats f JOIN house h ON h.id = f.house_id
+		WHERE `+strings.Join(conds, " AND ")+`
+		GROUP BY f.status`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "moderation queue counts")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			status dto.Status
+			count  int
+		)
+		if err = rows.Scan(&status, &count); err != nil {
+			return nil, errors.Wrap(err, "scan moderation queue counts")
+		}
+		counts[status] = count
+	}
+
+	return counts, errors.Wrap(rows.Err(), "moderation queue counts")
+}
+
+// moderationQueuePredicate is the predicate of the partial index idx_flats_moderation_queue. The planner only uses
+// the index for a query that states it literally, a parameter such as the statuses of the filter will not do.
+const moderationQueuePredicate = "f.status IN ('created', 'on moderation')"
+
+func moderationQueueConditions(filter ModerationQueueFilter) ([]string, []any) {
+	args := []any{statusStrings(filter.Statuses)}
+	conds := []string{moderationQueuePredicate, "f.status = ANY($1::flat_status[])", "f.deleted_at IS NULL"}
+
+	if filter.HouseID != nil {
+		args = append(args, *filter.HouseID)
+		conds = append(conds, fmt.Sprintf("f.house_id = $%d", len(args)))
+	}
+	if filter.DeveloperID != nil {
+		args = append(args, *filter.DeveloperID)
+		conds = append(conds, fmt.Sprintf("h.developer_id = $%d", len(args)))
+	}
+
+	return conds, args
+}
+
+// DeclineReasons returns the whole catalog of decline reasons, inactive ones included.
+func (r *ModerationRepository) DeclineReasons(ctx context.Context) ([]dto.DeclineReason, error) {
+	rows, err := r.db.Query(ctx, "SELECT code, title, active FROM decline_reasons ORDER BY code")
+	if err != nil {
+		return nil, errors.Wrap(err, "decline reasons")
+	}
+	defer rows.Close()
+
+	var reasons []dto.DeclineReason
+	for rows.Next() {
+		var reason dto.DeclineReason
+		if err = rows.Scan(&reason.Code, &reason.Title, &reason.Active); err != nil {
+			return nil, errors.Wrap(err, "scan decline reasons")
+		}
+		reasons = append(reasons, reason)
+	}
+
+	return reasons, errors.Wrap(rows.Err(), "decline reasons")
+}
+
+// SaveDeclineReason adds the reason to the catalog or replaces the title and the flag of an existing one, and
+// records it in the audit log in the same transaction.
+func (r *ModerationRepository) SaveDeclineReason(ctx context.Context, reason dto.DeclineReason) (*dto.DeclineReason, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin save decline reason")
+	}
+	defer tx.Rollback(ctx)
+
+	var before map[string]any
+	var previous dto.DeclineReason
+	err = tx.QueryRow(ctx, "SELECT title, active FROM decline_reasons WHERE code = $1 FOR UPDATE", reason.Code).Scan(&previous.Title, &previous.Active)
+	if err == nil {
+		before = map[string]any{"title": previous.Title, "active": previous.Active}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrap(err, "get decline reason")
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO decline_reasons (code, title, active) VALUES ($1, $2, $3)
+		ON CONFLICT (code) DO UPDATE SET title = EXCLUDED.title, active = EXCLUDED.active
+		RETURNING code, title, active`, reason.Code, reason.Title, reason.Active).Scan(&reason.Code, &reason.Title, &reason.Active)
+	if err != nil {
+		return nil, errors.Wrap(err, "save decline reason")
+	}
+
+	after := map[string]any{"title": reason.Title, "active": reason.Active}
+	if err = writeAudit(ctx, tx, dto.AuditDeclineReasonSave, dto.AuditEntityDeclineReason, reason.Code, before, after); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "commit save decline reason")
+	}
+
+	return &reason, nil
+}
+
+// FlatDecisions returns the moderation decisions on the flat, latest first.
+func (r *ModerationRepository) FlatDecisions(ctx context.Context, flatID int) ([]*ModerationDecision, error) {
+	rows, err := r.db.Query(ctx, `SELECT m.id, m.flat_id, m.status, m.comment, m.moderator_id, m.created_at, d.code, d.title, d.active
+		FROM moderation_decisions m LEFT JOIN decline_reasons d ON d.code = m.reason_code
+		WHERE m.flat_id = $1
+		ORDER BY m.id DESC`, flatID)
+	if err != nil {
+		return nil, errors.Wrap(err, "flat decisions")
+	}
+	defer rows.Close()
+
+	var decisions []*ModerationDecision
+	for rows.Next() {
+		var (
+			decision    ModerationDecision
+			code, title *string
+			active      *bool
+		)
+		err = rows.Scan(&decision.ID, &decision.FlatID, &decision.Status, &decision.Comment, &decision.ModeratorID, &decision.CreatedAt, &code, &title, &active)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan flat decisions")
+		}
+		if code != nil {
+			decision.Reason = &dto.DeclineReason{Code: *code, Title: *title, Active: *active}
+		}
+		decisions = append(decisions, &decision)
+	}
+
+	return decisions, errors.Wrap(rows.Err(), "flat decisions")
+}
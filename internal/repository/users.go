@@ -7,9 +7,13 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
 )
 
-var ErrUserExists = errors.New("user already exists")
+var (
+	ErrUserExists   = errors.New("user already exists")
+	ErrUserNotFound = errors.New("user not found")
+)
 
 type RowDBUser interface {
 	Scan(dest ...any) error
@@ -18,6 +22,7 @@ type RowDBUser interface {
 type DBUser interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type User struct {
@@ -25,6 +30,7 @@ type User struct {
 	Email    string
 	Password string
 	Type     string
+	Disabled bool
 }
 
 type UserRepository struct {
@@ -35,27 +41,111 @@ func NewUserRepository(db DBUser) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) CreateUser(ctx context.Context, user User) error {
+// CreateUser creates the user, records it in the audit log in one transaction and returns its ID. The
+// password hash is not audited.
+func (r *UserRepository) CreateUser(ctx context.Context, user User) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "begin create user")
+	}
+	defer tx.Rollback(ctx)
+
 	var existingUser User
-	err := r.db.QueryRow(ctx, "SELECT id FROM users WHERE email = $1", user.Email).Scan(&existingUser.UUID)
+	err = tx.QueryRow(ctx, "SELECT id FROM users WHERE email = $1", user.Email).Scan(&existingUser.UUID)
 	if err == nil {
-		return errors.Wrap(ErrUserExists, "query row")
+		return "", errors.Wrap(ErrUserExists, "query row")
 	} else if !errors.Is(err, pgx.ErrNoRows) {
-		return errors.Wrap(err, "query row")
+		return "", errors.Wrap(err, "query row")
+	}
+
+	var id string
+	if err = tx.QueryRow(ctx, "INSERT INTO users (email, password, type) VALUES ($1, $2, $3) RETURNING id", user.Email, user.Password, user.Type).Scan(&id); err != nil {
+		return "", errors.Wrap(err, "exec")
 	}
 
-	if _, err = r.db.Exec(ctx, "INSERT INTO users (email, password, type) VALUES ($1, $2, $3)", user.Email, user.Password, user.Type); err != nil {
-		return errors.Wrap(err, "exec")
+	after := map[string]any{"email": user.Email, "type": user.Type}
+	if err = writeAudit(ctx, tx, dto.AuditUserCreate, dto.AuditEntityUser, id, nil, after); err != nil {
+		return "", err
 	}
 
-	return nil
+	if err = tx.Commit(ctx); err != nil {
+		return "", errors.Wrap(err, "commit create user")
+	}
+
+	return id, nil
 }
 
 func (r *UserRepository) GetUser(ctx context.Context, email string) (User, error) {
 	var user User
-	if err := r.db.QueryRow(ctx, "SELECT * FROM users WHERE email = $1", email).Scan(&user.UUID, &user.Email, &user.Password, &user.Type); err != nil {
+	if err := r.db.QueryRow(ctx, "SELECT id, email, password, type, disabled FROM users WHERE email = $1", email).Scan(&user.UUID, &user.Email, &user.Password, &user.Type, &user.Disabled); err != nil {
 		return User{}, errors.Wrap(err, "get user")
 	}
 
 	return user, nil
 }
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id string) (User, error) {
+	var user User
+	err := r.db.QueryRow(ctx, "SELECT id, email, password, type, disabled FROM users WHERE id = $1", id).Scan(&user.UUID, &user.Email, &user.Password, &user.Type, &user.Disabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	} else if err != nil {
+		return User{}, errors.Wrap(err, "get user")
+	}
+
+	return user, nil
+}
+
+// UpdateUserType changes the role of the user and records it in the audit log in one transaction.
+func (r *UserRepository) UpdateUserType(ctx context.Context, id, userType string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin update user type")
+	}
+	defer tx.Rollback(ctx)
+
+	var previous string
+	err = tx.QueryRow(ctx, "SELECT type FROM users WHERE id = $1 FOR UPDATE", id).Scan(&previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUserNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "get user type")
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE users SET type = $1 WHERE id = $2", userType, id); err != nil {
+		return errors.Wrap(err, "update user type")
+	}
+
+	if err = writeAudit(ctx, tx, dto.AuditUserRole, dto.AuditEntityUser, id, map[string]any{"type": previous}, map[string]any{"type": userType}); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "commit update user type")
+}
+
+// SetUserDisabled disables or enables the user and records it in the audit log in one transaction.
+func (r *UserRepository) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin set user disabled")
+	}
+	defer tx.Rollback(ctx)
+
+	var previous bool
+	err = tx.QueryRow(ctx, "SELECT disabled FROM users WHERE id = $1 FOR UPDATE", id).Scan(&previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUserNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "get user disabled")
+	}
+
+	if _, err = tx.Exec(ctx, "UPDATE users SET disabled = $1 WHERE id = $2", disabled, id); err != nil {
+		return errors.Wrap(err, "set user disabled")
+	}
+
+	if err = writeAudit(ctx, tx, dto.AuditUserDisabled, dto.AuditEntityUser, id, map[string]any{"disabled": previous}, map[string]any{"disabled": disabled}); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "commit set user disabled")
+}
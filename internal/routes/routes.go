@@ -1,25 +1,264 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/shhesterka04/house-service/api"
+	"github.com/shhesterka04/house-service/internal/config"
 	"github.com/shhesterka04/house-service/internal/dto"
 	"github.com/shhesterka04/house-service/internal/handlers"
 	"github.com/shhesterka04/house-service/internal/middleware"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
 )
 
-func NewRouter(authHandlers *handlers.AuthHandlers, houseHandlers *handlers.HouseHandler, flatHandlers *handlers.FlatHandler) *http.ServeMux {
+// defaultMaxBody limits the request body of routes without a limit of their own.
+const defaultMaxBody = 1 << 20
+
+// ipRateLimitRoute counts the requests of a client IP to any protected route.
+const ipRateLimitRoute = "protected"
+
+// route is what the router adds to the generated handler of an operation.
+type route struct {
+	// public routes need no authentication, the others require action.
+	public bool
+	action policy.Action
+	// idempotent routes replay the response to a repeated Idempotency-Key.
+	idempotent bool
+	// maxBody limits the request body, defaultMaxBody if zero.
+	maxBody int64
+}
+
+// v1Routes lists every operation of api/api.yaml, the spec of v1. The router refuses to start if an operation
+// is missing here or an entry has no operation.
+var v1Routes = map[string]route{
+	"GET /dummyLogin": {public: true},
+	"POST /login":     {public: true},
+	"POST /register":  {public: true},
+
+	"GET /openapi.yaml": {public: true},
+	"GET /openapi.json": {public: true},
+	"GET /docs":         {public: true},
+
+	"POST /house/create":            {action: policy.HouseCreate, idempotent: true},
+	"GET /houses/nearby":            {action: policy.HouseRead},
+	"GET /houses/search":            {action: policy.HouseRead},
+	"GET /house/{id}":               {action: policy.HouseRead},
+	"GET /house/{id}/export":        {action: policy.HouseRead},
+	"POST /house/{id}/subscribe":    {action: policy.HouseSubscribe},
+	"POST /house/{id}/flats/import": {action: policy.FlatCreate, maxBody: handlers.MaxFlatImportBody},
+
+	"GET /flats/search":               {action: policy.FlatRead},
+	"GET /flats/export":               {action: policy.FlatExportAll},
+	"POST /flat/create":               {action: policy.FlatCreate, idempotent: true},
+	"POST /flat/update":               {action: policy.FlatModerate},
+	"DELETE /flat/{id}":               {action: policy.FlatDelete},
+	"GET /flat/{id}/decisions":        {action: policy.FlatRead},
+	"GET /flat/{id}/media":            {action: policy.FlatRead},
+	"GET /flat/{id}/media/{media_id}": {action: policy.FlatRead},
+	// One byte over the limit lets the service tell an oversized file from one of exactly the maximum size.
+	"POST /flat/{id}/media":              {action: policy.FlatMediaManage, maxBody: service.MaxFlatMediaSize + 1},
+	"DELETE /flat/{id}/media/{media_id}": {action: policy.FlatMediaManage},
+
+	"GET /moderation/queue":                  {action: policy.FlatModerate},
+	"GET /moderation/decline-reasons":        {action: policy.FlatModerate},
+	"PUT /moderation/decline-reasons/{code}": {action: policy.DeclineReasonManage},
+
+	"GET /audit":                     {action: policy.AuditRead},
+	"POST /admin/users/{id}/role":    {action: policy.UserManage},
+	"POST /admin/users/{id}/disable": {action: policy.UserManage},
+	"POST /admin/users/{id}/enable":  {action: policy.UserManage},
+	"GET /api-keys":                  {action: policy.APIKeyManage},
+	"POST /api-keys":                 {action: policy.APIKeyManage},
+	"DELETE /api-keys/{id}":          {action: policy.APIKeyManage},
+	"GET /developers":                {action: policy.DeveloperRead},
+	"POST /developers":               {action: policy.DeveloperManage},
+	"GET /developers/{id}/houses":    {action: policy.DeveloperRead},
+}
+
+// version is a major version of the API: a spec, the package generated from it and the routes of its
+// operations, served under a prefix of its own. The servers of all versions share the services, so a new
+// version is added next to v1 in NewRouter and clients move over while v1 is still served.
+type version struct {
+	prefix string
+	spec   *middleware.OpenAPI
+	routes map[string]route
+	// register registers the generated operations of the version with r, under the prefix of r.
+	register func(r *router)
+}
+
+func v1(cfg *config.Config, server dto.StrictServerInterface) version {
+	return version{
+		prefix: "/v1",
+		spec:   loadSpec(cfg, api.Spec),
+		routes: v1Routes,
+		register: func(r *router) {
+			strict := dto.NewStrictHandlerWithOptions(server, nil, dto.StrictHTTPServerOptions{
+				RequestErrorHandlerFunc:  handlers.WriteRequestError,
+				ResponseErrorHandlerFunc: handlers.WriteError,
+			})
+			dto.HandlerWithOptions(strict, dto.StdHTTPServerOptions{
+				BaseURL:          r.prefix,
+				BaseRouter:       r,
+				ErrorHandlerFunc: handlers.WriteRequestError,
+			})
+		},
+	}
+}
+
+// loadSpec checks requests against spec, and in the test environment the responses as well.
+func loadSpec(cfg *config.Config, spec []byte) *middleware.OpenAPI {
+	openAPI, err := middleware.NewOpenAPI(spec, cfg.Env == config.EnvTest)
+	if err != nil {
+		panic(fmt.Sprintf("routes: %v", err))
+	}
+
+	return openAPI
+}
+
+// NewRouter serves the operations of server under /v1, each behind the middleware of its entry in v1Routes.
+// The same routes without the prefix, as they were served before versioning, remain as deprecated aliases.
+// GET /dummyLogin is only served where dummy logins are enabled. It panics if a route of cfg.RateLimits is
+// not in v1Routes.
+func NewRouter(cfg *config.Config, server dto.StrictServerInterface, apiKeys middleware.APIKeyResolver, tokens middleware.TokenResolver, idempotencyKeys middleware.IdempotencyStore, rateLimits ratelimit.Store) *http.ServeMux {
+	for operation := range cfg.RateLimits.Routes {
+		if _, ok := v1Routes[operation]; !ok {
+			panic(fmt.Sprintf("routes: rate limited route %s is not an operation of the spec", operation))
+		}
+	}
+
 	mux := http.NewServeMux()
-	mux.HandleFunc("GET /dummyLogin", authHandlers.DummyLogin)
-	mux.HandleFunc("POST /login", authHandlers.Login)
-	mux.HandleFunc("POST /register", authHandlers.Register)
+	clientIP := middleware.ClientIP(cfg.TrustedProxyPrefixes)
+	cors := middleware.CORS(middleware.CORSOptions{
+		AllowedOrigins:   cfg.CORSAllowedOrigins,
+		AllowedMethods:   cfg.CORSAllowedMethods,
+		AllowedHeaders:   cfg.CORSAllowedHeaders,
+		ExposedHeaders:   cfg.CORSExposedHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	})
+	secure := middleware.SecurityHeaders(middleware.SecurityHeadersOptions{
+		HSTSMaxAge:            cfg.HSTSMaxAge,
+		FrameOptions:          cfg.FrameOptions,
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+	})
 
-	protectedRoutes := http.NewServeMux()
-	protectedRoutes.Handle("POST /house/create", middleware.AuthMiddleware(dto.Moderator)(http.HandlerFunc(houseHandlers.CreateHouse)))
-	protectedRoutes.Handle("GET /house/{id}", middleware.AuthMiddleware(dto.Client)(http.HandlerFunc(flatHandlers.GetFlatsByHouseID)))
-	protectedRoutes.Handle("POST /flat/create", middleware.AuthMiddleware(dto.Client)(http.HandlerFunc(flatHandlers.CreateFlat)))
-	protectedRoutes.Handle("POST /flat/update", middleware.AuthMiddleware(dto.Moderator)(http.HandlerFunc(flatHandlers.UpdateFlat)))
+	mount := func(v version, prefix string, deprecated func(http.Handler) http.Handler) {
+		r := &router{
+			ServeMux:   mux,
+			cfg:        cfg,
+			apiKeys:    apiKeys,
+			tokens:     tokens,
+			idempotent: middleware.Idempotency(idempotencyKeys, cfg.IdempotencyTTL, cfg.IdempotencyLockTimeout),
+			rateLimits: rateLimits,
+			clientIP:   clientIP,
+			cors:       cors,
+			secure:     secure,
+			version:    v,
+			prefix:     prefix,
+			deprecated: deprecated,
+			registered: map[string]bool{},
+			preflight:  map[string]bool{},
+		}
+		v.register(r)
+
+		for pattern := range v.routes {
+			if !r.registered[pattern] {
+				panic(fmt.Sprintf("routes: %s is not an operation of the spec", pattern))
+			}
+		}
+	}
+
+	v1 := v1(cfg, server)
+	mount(v1, v1.prefix, nil)
+	mount(v1, "", middleware.Deprecated(cfg.UnversionedDeprecatedAt, cfg.UnversionedSunsetAt, v1.prefix))
 
-	mux.Handle("/", protectedRoutes)
 	return mux
 }
+
+// router is the mux the generated code of a version registers the operations with, under prefix. It wraps
+// every operation in the middleware of its route.
+type router struct {
+	*http.ServeMux
+	cfg        *config.Config
+	apiKeys    middleware.APIKeyResolver
+	tokens     middleware.TokenResolver
+	idempotent func(http.Handler) http.Handler
+	rateLimits ratelimit.Store
+	clientIP   func(http.Handler) http.Handler
+	cors       func(http.Handler) http.Handler
+	secure     func(http.Handler) http.Handler
+
+	version version
+	prefix  string
+	// deprecated marks the responses of deprecated routes, nil if the routes are current.
+	deprecated func(http.Handler) http.Handler
+	registered map[string]bool
+	// preflight holds the paths with an OPTIONS route.
+	preflight map[string]bool
+}
+
+// HandleFunc wraps handler, the generated operation at pattern, in the middleware of its route. Routes are
+// looked up, configured and checked against the spec by pattern without prefix, e.g. "POST /flat/create";
+// the rate limit is counted by pattern with the prefix of the version, so that an alias shares it.
+func (r *router) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	method, path, _ := strings.Cut(pattern, " ")
+	path = strings.TrimPrefix(path, r.prefix)
+	operation := method + " " + path
+
+	rt, ok := r.version.routes[operation]
+	if !ok {
+		panic(fmt.Sprintf("routes: operation %s has no route", operation))
+	}
+	r.registered[operation] = true
+
+	if operation == "GET /dummyLogin" && !r.cfg.DummyLoginEnabled() {
+		return
+	}
+
+	maxBody := rt.maxBody
+	if maxBody == 0 {
+		maxBody = defaultMaxBody
+	}
+
+	var next http.Handler = http.HandlerFunc(handler)
+	next = r.version.spec.Operation(operation, handlers.WriteRequestError)(next)
+	next = middleware.LimitBody(maxBody)(next)
+	if rt.idempotent {
+		next = r.idempotent(next)
+	}
+
+	// Protected routes apply the rate limit of the route after authentication, so that their callers are told
+	// apart by user rather than by IP, and a limit by IP before it, which authentication failures count
+	// against.
+	next = middleware.RateLimit(r.rateLimits, method+" "+r.version.prefix+path, r.cfg.RateLimits.For(operation))(next)
+	if !rt.public {
+		next = middleware.AuthMiddleware(r.apiKeys, r.tokens, rt.action)(next)
+		next = middleware.RateLimit(r.rateLimits, ipRateLimitRoute, r.cfg.RateLimitPerIP)(next)
+	}
+	r.Handle(pattern, r.clientIP(r.common(next)))
+
+	// Browsers ask before calling a path from another origin, without credentials, so the answer is the
+	// same for every operation at the path.
+	if prefixed := r.prefix + path; !r.preflight[prefixed] {
+		r.preflight[prefixed] = true
+		r.Handle(http.MethodOptions+" "+prefixed, r.common(http.HandlerFunc(noContent)))
+	}
+}
+
+// common wraps next in the middleware of every route at the prefix of r.
+func (r *router) common(next http.Handler) http.Handler {
+	next = r.cors(next)
+	if r.deprecated != nil {
+		next = r.deprecated(next)
+	}
+
+	return r.secure(next)
+}
+
+func noContent(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,379 @@
+//go:build unit
+// +build unit
+
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shhesterka04/house-service/api"
+	"github.com/shhesterka04/house-service/internal/config"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/handlers"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// unimplementedServer fails the test if a request gets past the router to an operation.
+type unimplementedServer struct {
+	dto.StrictServerInterface
+}
+
+// invalidFlatServer creates flats that break the spec: the zero status is not one of its values.
+type invalidFlatServer struct {
+	unimplementedServer
+}
+
+func (invalidFlatServer) PostFlatCreate(context.Context, dto.PostFlatCreateRequestObject) (dto.PostFlatCreateResponseObject, error) {
+	return dto.PostFlatCreate200JSONResponse{Headers: dto.PostFlatCreate200ResponseHeaders{ETag: `"1"`}}, nil
+}
+
+// docsServer serves the spec and the docs page only.
+type docsServer struct {
+	unimplementedServer
+	*handlers.DocsHandler
+}
+
+type specOperation struct {
+	pattern string
+	public  bool
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+// specOperations reads the operations of api/api.yaml. Operations without security are public.
+func specOperations(t *testing.T) []specOperation {
+	t.Helper()
+
+	raw, err := os.ReadFile("../../api/api.yaml")
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	require.NoError(t, yaml.Unmarshal(raw, &spec))
+
+	var operations []specOperation
+	for path, methods := range spec.Paths {
+		for method, node := range methods {
+			switch method {
+			case "get", "post", "put", "patch", "delete":
+			default:
+				continue
+			}
+
+			var op struct {
+				Security []map[string][]string `yaml:"security"`
+			}
+			require.NoError(t, node.Decode(&op))
+			operations = append(operations, specOperation{pattern: strings.ToUpper(method) + " " + path, public: len(op.Security) == 0})
+		}
+	}
+
+	return operations
+}
+
+var (
+	testDeprecated = time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC)
+	testSunset     = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// dummyTokens resolves the dummy tokens of the tests, which need no account.
+var dummyTokens = service.NewAuthService(nil, service.DefaultPasswordPolicy())
+
+func newTestConfig(env string) *config.Config {
+	return &config.Config{Env: env, UnversionedDeprecatedAt: testDeprecated, UnversionedSunsetAt: testSunset}
+}
+
+func newTestRouter(env string) *http.ServeMux {
+	return NewRouter(newTestConfig(env), unimplementedServer{}, nil, dummyTokens, nil, nil)
+}
+
+func newRequest(method, target string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	return req.WithContext(logger.ToContext(req.Context(), zap.NewNop()))
+}
+
+func newAuthorizedRequest(t *testing.T, method, target, body string) *http.Request {
+	t.Helper()
+
+	token, err := service.GenerateDummyJWT(string(dto.Moderator))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(method, target, http.NoBody)
+	if body != "" {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return req.WithContext(logger.ToContext(req.Context(), zap.NewNop()))
+}
+
+func TestRouterMatchesSpec(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestRouter(config.EnvTest)
+	operations := specOperations(t)
+	assert.Len(t, v1Routes, len(operations), "every route must be an operation of the spec")
+
+	for _, op := range operations {
+		rt, ok := v1Routes[op.pattern]
+		if !assert.True(t, ok, "%s has no route", op.pattern) {
+			continue
+		}
+		assert.Equal(t, op.public, rt.public, "%s: security of the route and the spec differ", op.pattern)
+
+		method, path, _ := strings.Cut(op.pattern, " ")
+		for _, prefix := range []string{"/v1", ""} {
+			target := prefix + pathParam.ReplaceAllString(path, "1")
+
+			_, pattern := mux.Handler(newRequest(method, target))
+			assert.Equal(t, method+" "+prefix+path, pattern, "%s is not routed under %q", op.pattern, prefix)
+
+			if !op.public {
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, newRequest(method, target))
+				assert.Equal(t, http.StatusUnauthorized, w.Code, "%s must require authentication", op.pattern)
+			}
+		}
+	}
+}
+
+func TestRouterDeprecatesUnversionedRoutes(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestRouter(config.EnvTest)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, newRequest(http.MethodGet, "/house/1"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "@1725408000", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Sat, 01 Mar 2025 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</v1/house/1>; rel="successor-version"`, w.Header().Get("Link"))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, newRequest(http.MethodGet, "/v1/house/1"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+	assert.Empty(t, w.Header().Get("Link"))
+}
+
+func TestRouterWithoutDummyLogin(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestRouter(config.EnvProduction)
+
+	for _, prefix := range []string{"/v1", ""} {
+		_, pattern := mux.Handler(newRequest(http.MethodGet, prefix+"/dummyLogin?user_type=client"))
+		assert.Empty(t, pattern)
+
+		_, pattern = mux.Handler(newRequest(http.MethodPost, prefix+"/login"))
+		assert.Equal(t, "POST "+prefix+"/login", pattern)
+	}
+}
+
+func TestRouterRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestRouter(config.EnvTest)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		// wantFields are the fields named by the errors of a 400 response.
+		wantFields []string
+	}{
+		{name: "malformed path parameter", method: http.MethodGet, target: "/v1/house/abc", wantStatus: http.StatusBadRequest, wantFields: []string{"id"}},
+		{name: "malformed query parameter", method: http.MethodGet, target: "/v1/flats/search?rooms=many", wantStatus: http.StatusBadRequest, wantFields: []string{"rooms"}},
+		{name: "missing If-Match", method: http.MethodPost, target: "/v1/flat/update", body: `{"id": 1, "status": "approved"}`, wantStatus: http.StatusPreconditionRequired},
+		{name: "malformed body", method: http.MethodPost, target: "/v1/house/create", body: `{"address":`, wantStatus: http.StatusBadRequest},
+		{name: "body over the limit", method: http.MethodPost, target: "/v1/house/create", body: `{"address": "` + strings.Repeat("a", defaultMaxBody) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "unknown field", method: http.MethodPost, target: "/v1/flat/create", body: `{"house_id": 1, "price": 100, "rooms": 2, "color": "red"}`, wantStatus: http.StatusBadRequest, wantFields: []string{"color"}},
+		{name: "wrong type of an optional field", method: http.MethodPost, target: "/v1/flat/create", body: `{"house_id": 1, "price": 100, "rooms": 2, "floor": "high"}`, wantStatus: http.StatusBadRequest, wantFields: []string{"floor"}},
+		{name: "every violation is listed", method: http.MethodPost, target: "/v1/flat/create", body: `{"house_id": 1, "price": -1}`, wantStatus: http.StatusBadRequest, wantFields: []string{"price", "rooms"}},
+		{name: "unknown field of an import item", method: http.MethodPost, target: "/v1/house/1/flats/import", body: `[{"number": 1, "price": 100, "rooms": 2, "color": "red"}]`, wantStatus: http.StatusBadRequest, wantFields: []string{"0.color"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, newAuthorizedRequest(t, tt.method, tt.target, tt.body))
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.wantFields != nil {
+				var body dto.ValidationErrorResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+
+				var fields []string
+				for _, detail := range body.Errors {
+					fields = append(fields, detail.Field)
+				}
+				assert.ElementsMatch(t, tt.wantFields, fields)
+			}
+		})
+	}
+}
+
+func TestRouterValidatesResponsesInTests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		env        string
+		wantStatus int
+	}{
+		{env: config.EnvTest, wantStatus: http.StatusInternalServerError},
+		{env: config.EnvProduction, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Parallel()
+
+			mux := NewRouter(newTestConfig(tt.env), invalidFlatServer{}, nil, dummyTokens, nil, nil)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, newAuthorizedRequest(t, http.MethodPost, "/v1/flat/create", `{"house_id": 1, "price": 100, "rooms": 2}`))
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestRouterServesDocs(t *testing.T) {
+	t.Parallel()
+
+	docs, err := handlers.NewDocsHandler(api.Spec, api.DocsPage)
+	require.NoError(t, err)
+	mux := NewRouter(newTestConfig(config.EnvTest), docsServer{DocsHandler: docs}, nil, dummyTokens, nil, nil)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, newRequest(http.MethodGet, target))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		return w
+	}
+
+	w := get("/v1/openapi.yaml")
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Equal(t, api.Spec, w.Body.Bytes())
+
+	w = get("/v1/openapi.json")
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	var patterns []string
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			patterns = append(patterns, strings.ToUpper(method)+" "+path)
+		}
+	}
+	var operations []string
+	for _, op := range specOperations(t) {
+		operations = append(operations, op.pattern)
+	}
+	assert.ElementsMatch(t, operations, patterns)
+
+	w = get("/v1/docs")
+	assert.Equal(t, "text/html", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "openapi.json")
+	assert.Regexp(t, `^default-src 'none'; connect-src 'self'; .*script-src 'sha256-[^']+'; style-src 'sha256-[^']+'$`, w.Header().Get("Content-Security-Policy"))
+}
+
+func TestRouterBrowserHeaders(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTestConfig(config.EnvTest)
+	cfg.CORSAllowedOrigins = []string{"https://app.example.com"}
+	cfg.CORSAllowedMethods = []string{"GET", "POST"}
+	cfg.CORSAllowedHeaders = []string{"Authorization", "Content-Type"}
+	cfg.HSTSMaxAge = 24 * time.Hour
+	cfg.FrameOptions = "DENY"
+	cfg.ContentSecurityPolicy = "default-src 'none'"
+	mux := NewRouter(cfg, unimplementedServer{}, nil, dummyTokens, nil, nil)
+
+	request := func(method, target string) *httptest.ResponseRecorder {
+		req := newRequest(method, target)
+		req.Header.Set("Origin", "https://app.example.com")
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+		}
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, target := range []string{"/v1/flat/create", "/flat/create", "/v1/house/1"} {
+		w := request(http.MethodOptions, target)
+		assert.Equal(t, http.StatusNoContent, w.Code, "preflight of %s", target)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+	}
+	assert.NotEmpty(t, request(http.MethodOptions, "/flat/create").Header().Get("Deprecation"))
+
+	w := request(http.MethodPost, "/v1/flat/create")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "max-age=86400", w.Header().Get("Strict-Transport-Security"))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+}
+
+func TestRouterRateLimitsByIPBeforeAuth(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTestConfig(config.EnvTest)
+	cfg.RateLimitPerIP = ratelimit.Limit{Requests: 2, Period: time.Minute}
+	cfg.TrustedProxyPrefixes = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	mux := NewRouter(cfg, unimplementedServer{}, nil, dummyTokens, nil, ratelimit.NewMemory())
+
+	request := func(forwardedFor string) int {
+		req := newRequest(http.MethodGet, "/v1/house/1")
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.Header.Set("Authorization", "Bearer guessed")
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request("203.0.113.1"))
+	assert.Equal(t, http.StatusUnauthorized, request("203.0.113.1"))
+	assert.Equal(t, http.StatusTooManyRequests, request("203.0.113.1"))
+	assert.Equal(t, http.StatusUnauthorized, request("203.0.113.2"))
+}
+
+func TestRouterRejectsUnknownRateLimitedRoute(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTestConfig(config.EnvTest)
+	cfg.RateLimits.Routes = map[string]ratelimit.Limit{"POST /flats/create": {Requests: 1, Period: time.Minute}}
+
+	assert.Panics(t, func() { NewRouter(cfg, unimplementedServer{}, nil, dummyTokens, nil, nil) })
+}
@@ -0,0 +1,237 @@
+//go:generate mockgen -source ./apikeys.go -destination=./mocks/apikeys.go -package=mocks
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+const (
+	apiKeyPrefix        = "hsk_"
+	apiKeyPrefixBytes   = 4
+	apiKeySecretBytes   = 32
+	apiKeyMaxNameLen    = 255
+	apiKeyScopesField   = "scopes"
+	apiKeyNameField     = "name"
+	ruleUnknownScope    = "unknown_scope"
+	ruleScopeNotGranted = "scope_not_granted"
+)
+
+var (
+	ErrInvalidAPIKey   = errors.New("invalid api key")
+	ErrInvalidAPIKeyID = errors.New("invalid api key id")
+)
+
+type APIKeyRepo interface {
+	CreateAPIKey(ctx context.Context, key *repository.APIKey) (*repository.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]*repository.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id, userID string) error
+	ResolveAPIKey(ctx context.Context, hash string) (*repository.ResolvedAPIKey, error)
+}
+
+type APIKeyService struct {
+	apiKeyRepo APIKeyRepo
+}
+
+func NewAPIKeyService(apiKeyRepo APIKeyRepo) *APIKeyService {
+	return &APIKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+// Create issues a new key for the caller. The plain key is returned only here, the service keeps its hash.
+func (s *APIKeyService) Create(ctx context.Context, req dto.PostApiKeysJSONRequestBody) (*dto.APIKeyCreated, error) {
+	claims, err := s.authorizeOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateAPIKeyRequest(req, claims); err != nil {
+		return nil, err
+	}
+
+	prefix, err := randomString(apiKeyPrefixBytes, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomString(apiKeySecretBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	plainKey := apiKeyPrefix + prefix + "_" + secret
+	key, err := s.apiKeyRepo.CreateAPIKey(ctx, &repository.APIKey{
+		UserID: claims.UserID,
+		Name:   req.Name,
+		Prefix: apiKeyPrefix + prefix,
+		Hash:   hashAPIKey(plainKey),
+		Scopes: req.Scopes,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "create api key")
+	}
+
+	created, err := toDtoAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeyCreated{
+		Id:        created.Id,
+		Name:      created.Name,
+		Prefix:    created.Prefix,
+		Scopes:    created.Scopes,
+		CreatedAt: created.CreatedAt,
+		Key:       plainKey,
+	}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context) ([]dto.APIKey, error) {
+	claims, err := s.authorizeOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.ListAPIKeys(ctx, claims.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list api keys")
+	}
+
+	result := make([]dto.APIKey, 0, len(keys))
+	for _, key := range keys {
+		k, err := toDtoAPIKey(key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *k)
+	}
+
+	return result, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, id string) error {
+	claims, err := s.authorizeOwner(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = uuid.Parse(id); err != nil {
+		return ErrInvalidAPIKeyID
+	}
+
+	return s.apiKeyRepo.RevokeAPIKey(ctx, id, claims.UserID)
+}
+
+// Resolve turns a plain key into the claims of its owner, using the owner's current role.
+func (s *APIKeyService) Resolve(ctx context.Context, plainKey string) (*Claims, error) {
+	if !strings.HasPrefix(plainKey, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.ResolveAPIKey(ctx, hashAPIKey(plainKey))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, errors.Wrap(err, "resolve api key")
+	}
+
+	if key.UserDisabled {
+		return nil, ErrUserDisabled
+	}
+
+	claims := &Claims{
+		UserID:   key.UserID,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}
+	claims.Subject = key.UserType
+
+	return claims, nil
+}
+
+// authorizeOwner checks that the caller is a real account allowed to manage its keys.
+func (s *APIKeyService) authorizeOwner(ctx context.Context) (*Claims, error) {
+	if err := Authorize(ctx, policy.APIKeyManage); err != nil {
+		return nil, err
+	}
+
+	claims, _ := ClaimsFromContext(ctx)
+	if claims.Dummy || claims.UserID == "" {
+		return nil, errors.Wrap(ErrForbidden, "api keys require a registered account")
+	}
+
+	return claims, nil
+}
+
+// validateAPIKeyRequest checks that the new key grants no more than the caller has: the permissions of its
+// role and, when the caller is itself a key, that key's scopes.
+func validateAPIKeyRequest(req dto.PostApiKeysJSONRequestBody, claims *Claims) error {
+	var details []dto.ErrorDetail
+	role := dto.UserType(claims.Subject)
+
+	if strings.TrimSpace(req.Name) == "" || len(req.Name) > apiKeyMaxNameLen {
+		details = append(details, dto.ErrorDetail{Field: apiKeyNameField, Rule: RuleRequired, Message: fmt.Sprintf("name must be 1 to %d characters long", apiKeyMaxNameLen)})
+	}
+
+	if len(req.Scopes) == 0 {
+		details = append(details, dto.ErrorDetail{Field: apiKeyScopesField, Rule: RuleRequired, Message: "at least one scope is required"})
+	}
+
+	for _, scope := range req.Scopes {
+		switch {
+		case !policy.IsAction(scope):
+			details = append(details, dto.ErrorDetail{Field: apiKeyScopesField, Rule: ruleUnknownScope, Message: fmt.Sprintf("unknown scope %q", scope)})
+		case !policy.Allowed(role, policy.Action(scope)):
+			details = append(details, dto.ErrorDetail{Field: apiKeyScopesField, Rule: ruleScopeNotGranted, Message: fmt.Sprintf("scope %q exceeds the permissions of %s", scope, role)})
+		case claims.APIKeyID != "" && !slices.Contains(claims.Scopes, scope):
+			details = append(details, dto.ErrorDetail{Field: apiKeyScopesField, Rule: ruleScopeNotGranted, Message: fmt.Sprintf("scope %q exceeds the scopes of the calling api key", scope)})
+		}
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
+
+func toDtoAPIKey(key *repository.APIKey) (*dto.APIKey, error) {
+	id, err := uuid.Parse(key.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse api key id")
+	}
+
+	return &dto.APIKey{
+		Id:         id,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generate random bytes")
+	}
+
+	return encode(b), nil
+}
@@ -0,0 +1,199 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testAPIKeyID = "0b6f1e8c-4c7e-4d3a-9f1e-3a1c2b5d7e90"
+
+func TestAPIKeyService_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       dto.PostApiKeysJSONRequestBody
+		mockSetup func(m *mocks.MockAPIKeyRepo)
+		wantErr   bool
+	}{
+		{
+			name: "successful creation",
+			ctx:  ctxWithRole(dto.Client),
+			req:  dto.PostApiKeysJSONRequestBody{Name: "upload", Scopes: []string{string(policy.FlatCreate)}},
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {
+				m.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *repository.APIKey) (*repository.APIKey, error) {
+					key.ID = testAPIKeyID
+					key.CreatedAt = time.Now()
+					return key, nil
+				}).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "unknown scope",
+			ctx:       ctxWithRole(dto.Client),
+			req:       dto.PostApiKeysJSONRequestBody{Name: "upload", Scopes: []string{"house:delete"}},
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "scope exceeds role",
+			ctx:       ctxWithRole(dto.Client),
+			req:       dto.PostApiKeysJSONRequestBody{Name: "upload", Scopes: []string{string(policy.FlatModerate)}},
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "scope exceeds calling key",
+			ctx: service.ContextWithClaims(context.Background(), &service.Claims{
+				UserID:           testUserID,
+				APIKeyID:         testAPIKeyID,
+				Scopes:           []string{string(policy.APIKeyManage)},
+				RegisteredClaims: jwt.RegisteredClaims{Subject: string(dto.Client)},
+			}),
+			req:       dto.PostApiKeysJSONRequestBody{Name: "upload", Scopes: []string{string(policy.APIKeyManage), string(policy.FlatCreate)}},
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "scope within calling key",
+			ctx: service.ContextWithClaims(context.Background(), &service.Claims{
+				UserID:           testUserID,
+				APIKeyID:         testAPIKeyID,
+				Scopes:           []string{string(policy.APIKeyManage), string(policy.FlatCreate)},
+				RegisteredClaims: jwt.RegisteredClaims{Subject: string(dto.Client)},
+			}),
+			req: dto.PostApiKeysJSONRequestBody{Name: "upload", Scopes: []string{string(policy.FlatCreate)}},
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {
+				m.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *repository.APIKey) (*repository.APIKey, error) {
+					key.ID = testAPIKeyID
+					key.CreatedAt = time.Now()
+					return key, nil
+				}).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "dummy user",
+			ctx: service.ContextWithClaims(context.Background(), &service.Claims{
+				UserID:           testUserID,
+				Dummy:            true,
+				RegisteredClaims: jwt.RegisteredClaims{Subject: string(dto.Client)},
+			}),
+			req:       dto.PostApiKeysJSONRequestBody{Name: "upload", Scopes: []string{string(policy.FlatCreate)}},
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeyRepo := mocks.NewMockAPIKeyRepo(ctrl)
+			tt.mockSetup(mockAPIKeyRepo)
+
+			apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo)
+			key, err := apiKeyService.Create(tt.ctx, tt.req)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(key.Key, key.Prefix+"_"))
+				assert.Equal(t, tt.req.Scopes, key.Scopes)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Resolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		mockSetup func(m *mocks.MockAPIKeyRepo)
+		wantErr   bool
+	}{
+		{
+			name: "active key",
+			key:  "hsk_1a2b3c4d_secret",
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {
+				m.EXPECT().ResolveAPIKey(gomock.Any(), gomock.Any()).Return(&repository.ResolvedAPIKey{
+					ID:       testAPIKeyID,
+					UserID:   testUserID,
+					Scopes:   []string{string(policy.FlatCreate)},
+					UserType: string(dto.Moderator),
+				}, nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "revoked or unknown key",
+			key:  "hsk_1a2b3c4d_secret",
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {
+				m.EXPECT().ResolveAPIKey(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAPIKeyNotFound).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "disabled owner",
+			key:  "hsk_1a2b3c4d_secret",
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {
+				m.EXPECT().ResolveAPIKey(gomock.Any(), gomock.Any()).Return(&repository.ResolvedAPIKey{
+					ID:           testAPIKeyID,
+					UserID:       testUserID,
+					UserType:     string(dto.Moderator),
+					UserDisabled: true,
+				}, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "malformed key",
+			key:       "not-a-key",
+			mockSetup: func(m *mocks.MockAPIKeyRepo) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeyRepo := mocks.NewMockAPIKeyRepo(ctrl)
+			tt.mockSetup(mockAPIKeyRepo)
+
+			apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo)
+			claims, err := apiKeyService.Resolve(context.Background(), tt.key)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testUserID, claims.UserID)
+				assert.Equal(t, string(dto.Moderator), claims.Subject)
+				// The key is narrowed down to its scopes even though the role allows more.
+				assert.True(t, claims.Allows(policy.FlatCreate))
+				assert.False(t, claims.Allows(policy.FlatModerate))
+			}
+		})
+	}
+}
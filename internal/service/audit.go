@@ -0,0 +1,130 @@
+//go:generate mockgen -source ./audit.go -destination=./mocks/audit.go -package=mocks
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+type AuditRepo interface {
+	AuditLog(ctx context.Context, filter repository.AuditFilter) ([]*dto.AuditEntry, error)
+}
+
+type AuditService struct {
+	auditRepo AuditRepo
+}
+
+func NewAuditService(auditRepo AuditRepo) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// Query returns a page of the audit log matching the filters, newest entries first.
+func (s *AuditService) Query(ctx context.Context, params dto.GetAuditParams) (*dto.AuditLog, error) {
+	if err := Authorize(ctx, policy.AuditRead); err != nil {
+		return nil, err
+	}
+
+	if err := validateAuditQuery(params); err != nil {
+		return nil, err
+	}
+
+	filter := repository.AuditFilter{
+		Action:     params.Action,
+		EntityType: params.EntityType,
+		EntityID:   params.EntityId,
+		RequestID:  params.RequestId,
+		From:       params.From,
+		To:         params.To,
+		Limit:      DefaultSearchLimit,
+	}
+	if params.ActorId != nil {
+		actorID := params.ActorId.String()
+		filter.ActorID = &actorID
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	if params.Cursor != nil {
+		after, err := decodeAuditCursor(*params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = &after
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	entries, err := s.auditRepo.AuditLog(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "audit log")
+	}
+
+	page := &dto.AuditLog{Entries: entries}
+	if page.Entries == nil {
+		page.Entries = []*dto.AuditEntry{}
+	}
+
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(entries[limit-1].Id, 10)))
+		page.NextCursor = &cursor
+	}
+
+	return page, nil
+}
+
+func validateAuditQuery(params dto.GetAuditParams) error {
+	var details []dto.ErrorDetail
+
+	if params.Action != nil {
+		switch *params.Action {
+		case dto.AuditHouseCreate, dto.AuditFlatCreate, dto.AuditFlatStatus, dto.AuditFlatDelete, dto.AuditUserCreate, dto.AuditUserRole:
+		default:
+			details = append(details, dto.ErrorDetail{Field: "action", Rule: ruleEnum, Message: fmt.Sprintf("unknown action %q", *params.Action)})
+		}
+	}
+
+	if params.EntityType != nil {
+		switch *params.EntityType {
+		case dto.AuditEntityHouse, dto.AuditEntityFlat, dto.AuditEntityUser:
+		default:
+			details = append(details, dto.ErrorDetail{Field: "entity_type", Rule: ruleEnum, Message: fmt.Sprintf("unknown entity_type %q", *params.EntityType)})
+		}
+	}
+
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		details = append(details, dto.ErrorDetail{Field: "to", Rule: ruleRange, Message: "to must be after from"})
+	}
+
+	if params.Limit != nil && (*params.Limit < 1 || *params.Limit > MaxSearchLimit) {
+		details = append(details, dto.ErrorDetail{Field: "limit", Rule: ruleRange, Message: fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit)})
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
+
+func decodeAuditCursor(s string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
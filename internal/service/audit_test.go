@@ -0,0 +1,182 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/shhesterka04/house-service/internal/audit"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_Query(t *testing.T) {
+	actorID := uuid.MustParse(testUserID)
+	flatStatus := dto.AuditFlatStatus
+	flatEntity := dto.AuditEntityFlat
+	unknownAction := dto.AuditAction("house.delete")
+	from := time.Date(2024, 8, 30, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		role      dto.UserType
+		params    dto.GetAuditParams
+		mockSetup func(m *mocks.MockAuditRepo)
+		wantLen   int
+		wantErr   error
+	}{
+		{
+			name: "moderator filters by actor, action and entity",
+			role: dto.Moderator,
+			params: dto.GetAuditParams{
+				ActorId:    &actorID,
+				Action:     &flatStatus,
+				EntityType: &flatEntity,
+				EntityId:   ptr("1234"),
+				From:       &from,
+				To:         &to,
+			},
+			mockSetup: func(m *mocks.MockAuditRepo) {
+				m.EXPECT().AuditLog(gomock.Any(), repository.AuditFilter{
+					ActorID:    ptr(testUserID),
+					Action:     &flatStatus,
+					EntityType: &flatEntity,
+					EntityID:   ptr("1234"),
+					From:       &from,
+					To:         &to,
+					Limit:      service.DefaultSearchLimit + 1,
+				}).Return([]*dto.AuditEntry{{Id: 2}, {Id: 1}}, nil).Times(1)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "empty log",
+			role: dto.Admin,
+			mockSetup: func(m *mocks.MockAuditRepo) {
+				m.EXPECT().AuditLog(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name:      "client can not read the audit log",
+			role:      dto.Client,
+			mockSetup: func(m *mocks.MockAuditRepo) {},
+			wantErr:   service.ErrForbidden,
+		},
+		{
+			name:      "unknown action",
+			role:      dto.Moderator,
+			params:    dto.GetAuditParams{Action: &unknownAction},
+			mockSetup: func(m *mocks.MockAuditRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+		{
+			name:      "inverted period",
+			role:      dto.Moderator,
+			params:    dto.GetAuditParams{From: &to, To: &from},
+			mockSetup: func(m *mocks.MockAuditRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+		{
+			name:      "invalid cursor",
+			role:      dto.Moderator,
+			params:    dto.GetAuditParams{Cursor: ptr("not-a-cursor")},
+			mockSetup: func(m *mocks.MockAuditRepo) {},
+			wantErr:   service.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuditRepo := mocks.NewMockAuditRepo(ctrl)
+			tt.mockSetup(mockAuditRepo)
+
+			auditService := service.NewAuditService(mockAuditRepo)
+			result, err := auditService.Query(ctxWithRole(tt.role), tt.params)
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+				assert.Len(t, result.Entries, tt.wantLen)
+				assert.NotNil(t, result.Entries)
+				assert.Nil(t, result.NextCursor)
+			case *service.ValidationError:
+				require.ErrorAs(t, err, &want)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuditService_QueryPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := 2
+
+	mockAuditRepo := mocks.NewMockAuditRepo(ctrl)
+	first := mockAuditRepo.EXPECT().AuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, filter repository.AuditFilter) ([]*dto.AuditEntry, error) {
+			assert.Nil(t, filter.After)
+			assert.Equal(t, limit+1, filter.Limit)
+			return []*dto.AuditEntry{{Id: 9}, {Id: 7}, {Id: 4}}, nil
+		}).Times(1)
+	mockAuditRepo.EXPECT().AuditLog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, filter repository.AuditFilter) ([]*dto.AuditEntry, error) {
+			require.NotNil(t, filter.After)
+			assert.Equal(t, int64(7), *filter.After)
+			return []*dto.AuditEntry{{Id: 4}}, nil
+		}).After(first).Times(1)
+
+	auditService := service.NewAuditService(mockAuditRepo)
+	ctx := ctxWithRole(dto.Moderator)
+
+	page, err := auditService.Query(ctx, dto.GetAuditParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	require.NotNil(t, page.NextCursor)
+
+	page, err = auditService.Query(ctx, dto.GetAuditParams{Limit: &limit, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Entries, 1)
+	assert.Nil(t, page.NextCursor)
+}
+
+func TestContextWithClaims_DummyActor(t *testing.T) {
+	token, err := service.GenerateDummyJWT(string(dto.Moderator))
+	require.NoError(t, err)
+	claims, err := service.ParseJWT(token)
+	require.NoError(t, err)
+
+	actor, ok := audit.ActorFromContext(service.ContextWithClaims(context.Background(), claims))
+	require.True(t, ok)
+	assert.True(t, actor.Dummy)
+	assert.Equal(t, claims.UserID, actor.UserID)
+}
+
+func TestContextWithClaims_Actor(t *testing.T) {
+	ctx := service.ContextWithClaims(context.Background(), &service.Claims{
+		UserID:           testUserID,
+		APIKeyID:         "0b7c4d2e-8a1f-4c3b-9e5d-6f7a8b9c0d1e",
+		RegisteredClaims: jwt.RegisteredClaims{Subject: string(dto.Moderator)},
+	})
+
+	actor, ok := audit.ActorFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, audit.Actor{UserID: testUserID, Role: string(dto.Moderator), APIKeyID: "0b7c4d2e-8a1f-4c3b-9e5d-6f7a8b9c0d1e"}, actor)
+}
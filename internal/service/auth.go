@@ -16,6 +16,7 @@ var (
 
 	ErrorInvalidLogin = errors.New("invalid login")
 	ErrInValidEmail   = errors.New("invalid email")
+	ErrUserDisabled   = errors.New("user is disabled")
 
 	re = regexp.MustCompile(emailRegex)
 )
@@ -25,16 +26,20 @@ const (
 )
 
 type UserRepo interface {
-	CreateUser(ctx context.Context, user repository.User) error
-	GetUser(ctx context.Context, email string) (repository.User, error)
+	CreateUser(ctx context.Context, user repository.User) (string, error)
+	GetUserByID(ctx context.Context, id string) (repository.User, error)
 }
 
 type AuthService struct {
-	userRepo UserRepo
+	userRepo       UserRepo
+	passwordPolicy PasswordPolicy
 }
 
-func NewAuthService(userRepo UserRepo) *AuthService {
-	return &AuthService{userRepo: userRepo}
+func NewAuthService(userRepo UserRepo, passwordPolicy PasswordPolicy) *AuthService {
+	return &AuthService{
+		userRepo:       userRepo,
+		passwordPolicy: passwordPolicy,
+	}
 }
 
 func hashPassword(password string) (string, error) {
@@ -51,35 +56,50 @@ func isValidEmail(email string) bool {
 	return re.MatchString(email)
 }
 
-func (s *AuthService) Register(ctx context.Context, req dto.PostRegisterJSONRequestBody) error {
-	if *req.UserType != dto.Client && *req.UserType != dto.Moderator {
-		return ErrInvalidUserType
+// Register creates a client account and returns its ID. Moderators and admins are never self-registered:
+// moderators are promoted by an admin and the first admin is created from the command line.
+func (s *AuthService) Register(ctx context.Context, req dto.PostRegisterJSONRequestBody) (string, error) {
+	if req.UserType != nil && *req.UserType != dto.Client {
+		return "", ErrInvalidUserType
 	}
 
-	if req.Email == nil || *req.Email == "" || !isValidEmail(string(*req.Email)) {
-		return ErrInValidEmail
+	var email, password string
+	if req.Email != nil {
+		email = string(*req.Email)
 	}
+	if req.Password != nil {
+		password = *req.Password
+	}
+
+	return s.createUser(ctx, email, password, dto.Client)
+}
+
+// BootstrapAdmin creates an admin account and returns its ID. It is only reachable from the command line.
+func (s *AuthService) BootstrapAdmin(ctx context.Context, email, password string) (string, error) {
+	return s.createUser(ctx, email, password, dto.Admin)
+}
 
-	if req.Password == nil || *req.Password == "" {
-		return ErrorInvalidLogin
+func (s *AuthService) createUser(ctx context.Context, email, password string, userType dto.UserType) (string, error) {
+	if email == "" || !isValidEmail(email) {
+		return "", ErrInValidEmail
 	}
 
-	hashedPassword, err := hashPassword(*req.Password)
+	if err := s.passwordPolicy.Validate(password, email); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := hashPassword(password)
 	if err != nil {
-		return ErrorInvalidLogin
+		return "", ErrorInvalidLogin
 	}
 
 	user := repository.User{
-		Email:    string(*req.Email),
+		Email:    email,
 		Password: hashedPassword,
-		Type:     string(*req.UserType),
+		Type:     string(userType),
 	}
 
-	if err = s.userRepo.CreateUser(ctx, user); err != nil {
-		return err
-	}
-
-	return nil
+	return s.userRepo.CreateUser(ctx, user)
 }
 
 func (s *AuthService) DummyLogin(ctx context.Context, req dto.GetDummyLoginParams) (string, error) {
@@ -93,7 +113,7 @@ func (s *AuthService) DummyLogin(ctx context.Context, req dto.GetDummyLoginParam
 		return "", ErrInvalidUserType
 	}
 
-	token, err := GenerateJWT(userType)
+	token, err := GenerateDummyJWT(userType)
 	if err != nil {
 		return "", err
 	}
@@ -101,17 +121,61 @@ func (s *AuthService) DummyLogin(ctx context.Context, req dto.GetDummyLoginParam
 	return token, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, req dto.LoginRequest) (string, error) {
-	user, err := s.userRepo.GetUser(ctx, req.Email)
+// ResolveToken turns a bearer token into the claims of its user. The account is looked up on every call, so
+// that a disabled account is refused and a changed role applies at once rather than when the token expires.
+// Dummy tokens have no account and are taken as issued.
+func (s *AuthService) ResolveToken(ctx context.Context, token string) (*Claims, error) {
+	claims, err := ParseJWT(token)
 	if err != nil {
+		return nil, err
+	}
+
+	if claims.Dummy {
+		return claims, nil
+	}
+	if claims.UserID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, errors.Wrap(err, "resolve token")
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+	claims.Subject = user.Type
+
+	return claims, nil
+}
+
+// Login issues a token for the user with the ID and password of req. An unknown user gives
+// repository.ErrUserNotFound, a wrong password ErrorInvalidLogin.
+func (s *AuthService) Login(ctx context.Context, req dto.PostLoginJSONRequestBody) (string, error) {
+	if req.Id == nil || req.Password == nil {
 		return "", ErrorInvalidLogin
 	}
 
-	if !checkPasswordHash(req.Password, user.Password) {
+	// An unknown user is answered as a wrong password, so that logins do not tell which accounts exist.
+	user, err := s.userRepo.GetUserByID(ctx, req.Id.String())
+	if errors.Is(err, repository.ErrUserNotFound) {
 		return "", ErrorInvalidLogin
+	} else if err != nil {
+		return "", err
+	}
+
+	if !checkPasswordHash(*req.Password, user.Password) {
+		return "", ErrorInvalidLogin
+	}
+
+	if user.Disabled {
+		return "", ErrUserDisabled
 	}
 
-	token, err := GenerateJWT(user.Type)
+	token, err := GenerateJWT(user.UUID, user.Type)
 	if err != nil {
 		return "", err
 	}
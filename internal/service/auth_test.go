@@ -8,6 +8,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/shhesterka04/house-service/internal/dto"
 	"github.com/shhesterka04/house-service/internal/repository"
 	"github.com/shhesterka04/house-service/internal/service"
@@ -29,7 +31,7 @@ func TestAuthService_Register(t *testing.T) {
 			name: "successful registration",
 			req: dto.PostRegisterJSONRequestBody{
 				Email:    (*dto.Email)(ptr("test@example.com")),
-				Password: ptr("password"),
+				Password: ptr("Str0ngHouse!"),
 				UserType: ptr(dto.Client),
 			},
 			mockSetup: func(m *mocks.MockUserRepo) {
@@ -37,7 +39,7 @@ func TestAuthService_Register(t *testing.T) {
 					Email:    "test@example.com",
 					Password: "",
 					Type:     "client",
-				})).Return(nil).Times(1)
+				})).Return(testUserID, nil).Times(1)
 			},
 			wantErr: false,
 		},
@@ -45,7 +47,7 @@ func TestAuthService_Register(t *testing.T) {
 			name: "invalid user type",
 			req: dto.PostRegisterJSONRequestBody{
 				Email:    (*dto.Email)(ptr("test@example.com")),
-				Password: ptr("password"),
+				Password: ptr("Str0ngHouse!"),
 				UserType: (*dto.UserType)(ptr("invalid")),
 			},
 			mockSetup: func(m *mocks.MockUserRepo) {},
@@ -55,14 +57,44 @@ func TestAuthService_Register(t *testing.T) {
 			name: "error creating user",
 			req: dto.PostRegisterJSONRequestBody{
 				Email:    (*dto.Email)(ptr("test@example.com")),
-				Password: ptr("password"),
+				Password: ptr("Str0ngHouse!"),
 				UserType: ptr(dto.Client),
 			},
 			mockSetup: func(m *mocks.MockUserRepo) {
-				m.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(errors.New("create user error")).Times(1)
+				m.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return("", errors.New("create user error")).Times(1)
 			},
 			wantErr: true,
 		},
+		{
+			name: "moderator self-registration",
+			req: dto.PostRegisterJSONRequestBody{
+				Email:    (*dto.Email)(ptr("test@example.com")),
+				Password: ptr("Str0ngHouse!"),
+				UserType: ptr(dto.Moderator),
+			},
+			mockSetup: func(m *mocks.MockUserRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "weak password",
+			req: dto.PostRegisterJSONRequestBody{
+				Email:    (*dto.Email)(ptr("test@example.com")),
+				Password: ptr("1"),
+				UserType: ptr(dto.Client),
+			},
+			mockSetup: func(m *mocks.MockUserRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "breached password",
+			req: dto.PostRegisterJSONRequestBody{
+				Email:    (*dto.Email)(ptr("test@example.com")),
+				Password: ptr("Password123"),
+				UserType: ptr(dto.Client),
+			},
+			mockSetup: func(m *mocks.MockUserRepo) {},
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -74,18 +106,39 @@ func TestAuthService_Register(t *testing.T) {
 			mockUserRepo := mocks.NewMockUserRepo(ctrl)
 			tt.mockSetup(mockUserRepo)
 
-			authService := service.NewAuthService(mockUserRepo)
-			err := authService.Register(context.Background(), tt.req)
+			authService := service.NewAuthService(mockUserRepo, service.DefaultPasswordPolicy())
+			id, err := authService.Register(context.Background(), tt.req)
 
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+				assert.Equal(t, testUserID, id)
 			}
 		})
 	}
 }
 
+func TestAuthService_BootstrapAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepo(ctrl)
+	mockUserRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user repository.User) (string, error) {
+		assert.Equal(t, "admin@example.com", user.Email)
+		assert.Equal(t, string(dto.Admin), user.Type)
+		return testUserID, nil
+	}).Times(1)
+
+	authService := service.NewAuthService(mockUserRepo, service.DefaultPasswordPolicy())
+	id, err := authService.BootstrapAdmin(context.Background(), "admin@example.com", "Str0ngHouse!")
+	require.NoError(t, err)
+	assert.Equal(t, testUserID, id)
+
+	_, err = authService.BootstrapAdmin(context.Background(), "admin@example.com", "weak")
+	require.Error(t, err)
+}
+
 func TestAuthService_DummyLogin(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -122,54 +175,99 @@ func TestAuthService_DummyLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			authService := service.NewAuthService(nil)
-			_, err := authService.DummyLogin(context.Background(), tt.req)
+			authService := service.NewAuthService(nil, service.DefaultPasswordPolicy())
+			token, err := authService.DummyLogin(context.Background(), tt.req)
 
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
+
+				claims, err := service.ParseJWT(token)
+				require.NoError(t, err)
+				assert.True(t, claims.Dummy)
+				assert.NotEmpty(t, claims.UserID)
+				assert.Equal(t, string(tt.req.UserType), claims.Subject)
 			}
 		})
 	}
 }
 
 func TestAuthService_Login(t *testing.T) {
+	userID := uuid.MustParse(testUserID)
+
 	tests := []struct {
 		name      string
-		req       dto.LoginRequest
+		req       dto.PostLoginJSONRequestBody
 		mockSetup func(m *mocks.MockUserRepo)
-		wantToken string
-		wantErr   bool
+		wantErr   error
 	}{
-
 		{
-			name: "invalid email",
-			req: dto.LoginRequest{
-				Email:    "invalid@example.com",
-				Password: "password",
+			name: "successful login",
+			req: dto.PostLoginJSONRequestBody{
+				Id:       &userID,
+				Password: ptr("password"),
 			},
 			mockSetup: func(m *mocks.MockUserRepo) {
-				m.EXPECT().GetUser(gomock.Any(), "invalid@example.com").Return(repository.User{}, errors.New("invalid email")).Times(1)
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{
+					UUID:     testUserID,
+					Email:    "test@example.com",
+					Password: hashPassword("password"),
+					Type:     "client",
+				}, nil).Times(1)
 			},
-			wantToken: "",
-			wantErr:   true,
+		},
+		{
+			name: "missing password",
+			req: dto.PostLoginJSONRequestBody{
+				Id: &userID,
+			},
+			mockSetup: func(m *mocks.MockUserRepo) {},
+			wantErr:   service.ErrorInvalidLogin,
+		},
+		{
+			name: "unknown user",
+			req: dto.PostLoginJSONRequestBody{
+				Id:       &userID,
+				Password: ptr("password"),
+			},
+			mockSetup: func(m *mocks.MockUserRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{}, repository.ErrUserNotFound).Times(1)
+			},
+			wantErr: service.ErrorInvalidLogin,
 		},
 		{
 			name: "invalid password",
-			req: dto.LoginRequest{
-				Email:    "test@example.com",
-				Password: "wrongpassword",
+			req: dto.PostLoginJSONRequestBody{
+				Id:       &userID,
+				Password: ptr("wrongpassword"),
 			},
 			mockSetup: func(m *mocks.MockUserRepo) {
-				m.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(repository.User{
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{
+					UUID:     testUserID,
 					Email:    "test@example.com",
 					Password: hashPassword("password"),
 					Type:     "client",
 				}, nil).Times(1)
 			},
-			wantToken: "",
-			wantErr:   true,
+			wantErr: service.ErrorInvalidLogin,
+		},
+		{
+			name: "disabled user",
+			req: dto.PostLoginJSONRequestBody{
+				Id:       &userID,
+				Password: ptr("password"),
+			},
+			mockSetup: func(m *mocks.MockUserRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{
+					UUID:     testUserID,
+					Email:    "test@example.com",
+					Password: hashPassword("password"),
+					Type:     "moderator",
+					Disabled: true,
+				}, nil).Times(1)
+			},
+			wantErr: service.ErrUserDisabled,
 		},
 	}
 
@@ -182,19 +280,104 @@ func TestAuthService_Login(t *testing.T) {
 			mockUserRepo := mocks.NewMockUserRepo(ctrl)
 			tt.mockSetup(mockUserRepo)
 
-			authService := service.NewAuthService(mockUserRepo)
+			authService := service.NewAuthService(mockUserRepo, service.DefaultPasswordPolicy())
 			token, err := authService.Login(context.Background(), tt.req)
 
-			if tt.wantErr {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, tt.wantToken, token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			claims, err := service.ParseJWT(token)
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, claims.UserID)
+			assert.Equal(t, "client", claims.Subject)
+		})
+	}
+}
+
+func TestAuthService_ResolveToken(t *testing.T) {
+	token, err := service.GenerateJWT(testUserID, "moderator")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		token     string
+		mockSetup func(m *mocks.MockUserRepo)
+		wantRole  string
+		wantErr   error
+	}{
+		{
+			name:  "active user",
+			token: token,
+			mockSetup: func(m *mocks.MockUserRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "moderator"}, nil).Times(1)
+			},
+			wantRole: "moderator",
+		},
+		{
+			name:  "demoted user",
+			token: token,
+			mockSetup: func(m *mocks.MockUserRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "client"}, nil).Times(1)
+			},
+			wantRole: "client",
+		},
+		{
+			name:  "disabled user",
+			token: token,
+			mockSetup: func(m *mocks.MockUserRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "moderator", Disabled: true}, nil).Times(1)
+			},
+			wantErr: service.ErrUserDisabled,
+		},
+		{
+			name:  "deleted user",
+			token: token,
+			mockSetup: func(m *mocks.MockUserRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{}, repository.ErrUserNotFound).Times(1)
+			},
+			wantErr: service.ErrInvalidToken,
+		},
+		{
+			name:      "malformed token",
+			token:     "not-a-token",
+			mockSetup: func(m *mocks.MockUserRepo) {},
+			wantErr:   service.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			tt.mockSetup(mockUserRepo)
+
+			authService := service.NewAuthService(mockUserRepo, service.DefaultPasswordPolicy())
+			claims, err := authService.ResolveToken(context.Background(), tt.token)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, claims.UserID)
+			assert.Equal(t, tt.wantRole, claims.Subject)
 		})
 	}
 }
 
+func ctxWithRole(role dto.UserType) context.Context {
+	return service.ContextWithClaims(context.Background(), &service.Claims{
+		UserID:           testUserID,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: string(role)},
+	})
+}
+
 func ptr[T interface{}](v T) *T {
 	return &v
 }
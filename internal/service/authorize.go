@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+)
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("insufficient permissions")
+)
+
+// Authorize checks the caller stored in ctx by the auth middleware against the policy.
+func Authorize(ctx context.Context, action policy.Action) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	if !claims.Allows(action) {
+		return errors.Wrapf(ErrForbidden, "%s is not allowed to %s", claims.Subject, action)
+	}
+
+	return nil
+}
+
+// callerRole returns the role of the caller stored in ctx.
+func callerRole(ctx context.Context) (dto.UserType, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return "", ErrUnauthorized
+	}
+
+	return dto.UserType(claims.Subject), nil
+}
+
+// callerAccountID returns the ID of the caller's account, nil for dummy logins that have none.
+func callerAccountID(ctx context.Context) *string {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.Dummy || claims.UserID == "" {
+		return nil
+	}
+
+	id := claims.UserID
+	return &id
+}
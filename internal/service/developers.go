@@ -0,0 +1,117 @@
+//go:generate mockgen -source ./developers.go -destination=./mocks/developers.go -package=mocks
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+var ErrInvalidDeveloperID = errors.New("invalid developer ID")
+
+const (
+	ruleUnknownUser = "unknown_user"
+	ruleUserRole    = "user_role"
+)
+
+type DeveloperRepo interface {
+	CreateDeveloper(ctx context.Context, developer *dto.DeveloperProfile) (*dto.DeveloperProfile, error)
+	ListDevelopers(ctx context.Context) ([]*dto.DeveloperProfile, error)
+	GetHousesByDeveloperID(ctx context.Context, developerID int) ([]*dto.House, error)
+}
+
+type DeveloperService struct {
+	developerRepo DeveloperRepo
+}
+
+func NewDeveloperService(developerRepo DeveloperRepo) *DeveloperService {
+	return &DeveloperService{developerRepo: developerRepo}
+}
+
+func (s *DeveloperService) CreateDeveloper(ctx context.Context, req dto.PostDevelopersJSONRequestBody) (*dto.DeveloperProfile, error) {
+	if err := Authorize(ctx, policy.DeveloperManage); err != nil {
+		return nil, err
+	}
+
+	if err := validateDeveloperRequest(req); err != nil {
+		return nil, err
+	}
+
+	developer := &dto.DeveloperProfile{
+		Name:        strings.TrimSpace(req.Name),
+		Website:     req.Website,
+		Phone:       req.Phone,
+		Description: req.Description,
+		UserId:      req.UserId,
+	}
+	if req.Email != nil {
+		email := string(*req.Email)
+		developer.Email = &email
+	}
+
+	developer, err := s.developerRepo.CreateDeveloper(ctx, developer)
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: "user_id", Rule: ruleUnknownUser, Message: "user does not exist"}}}
+	case errors.Is(err, repository.ErrDeveloperUserRole):
+		return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: "user_id", Rule: ruleUserRole, Message: "only a client or developer account can be linked to a developer"}}}
+	case err != nil:
+		return nil, errors.Wrap(err, "create developer")
+	}
+
+	return developer, nil
+}
+
+func (s *DeveloperService) ListDevelopers(ctx context.Context) ([]*dto.DeveloperProfile, error) {
+	if err := Authorize(ctx, policy.DeveloperRead); err != nil {
+		return nil, err
+	}
+
+	developers, err := s.developerRepo.ListDevelopers(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list developers")
+	}
+
+	return developers, nil
+}
+
+func (s *DeveloperService) GetDeveloperHouses(ctx context.Context, developerIDStr string) ([]*dto.House, error) {
+	if err := Authorize(ctx, policy.DeveloperRead); err != nil {
+		return nil, err
+	}
+
+	developerID, err := strconv.Atoi(developerIDStr)
+	if err != nil || developerID <= 0 {
+		return nil, ErrInvalidDeveloperID
+	}
+
+	houses, err := s.developerRepo.GetHousesByDeveloperID(ctx, developerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get developer houses")
+	}
+
+	return houses, nil
+}
+
+func validateDeveloperRequest(req dto.PostDevelopersJSONRequestBody) error {
+	var details []dto.ErrorDetail
+
+	if name := strings.TrimSpace(req.Name); name == "" || len(name) > 255 {
+		details = append(details, dto.ErrorDetail{Field: "name", Rule: RuleRequired, Message: "name must be 1 to 255 characters long"})
+	}
+
+	if req.Email != nil && !isValidEmail(string(*req.Email)) {
+		details = append(details, dto.ErrorDetail{Field: "email", Rule: "email", Message: "email is invalid"})
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeveloperService_CreateDeveloper(t *testing.T) {
+	badEmail := dto.Email("not-an-email")
+	userID := uuid.MustParse(testUserID)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       dto.PostDevelopersJSONRequestBody
+		mockSetup func(m *mocks.MockDeveloperRepo)
+		wantName  string
+		wantRule  string
+		wantErr   error
+	}{
+		{
+			name: "successful creation",
+			req:  dto.PostDevelopersJSONRequestBody{Name: "  Developer Inc. "},
+			mockSetup: func(m *mocks.MockDeveloperRepo) {
+				m.EXPECT().CreateDeveloper(gomock.Any(), &dto.DeveloperProfile{Name: "Developer Inc."}).
+					Return(&dto.DeveloperProfile{Id: 1, Name: "Developer Inc."}, nil).Times(1)
+			},
+			wantName: "Developer Inc.",
+		},
+		{
+			name:      "client can not create developer",
+			ctx:       ctxWithRole(dto.Client),
+			req:       dto.PostDevelopersJSONRequestBody{Name: "Developer Inc."},
+			mockSetup: func(m *mocks.MockDeveloperRepo) {},
+			wantErr:   service.ErrForbidden,
+		},
+		{
+			name:      "empty name and invalid email",
+			req:       dto.PostDevelopersJSONRequestBody{Name: " ", Email: &badEmail},
+			mockSetup: func(m *mocks.MockDeveloperRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+		{
+			name: "unknown user",
+			req:  dto.PostDevelopersJSONRequestBody{Name: "Developer Inc.", UserId: &userID},
+			mockSetup: func(m *mocks.MockDeveloperRepo) {
+				m.EXPECT().CreateDeveloper(gomock.Any(), gomock.Any()).Return(nil, repository.ErrUserNotFound).Times(1)
+			},
+			wantRule: "unknown_user",
+		},
+		{
+			name: "moderator account can not be linked",
+			req:  dto.PostDevelopersJSONRequestBody{Name: "Developer Inc.", UserId: &userID},
+			mockSetup: func(m *mocks.MockDeveloperRepo) {
+				m.EXPECT().CreateDeveloper(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDeveloperUserRole).Times(1)
+			},
+			wantRule: "user_role",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDeveloperRepo := mocks.NewMockDeveloperRepo(ctrl)
+			tt.mockSetup(mockDeveloperRepo)
+
+			if tt.ctx == nil {
+				tt.ctx = ctxWithRole(dto.Moderator)
+			}
+
+			developerService := service.NewDeveloperService(mockDeveloperRepo)
+			developer, err := developerService.CreateDeveloper(tt.ctx, tt.req)
+
+			if tt.wantRule != "" {
+				var validationErr *service.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "user_id", validationErr.Details[0].Field)
+				assert.Equal(t, tt.wantRule, validationErr.Details[0].Rule)
+				return
+			}
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantName, developer.Name)
+			case *service.ValidationError:
+				require.ErrorAs(t, err, &want)
+				assert.Len(t, want.Details, 2)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeveloperService_GetDeveloperHouses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	developerID := 7
+	mockDeveloperRepo := mocks.NewMockDeveloperRepo(ctrl)
+	mockDeveloperRepo.EXPECT().GetHousesByDeveloperID(gomock.Any(), developerID).
+		Return([]*dto.House{{Id: 1, DeveloperId: &developerID}}, nil).Times(1)
+
+	developerService := service.NewDeveloperService(mockDeveloperRepo)
+
+	houses, err := developerService.GetDeveloperHouses(ctxWithRole(dto.Client), "7")
+	require.NoError(t, err)
+	assert.Len(t, houses, 1)
+
+	_, err = developerService.GetDeveloperHouses(ctxWithRole(dto.Client), "abc")
+	require.ErrorIs(t, err, service.ErrInvalidDeveloperID)
+}
@@ -3,23 +3,47 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/pkg/errors"
 	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+const (
+	RulePositive  = "positive"
+	RuleUnique    = "unique"
+	ruleEnum      = "enum"
+	ruleForbidden = "forbidden"
+
+	// maxFlatArea is the bound of the NUMERIC(7, 2) area columns.
+	maxFlatArea       = 100000
+	minCeilingHeight  = 2
+	maxCeilingHeight  = 6
+	maxFlatSectionLen = 32
+
+	maxModerationCommentLen = 1000
 )
 
 type FlatRepo interface {
 	CreateFlat(ctx context.Context, flat *dto.DtoFlat) (*dto.DtoFlat, error)
 	UpdateFlat(ctx context.Context, flat *dto.DtoFlat) (*dto.DtoFlat, error)
-	GetFlatByHouseID(ctx context.Context, houseID int, userType string) ([]*dto.DtoFlat, error)
+	ModerateFlat(ctx context.Context, flat *dto.DtoFlat, decision *repository.ModerationDecision) (*dto.DtoFlat, error)
+	DeleteFlat(ctx context.Context, id int, allow func(*dto.DtoFlat) error) error
+	GetFlatByHouseID(ctx context.Context, houseID int, statuses []dto.Status) ([]*dto.DtoFlat, error)
 	GetFlatByID(ctx context.Context, id int) (*dto.DtoFlat, error)
+	ImportFlats(ctx context.Context, flats []*dto.DtoFlat, atomic bool) ([]error, error)
+	StreamFlats(ctx context.Context, houseID int, statuses []dto.Status, fn func(*dto.DtoFlat) error) error
+	SearchFlats(ctx context.Context, search repository.FlatSearch) ([]*dto.DtoFlat, error)
 }
 
 type HouseFlatRepo interface {
-	UpdateHouse(ctx context.Context, id int, updAt time.Time) (*dto.House, error)
+	GetHouse(ctx context.Context, id int) (*dto.House, error)
+	IsHouseDeveloper(ctx context.Context, houseID int, userID string) (bool, error)
 }
 
 type FlatService struct {
@@ -35,31 +59,49 @@ func NewFlatService(flatRepo FlatRepo, houseFlatRepo HouseFlatRepo) *FlatService
 }
 
 func (s *FlatService) CreateFlat(ctx context.Context, req dto.CreateFlatRequest) (*dto.DtoFlat, error) {
+	if err := Authorize(ctx, policy.FlatCreate); err != nil {
+		return nil, err
+	}
+
 	flat := &dto.DtoFlat{
-		HouseID: req.HouseID,
-		Number:  req.Number,
-		Rooms:   req.Rooms,
-		Price:   req.Price,
-		Status:  string(dto.Created),
+		HouseID:        req.HouseID,
+		Number:         req.Number,
+		Rooms:          req.Rooms,
+		Price:          req.Price,
+		Status:         string(dto.Created),
+		FlatAttributes: req.FlatAttributes,
+		CreatedBy:      callerAccountID(ctx),
 	}
 
-	if !validateFlatRequest(*flat) {
-		return nil, errors.New("invalid request")
+	if details := flatViolations(*flat); len(details) > 0 {
+		return nil, &ValidationError{Details: details}
 	}
 
-	createdFlat, err := s.flatRepo.CreateFlat(ctx, flat)
-	if err != nil {
+	if err := s.authorizeHouse(ctx, policy.FlatCreate, req.HouseID); err != nil {
 		return nil, err
 	}
 
-	if _, err = s.houseFlatRepo.UpdateHouse(ctx, req.HouseID, time.Now()); err != nil {
-		return nil, err
+	if flat.Floor != nil {
+		floors, err := s.houseFloors(ctx, req.HouseID)
+		if err != nil {
+			return nil, err
+		}
+		if detail, ok := floorViolation("floor", *flat.Floor, floors); !ok {
+			return nil, &ValidationError{Details: []dto.ErrorDetail{detail}}
+		}
 	}
 
-	return createdFlat, nil
+	return s.flatRepo.CreateFlat(ctx, flat)
 }
 
-func (s *FlatService) UpdateFlat(ctx context.Context, req dto.PostFlatUpdateJSONRequestBody) (*dto.DtoFlat, error) {
+// UpdateFlat sets the status of the flat if it is still at version, otherwise repository.ErrFlatModified
+// is returned. Approvals and declines are recorded as moderation decisions, a decline requires a reason
+// from the catalog.
+func (s *FlatService) UpdateFlat(ctx context.Context, req dto.PostFlatUpdateJSONRequestBody, version int) (*dto.DtoFlat, error) {
+	if err := Authorize(ctx, policy.FlatModerate); err != nil {
+		return nil, err
+	}
+
 	validStatuses := map[dto.Status]struct{}{
 		dto.Created:      {},
 		dto.Approved:     {},
@@ -67,46 +109,145 @@ func (s *FlatService) UpdateFlat(ctx context.Context, req dto.PostFlatUpdateJSON
 		dto.OnModeration: {},
 	}
 
-	if _, ok := validStatuses[*req.Status]; !ok {
+	if req.Status == nil {
+		return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: "status", Rule: RuleRequired, Message: "status is required"}}}
+	}
+	status := *req.Status
+
+	if _, ok := validStatuses[status]; !ok {
 		return nil, errors.New("invalid status")
 	}
 
+	if details := moderationViolations(status, req); len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+
 	flat, err := s.flatRepo.GetFlatByID(ctx, req.Id)
 	if err != nil {
 		return nil, errors.Wrap(err, "get flat")
 	}
 
-	flat.Status = string(*req.Status)
+	flat.Status = string(status)
+	flat.Version = version
 
-	updatedFlat, err := s.flatRepo.UpdateFlat(ctx, flat)
+	var updatedFlat *dto.DtoFlat
+	if isModerationDecision(status) {
+		decision := &repository.ModerationDecision{Status: status, Comment: req.Comment, ModeratorID: callerAccountID(ctx)}
+		if req.Reason != nil {
+			decision.Reason = &dto.DeclineReason{Code: *req.Reason}
+		}
+		updatedFlat, err = s.flatRepo.ModerateFlat(ctx, flat, decision)
+		if errors.Is(err, repository.ErrDeclineReasonNotFound) {
+			return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: "reason", Rule: ruleEnum, Message: fmt.Sprintf("unknown decline reason %q", *req.Reason)}}}
+		}
+	} else {
+		updatedFlat, err = s.flatRepo.UpdateFlat(ctx, flat)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.houseFlatRepo.UpdateHouse(ctx, updatedFlat.HouseID, time.Now())
+	return updatedFlat, nil
+}
+
+// isModerationDecision reports whether setting the status is a moderator's verdict on the flat.
+func isModerationDecision(status dto.Status) bool {
+	return status == dto.Approved || status == dto.Declined
+}
+
+// moderationViolations checks the reason and the comment of a change to status, which the caller has
+// already checked. Only declines carry a reason, and a comment is kept only with a decision.
+func moderationViolations(status dto.Status, req dto.PostFlatUpdateJSONRequestBody) []dto.ErrorDetail {
+	var details []dto.ErrorDetail
+
+	switch {
+	case status == dto.Declined && (req.Reason == nil || *req.Reason == ""):
+		details = append(details, dto.ErrorDetail{Field: "reason", Rule: RuleRequired, Message: "reason is required to decline a flat"})
+	case status != dto.Declined && req.Reason != nil:
+		details = append(details, dto.ErrorDetail{Field: "reason", Rule: ruleForbidden, Message: "reason is accepted only when declining a flat"})
+	}
+
+	if req.Comment != nil {
+		if !isModerationDecision(status) {
+			details = append(details, dto.ErrorDetail{Field: "comment", Rule: ruleForbidden, Message: "comment is accepted only when approving or declining a flat"})
+		} else if len([]rune(*req.Comment)) > maxModerationCommentLen {
+			details = append(details, dto.ErrorDetail{Field: "comment", Rule: RuleMaxLength, Message: fmt.Sprintf("comment must be at most %d characters long", maxModerationCommentLen)})
+		}
+	}
+
+	return details
+}
+
+// DeleteFlat soft-deletes the flat, hiding it from every listing. Moderators delete any flat, the user who
+// created it only while it is not approved. A pending flat of another user does not exist for the caller.
+func (s *FlatService) DeleteFlat(ctx context.Context, flatIDStr string) error {
+	if err := Authorize(ctx, policy.FlatDelete); err != nil {
+		return err
+	}
+
+	flatID, err := strconv.Atoi(flatIDStr)
+	if err != nil || flatID <= 0 {
+		return ErrInvalidFlatID
+	}
+
+	// The flat is checked as it is locked for the deletion, a flat approved in the meantime stays.
+	moderator := Authorize(ctx, policy.FlatModerate) == nil
+	return s.flatRepo.DeleteFlat(ctx, flatID, func(flat *dto.DtoFlat) error {
+		if moderator {
+			return nil
+		}
+
+		userID := callerAccountID(ctx)
+		owner := userID != nil && flat.CreatedBy != nil && *userID == *flat.CreatedBy
+		approved := flat.Status == string(dto.Approved)
+		switch {
+		case owner && approved:
+			return errors.Wrapf(ErrForbidden, "flat %d is approved and can be deleted by a moderator only", flatID)
+		case approved:
+			return errors.Wrapf(ErrForbidden, "flat %d belongs to another user", flatID)
+		case !owner:
+			return repository.ErrFlatNotFound
+		}
+
+		return nil
+	})
+}
+
+// HouseFlatsUpdatedAt returns the time the flats of the house last changed, nil if it is not known.
+func (s *FlatService) HouseFlatsUpdatedAt(ctx context.Context, houseIDStr string) (*time.Time, error) {
+	if err := Authorize(ctx, policy.HouseRead); err != nil {
+		return nil, err
+	}
+
+	houseID, err := strconv.Atoi(houseIDStr)
+	if err != nil {
+		return nil, ErrInvalidHouseID
+	}
+
+	house, err := s.houseFlatRepo.GetHouse(ctx, houseID)
 	if err != nil {
 		return nil, err
 	}
 
-	return updatedFlat, nil
+	return house.UpdateAt, nil
 }
 
-func (s *FlatService) GetFlatsByHouseID(ctx context.Context, houseIDStr, token string) ([]*dto.DtoFlat, error) {
+func (s *FlatService) GetFlatsByHouseID(ctx context.Context, houseIDStr string) ([]*dto.DtoFlat, error) {
+	if err := Authorize(ctx, policy.HouseRead); err != nil {
+		return nil, err
+	}
+
 	houseID, err := strconv.Atoi(houseIDStr)
 	if err != nil {
-		return nil, errors.New("invalid house ID")
+		return nil, ErrInvalidHouseID
 	}
 
-	claims := &jwt.RegisteredClaims{}
-	parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-		return JwtKey, nil
-	})
-	if err != nil || !parsedToken.Valid {
-		return nil, errors.New("invalid token")
+	role, err := callerRole(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	userType := claims.Subject
-	flats, err := s.flatRepo.GetFlatByHouseID(ctx, houseID, userType)
+	flats, err := s.flatRepo.GetFlatByHouseID(ctx, houseID, policy.VisibleFlatStatuses(role))
 	if err != nil {
 		return nil, err
 	}
@@ -114,18 +255,126 @@ func (s *FlatService) GetFlatsByHouseID(ctx context.Context, houseIDStr, token s
 	return flats, nil
 }
 
-func validateFlatRequest(f dto.DtoFlat) bool {
+func (s *FlatService) authorizeHouse(ctx context.Context, action policy.Action, houseID int) error {
+	return authorizeOwnHouse(ctx, s.houseFlatRepo, action, houseID)
+}
+
+// authorizeOwnHouse enforces the ownership part of the policy: roles restricted to their own houses
+// may act only on houses of the developer they are linked to.
+func authorizeOwnHouse(ctx context.Context, houses HouseOwnerRepo, action policy.Action, houseID int) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	if !policy.OwnHousesOnly(dto.UserType(claims.Subject), action) {
+		return nil
+	}
+
+	owned, err := houses.IsHouseDeveloper(ctx, houseID, claims.UserID)
+	if err != nil {
+		return errors.Wrap(err, "check house developer")
+	}
+
+	if !owned {
+		return errors.Wrapf(ErrForbidden, "house %d belongs to another developer", houseID)
+	}
+
+	return nil
+}
+
+// houseFloors returns the floor count of the house, nil if it is not known.
+func (s *FlatService) houseFloors(ctx context.Context, houseID int) (*int, error) {
+	house, err := s.houseFlatRepo.GetHouse(ctx, houseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get house")
+	}
+
+	return house.Floors, nil
+}
+
+// floorViolation checks that the floor exists in a house with the given floor count. Houses without a
+// floor count accept any floor.
+func floorViolation(field string, floor int, floors *int) (dto.ErrorDetail, bool) {
+	if floors == nil || floor <= *floors {
+		return dto.ErrorDetail{}, true
+	}
+
+	return dto.ErrorDetail{Field: field, Rule: ruleRange, Message: fmt.Sprintf("floor must not exceed the %d floors of the house", *floors)}, false
+}
+
+// flatViolations lists the rules broken by the flat, one detail per field.
+func flatViolations(f dto.DtoFlat) []dto.ErrorDetail {
+	var details []dto.ErrorDetail
+
 	if f.Number <= 0 {
-		return false
+		details = append(details, dto.ErrorDetail{Field: "number", Rule: RulePositive, Message: "number must be positive"})
 	}
 
 	if f.Rooms <= 0 {
-		return false
+		details = append(details, dto.ErrorDetail{Field: "rooms", Rule: RulePositive, Message: "rooms must be positive"})
 	}
 
 	if f.Price <= 0 {
+		details = append(details, dto.ErrorDetail{Field: "price", Rule: RulePositive, Message: "price must be positive"})
+	}
+
+	return append(details, flatAttributeViolations(f.FlatAttributes)...)
+}
+
+// flatAttributeViolations checks the optional layout attributes, absent ones are not validated.
+func flatAttributeViolations(a dto.FlatAttributes) []dto.ErrorDetail {
+	var details []dto.ErrorDetail
+	violation := func(field, rule, message string) {
+		details = append(details, dto.ErrorDetail{Field: field, Rule: rule, Message: message})
+	}
+
+	if a.Floor != nil && *a.Floor <= 0 {
+		violation("floor", RulePositive, "floor must be positive")
+	}
+
+	validArea := func(field string, v *float64) bool {
+		switch {
+		case v == nil:
+			return false
+		case math.IsNaN(*v) || *v <= 0:
+			violation(field, RulePositive, field+" must be positive")
+		case *v >= maxFlatArea:
+			violation(field, ruleRange, fmt.Sprintf("%s must be less than %d", field, maxFlatArea))
+		default:
+			return true
+		}
 		return false
 	}
+	totalOK := validArea("total_area", a.TotalArea)
+	livingOK := validArea("living_area", a.LivingArea)
+	if totalOK && livingOK && *a.LivingArea > *a.TotalArea {
+		violation("living_area", ruleRange, "living_area must not exceed total_area")
+	}
+
+	if a.Section != nil && len([]rune(*a.Section)) > maxFlatSectionLen {
+		violation("section", RuleMaxLength, fmt.Sprintf("section must be at most %d characters long", maxFlatSectionLen))
+	}
+
+	if a.CeilingHeight != nil && !(*a.CeilingHeight >= minCeilingHeight && *a.CeilingHeight <= maxCeilingHeight) {
+		violation("ceiling_height", ruleRange, fmt.Sprintf("ceiling_height must be between %d and %d meters", minCeilingHeight, maxCeilingHeight))
+	}
+
+	if a.Balcony != nil {
+		switch *a.Balcony {
+		case dto.NoBalcony, dto.WithBalcony, dto.WithLoggia, dto.WithBalconyAndLoggia:
+		default:
+			violation("balcony", ruleEnum, fmt.Sprintf("unknown balcony %q", *a.Balcony))
+		}
+	}
+
+	if a.Finishing != nil {
+		switch *a.Finishing {
+		case dto.NoFinishing, dto.RoughFinishing, dto.PreFinishing, dto.FullFinishing:
+		default:
+			violation("finishing", ruleEnum, fmt.Sprintf("unknown finishing %q", *a.Finishing))
+		}
+	}
 
-	return true
+	return details
 }
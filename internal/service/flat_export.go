@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+)
+
+var ErrInvalidExportFormat = errors.New("unsupported export format, use csv, tsv or jsonl")
+
+var exportContentTypes = map[dto.ExportFormat]string{
+	dto.ExportCSV:   "text/csv; charset=utf-8",
+	dto.ExportTSV:   "text/tab-separated-values; charset=utf-8",
+	dto.ExportJSONL: "application/x-ndjson",
+}
+
+var flatExportColumns = []string{
+	"id", "house_id", "number", "rooms", "price", "status",
+	"floor", "total_area", "living_area", "section", "ceiling_height", "balcony", "finishing", "price_per_sqm",
+}
+
+// FlatExport is an authorized export whose rows are read from the database only when it is written.
+type FlatExport struct {
+	Format      dto.ExportFormat
+	ContentType string
+	FileName    string
+
+	stream func(ctx context.Context, fn func(*dto.DtoFlat) error) error
+}
+
+// ExportFlats prepares the export of the house flats visible to the caller, as in GetFlatsByHouseID. A house
+// that does not exist gives repository.ErrHouseNotFound, before anything is written.
+func (s *FlatService) ExportFlats(ctx context.Context, houseIDStr string, format dto.ExportFormat) (*FlatExport, error) {
+	if err := Authorize(ctx, policy.HouseRead); err != nil {
+		return nil, err
+	}
+
+	houseID, err := strconv.Atoi(houseIDStr)
+	if err != nil || houseID <= 0 {
+		return nil, ErrInvalidHouseID
+	}
+
+	role, err := callerRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := s.newFlatExport(format, fmt.Sprintf("house-%d-flats", houseID), houseID, policy.VisibleFlatStatuses(role))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.houseFlatRepo.GetHouse(ctx, houseID); err != nil {
+		return nil, errors.Wrap(err, "get house")
+	}
+
+	return export, nil
+}
+
+// ExportAllFlats prepares the export of the flats of every house in any status.
+func (s *FlatService) ExportAllFlats(ctx context.Context, format dto.ExportFormat) (*FlatExport, error) {
+	if err := Authorize(ctx, policy.FlatExportAll); err != nil {
+		return nil, err
+	}
+
+	return s.newFlatExport(format, "flats", 0, []dto.Status{dto.Created, dto.Approved, dto.Declined, dto.OnModeration})
+}
+
+func (s *FlatService) newFlatExport(format dto.ExportFormat, name string, houseID int, statuses []dto.Status) (*FlatExport, error) {
+	if format == "" {
+		format = dto.ExportCSV
+	}
+
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return nil, ErrInvalidExportFormat
+	}
+
+	return &FlatExport{
+		Format:      format,
+		ContentType: contentType,
+		FileName:    name + "." + string(format),
+		stream: func(ctx context.Context, fn func(*dto.DtoFlat) error) error {
+			return s.flatRepo.StreamFlats(ctx, houseID, statuses, fn)
+		},
+	}, nil
+}
+
+// WriteTo streams the flats to w row by row.
+func (e *FlatExport) WriteTo(ctx context.Context, w io.Writer) error {
+	if e.Format == dto.ExportJSONL {
+		enc := json.NewEncoder(w)
+		return e.stream(ctx, func(flat *dto.DtoFlat) error {
+			return enc.Encode(flat)
+		})
+	}
+
+	cw := csv.NewWriter(w)
+	if e.Format == dto.ExportTSV {
+		cw.Comma = '\t'
+	}
+
+	if err := cw.Write(flatExportColumns); err != nil {
+		return errors.Wrap(err, "write header")
+	}
+
+	err := e.stream(ctx, func(flat *dto.DtoFlat) error {
+		return cw.Write([]string{
+			strconv.Itoa(flat.ID),
+			strconv.Itoa(flat.HouseID),
+			strconv.Itoa(flat.Number),
+			strconv.Itoa(flat.Rooms),
+			strconv.Itoa(flat.Price),
+			flat.Status,
+			optionalCell(flat.Floor, strconv.Itoa),
+			optionalCell(flat.TotalArea, formatDecimal),
+			optionalCell(flat.LivingArea, formatDecimal),
+			optionalCell(flat.Section, func(s string) string { return s }),
+			optionalCell(flat.CeilingHeight, formatDecimal),
+			optionalCell(flat.Balcony, func(b dto.Balcony) string { return string(b) }),
+			optionalCell(flat.Finishing, func(f dto.Finishing) string { return string(f) }),
+			optionalCell(flat.PricePerSqm, strconv.Itoa),
+		})
+	})
+
+	cw.Flush()
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(cw.Error(), "write export")
+}
+
+// optionalCell formats a nullable column, leaving the cell empty for nil.
+func optionalCell[T any](v *T, format func(T) string) string {
+	if v == nil {
+		return ""
+	}
+
+	return format(*v)
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlatService_ExportFlats(t *testing.T) {
+	flats := []dto.DtoFlat{
+		{ID: 1, HouseID: 7, Number: 1, Rooms: 2, Price: 5000, Status: string(dto.Approved)},
+		{ID: 2, HouseID: 7, Number: 2, Rooms: 3, Price: 7000, Status: string(dto.OnModeration), FlatAttributes: dto.FlatAttributes{
+			Floor: ptr(4), TotalArea: ptr(35.5), Section: ptr("B"), Balcony: ptr(dto.WithLoggia),
+		}, PricePerSqm: ptr(197)},
+	}
+
+	stream := func(_ context.Context, _ int, _ []dto.Status, fn func(*dto.DtoFlat) error) error {
+		for i := range flats {
+			if err := fn(&flats[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name            string
+		role            dto.UserType
+		format          dto.ExportFormat
+		wantStatuses    []dto.Status
+		wantContentType string
+		wantFileName    string
+		wantBody        string
+	}{
+		{
+			name:            "csv by default",
+			role:            dto.Moderator,
+			wantStatuses:    []dto.Status{dto.Approved, dto.Created, dto.OnModeration, dto.Declined},
+			wantContentType: "text/csv; charset=utf-8",
+			wantFileName:    "house-7-flats.csv",
+			wantBody: "id,house_id,number,rooms,price,status,floor,total_area,living_area,section,ceiling_height,balcony,finishing,price_per_sqm\n" +
+				"1,7,1,2,5000,approved,,,,,,,,\n" +
+				"2,7,2,3,7000,on moderation,4,35.5,,B,,loggia,,197\n",
+		},
+		{
+			name:            "tsv for client",
+			role:            dto.Client,
+			format:          dto.ExportTSV,
+			wantStatuses:    []dto.Status{dto.Approved},
+			wantContentType: "text/tab-separated-values; charset=utf-8",
+			wantFileName:    "house-7-flats.tsv",
+			wantBody: "id\thouse_id\tnumber\trooms\tprice\tstatus\tfloor\ttotal_area\tliving_area\tsection\tceiling_height\tbalcony\tfinishing\tprice_per_sqm\n" +
+				"1\t7\t1\t2\t5000\tapproved\t\t\t\t\t\t\t\t\n" +
+				"2\t7\t2\t3\t7000\ton moderation\t4\t35.5\t\tB\t\tloggia\t\t197\n",
+		},
+		{
+			name:            "json lines",
+			role:            dto.Client,
+			format:          dto.ExportJSONL,
+			wantStatuses:    []dto.Status{dto.Approved},
+			wantContentType: "application/x-ndjson",
+			wantFileName:    "house-7-flats.jsonl",
+			wantBody: `{"id":1,"house_id":7,"status":"approved","number":1,"rooms":2,"price":5000}` + "\n" +
+				`{"id":2,"house_id":7,"status":"on moderation","number":2,"rooms":3,"price":7000,"floor":4,"total_area":35.5,"section":"B","balcony":"loggia","price_per_sqm":197}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
+			mockFlatRepo.EXPECT().StreamFlats(gomock.Any(), 7, tt.wantStatuses, gomock.Any()).DoAndReturn(stream).Times(1)
+
+			mockHouseRepo := mocks.NewMockHouseFlatRepo(ctrl)
+			mockHouseRepo.EXPECT().GetHouse(gomock.Any(), 7).Return(&dto.House{Id: 7}, nil).Times(1)
+
+			flatService := service.NewFlatService(mockFlatRepo, mockHouseRepo)
+			export, err := flatService.ExportFlats(ctxWithRole(tt.role), "7", tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContentType, export.ContentType)
+			assert.Equal(t, tt.wantFileName, export.FileName)
+
+			var buf bytes.Buffer
+			require.NoError(t, export.WriteTo(context.Background(), &buf))
+			assert.Equal(t, tt.wantBody, buf.String())
+		})
+	}
+}
+
+func TestFlatService_ExportFlatsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseRepo := mocks.NewMockHouseFlatRepo(ctrl)
+	flatService := service.NewFlatService(mocks.NewMockFlatRepo(ctrl), mockHouseRepo)
+
+	_, err := flatService.ExportFlats(ctxWithRole(dto.Client), "7", "xlsx")
+	require.ErrorIs(t, err, service.ErrInvalidExportFormat)
+
+	mockHouseRepo.EXPECT().GetHouse(gomock.Any(), 8).Return(nil, repository.ErrHouseNotFound).Times(1)
+	_, err = flatService.ExportFlats(ctxWithRole(dto.Client), "8", dto.ExportCSV)
+	require.ErrorIs(t, err, repository.ErrHouseNotFound)
+
+	_, err = flatService.ExportAllFlats(ctxWithRole(dto.Client), dto.ExportCSV)
+	require.ErrorIs(t, err, service.ErrForbidden)
+
+	export, err := flatService.ExportAllFlats(ctxWithRole(dto.Moderator), dto.ExportJSONL)
+	require.NoError(t, err)
+	assert.Equal(t, "flats.jsonl", export.FileName)
+}
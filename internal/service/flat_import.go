@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+)
+
+// MaxFlatImportRows bounds a single import, a bigger building has to be split into several requests.
+const MaxFlatImportRows = 5000
+
+const (
+	flatImportRowsField = "rows"
+	ruleInteger         = "integer"
+	ruleCSVHeader       = "csv_header"
+	ruleCSVFormat       = "csv_format"
+	ruleDecimal         = "decimal"
+)
+
+var flatImportColumns = []string{"number", "rooms", "price"}
+
+// ImportFlats creates the flats of the house in one transaction and reports the outcome of every row.
+// Rows breaking the rules of flatViolations or floorViolation, or repeating a number, are rejected. If atomic
+// is set, a single rejected row cancels the whole import and the result has Imported == 0.
+func (s *FlatService) ImportFlats(ctx context.Context, houseIDStr string, items []dto.FlatImportItem, atomic bool) (*dto.FlatImportResult, error) {
+	if err := Authorize(ctx, policy.FlatCreate); err != nil {
+		return nil, err
+	}
+
+	houseID, err := strconv.Atoi(houseIDStr)
+	if err != nil {
+		return nil, ErrInvalidHouseID
+	}
+
+	if len(items) == 0 || len(items) > MaxFlatImportRows {
+		return nil, &ValidationError{Details: []dto.ErrorDetail{{
+			Field:   flatImportRowsField,
+			Rule:    RuleRequired,
+			Message: fmt.Sprintf("import must contain 1 to %d rows", MaxFlatImportRows),
+		}}}
+	}
+
+	if err = s.authorizeHouse(ctx, policy.FlatCreate, houseID); err != nil {
+		return nil, err
+	}
+
+	createdBy := callerAccountID(ctx)
+
+	var floors *int
+	for _, item := range items {
+		if item.Floor != nil {
+			if floors, err = s.houseFloors(ctx, houseID); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	result := &dto.FlatImportResult{Atomic: atomic, Rows: make([]dto.FlatImportRow, len(items))}
+	flats := make([]*dto.DtoFlat, 0, len(items))
+	rows := make([]*dto.FlatImportRow, 0, len(items))
+	seen := make(map[int]int, len(items))
+
+	for i, item := range items {
+		number := item.Number
+		row := &result.Rows[i]
+		row.Row = i + 1
+		row.Number = &number
+
+		flat := &dto.DtoFlat{
+			HouseID: houseID,
+			Number:  item.Number,
+			Rooms:   item.Rooms,
+			Price:   item.Price,
+			Status:  string(dto.Created),
+			FlatAttributes: dto.FlatAttributes{
+				Floor:         item.Floor,
+				TotalArea:     item.TotalArea,
+				LivingArea:    item.LivingArea,
+				Section:       item.Section,
+				CeilingHeight: item.CeilingHeight,
+				Balcony:       item.Balcony,
+				Finishing:     item.Finishing,
+			},
+			CreatedBy: createdBy,
+		}
+
+		row.Errors = flatViolations(*flat)
+		if item.Floor != nil {
+			if detail, ok := floorViolation("floor", *item.Floor, floors); !ok {
+				row.Errors = append(row.Errors, detail)
+			}
+		}
+		if first, ok := seen[item.Number]; ok && item.Number > 0 {
+			row.Errors = append(row.Errors, dto.ErrorDetail{Field: "number", Rule: RuleUnique, Message: fmt.Sprintf("number repeats row %d", first)})
+		} else {
+			seen[item.Number] = row.Row
+		}
+
+		if len(row.Errors) > 0 {
+			row.Status = dto.FlatRejected
+			result.Rejected++
+			continue
+		}
+
+		flats = append(flats, flat)
+		rows = append(rows, row)
+	}
+
+	if len(flats) > 0 && (!atomic || result.Rejected == 0) {
+		rowErrs, err := s.flatRepo.ImportFlats(ctx, flats, atomic)
+		if err != nil {
+			return nil, errors.Wrap(err, "import flats")
+		}
+
+		for i, rowErr := range rowErrs {
+			if rowErr != nil {
+				rows[i].Status = dto.FlatRejected
+				rows[i].Errors = []dto.ErrorDetail{{Field: "number", Rule: RuleUnique, Message: "flat with this number already exists"}}
+				result.Rejected++
+				continue
+			}
+			rows[i].Status = dto.FlatImported
+			id := flats[i].ID
+			rows[i].FlatId = &id
+			result.Imported++
+		}
+	}
+
+	if atomic && result.Rejected > 0 {
+		result.Imported = 0
+		for i := range result.Rows {
+			if result.Rows[i].Status != dto.FlatRejected {
+				result.Rows[i].Status = dto.FlatNotApplied
+				result.Rows[i].FlatId = nil
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// DecodeFlatImportCSV reads flats from CSV with a number,rooms,price header, in any column order. The floor,
+// total_area, living_area, section, ceiling_height, balcony and finishing columns are optional and their cells
+// may be empty. Cells that are not numbers are reported all at once as a ValidationError.
+func DecodeFlatImportCSV(r io.Reader) ([]dto.FlatImportItem, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, csvFormatError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	var details []dto.ErrorDetail
+	for _, name := range flatImportColumns {
+		if _, ok := columns[name]; !ok {
+			details = append(details, dto.ErrorDetail{Field: name, Rule: ruleCSVHeader, Message: fmt.Sprintf("column %q is missing from the header", name)})
+		}
+	}
+	if len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+
+	var items []dto.FlatImportItem
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, csvFormatError(err)
+		}
+
+		if len(items) == MaxFlatImportRows {
+			return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: flatImportRowsField, Rule: RuleMaxLength, Message: fmt.Sprintf("import must contain at most %d rows", MaxFlatImportRows)}}}
+		}
+
+		values := make([]int, len(flatImportColumns))
+		for i, name := range flatImportColumns {
+			cell := strings.TrimSpace(record[columns[name]])
+			if values[i], err = strconv.Atoi(cell); err != nil {
+				details = append(details, dto.ErrorDetail{
+					Field:   fmt.Sprintf("%s[%d].%s", flatImportRowsField, line, name),
+					Rule:    ruleInteger,
+					Message: fmt.Sprintf("%q is not an integer", cell),
+				})
+			}
+		}
+
+		item := dto.FlatImportItem{Number: values[0], Rooms: values[1], Price: values[2]}
+		details = append(details, decodeFlatImportAttributes(&item, line, func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		})...)
+
+		items = append(items, item)
+	}
+
+	if len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+
+	return items, nil
+}
+
+// decodeFlatImportAttributes fills the optional attributes of the item from the non-empty cells of the line.
+func decodeFlatImportAttributes(item *dto.FlatImportItem, line int, cell func(name string) string) []dto.ErrorDetail {
+	var details []dto.ErrorDetail
+	invalid := func(name, rule, message string) {
+		details = append(details, dto.ErrorDetail{Field: fmt.Sprintf("%s[%d].%s", flatImportRowsField, line, name), Rule: rule, Message: message})
+	}
+
+	if v := cell("floor"); v != "" {
+		floor, err := strconv.Atoi(v)
+		if err != nil {
+			invalid("floor", ruleInteger, fmt.Sprintf("%q is not an integer", v))
+		} else {
+			item.Floor = &floor
+		}
+	}
+
+	for _, f := range []struct {
+		name string
+		dest **float64
+	}{
+		{"total_area", &item.TotalArea},
+		{"living_area", &item.LivingArea},
+		{"ceiling_height", &item.CeilingHeight},
+	} {
+		v := cell(f.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		if err != nil {
+			invalid(f.name, ruleDecimal, fmt.Sprintf("%q is not a number", v))
+			continue
+		}
+		*f.dest = &n
+	}
+
+	if v := cell("section"); v != "" {
+		item.Section = &v
+	}
+	if v := cell("balcony"); v != "" {
+		balcony := dto.Balcony(v)
+		item.Balcony = &balcony
+	}
+	if v := cell("finishing"); v != "" {
+		finishing := dto.Finishing(v)
+		item.Finishing = &finishing
+	}
+
+	return details
+}
+
+func csvFormatError(err error) error {
+	return &ValidationError{Details: []dto.ErrorDetail{{Field: flatImportRowsField, Rule: ruleCSVFormat, Message: err.Error()}}}
+}
@@ -0,0 +1,188 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlatService_ImportFlats(t *testing.T) {
+	items := []dto.FlatImportItem{
+		{Number: 1, Rooms: 2, Price: 100},
+		{Number: 2, Rooms: 0, Price: 100},
+		{Number: 1, Rooms: 3, Price: 200},
+		{Number: 3, Rooms: 1, Price: 300},
+	}
+
+	setIDs := func(flats []*dto.DtoFlat) {
+		for i, flat := range flats {
+			flat.ID = 10 + i
+		}
+	}
+
+	tests := []struct {
+		name         string
+		items        []dto.FlatImportItem
+		atomic       bool
+		mockSetup    func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo)
+		wantImported int
+		wantRejected int
+		wantStatuses []dto.FlatImportRowStatus
+	}{
+		{
+			name:  "successful import",
+			items: []dto.FlatImportItem{items[0], items[3]},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().ImportFlats(gomock.Any(), gomock.Len(2), true).DoAndReturn(
+					func(_ any, flats []*dto.DtoFlat, _ bool) ([]error, error) {
+						setIDs(flats)
+						return make([]error, len(flats)), nil
+					}).Times(1)
+			},
+			atomic:       true,
+			wantImported: 2,
+			wantStatuses: []dto.FlatImportRowStatus{dto.FlatImported, dto.FlatImported},
+		},
+		{
+			name:         "atomic import with invalid rows is not applied",
+			items:        items,
+			atomic:       true,
+			mockSetup:    func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {},
+			wantRejected: 2,
+			wantStatuses: []dto.FlatImportRowStatus{dto.FlatNotApplied, dto.FlatRejected, dto.FlatRejected, dto.FlatNotApplied},
+		},
+		{
+			name:  "atomic import rolled back on existing flat",
+			items: []dto.FlatImportItem{items[0], items[3]},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().ImportFlats(gomock.Any(), gomock.Len(2), true).
+					Return([]error{nil, repository.ErrFlatExists}, nil).Times(1)
+			},
+			atomic:       true,
+			wantRejected: 1,
+			wantStatuses: []dto.FlatImportRowStatus{dto.FlatNotApplied, dto.FlatRejected},
+		},
+		{
+			name:  "partial import keeps valid rows",
+			items: items,
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().ImportFlats(gomock.Any(), gomock.Len(2), false).
+					Return([]error{nil, repository.ErrFlatExists}, nil).Times(1)
+			},
+			wantImported: 1,
+			wantRejected: 3,
+			wantStatuses: []dto.FlatImportRowStatus{dto.FlatImported, dto.FlatRejected, dto.FlatRejected, dto.FlatRejected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
+			mockHouseFlatRepo := mocks.NewMockHouseFlatRepo(ctrl)
+			tt.mockSetup(mockFlatRepo, mockHouseFlatRepo)
+
+			flatService := service.NewFlatService(mockFlatRepo, mockHouseFlatRepo)
+			result, err := flatService.ImportFlats(ctxWithRole(dto.Client), "1", tt.items, tt.atomic)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantImported, result.Imported)
+			assert.Equal(t, tt.wantRejected, result.Rejected)
+			for i, row := range result.Rows {
+				assert.Equal(t, i+1, row.Row)
+				assert.Equal(t, tt.wantStatuses[i], row.Status, "row %d", row.Row)
+				assert.Equal(t, row.Status == dto.FlatImported, row.FlatId != nil, "row %d", row.Row)
+			}
+		})
+	}
+}
+
+func TestFlatService_ImportFlatsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseFlatRepo := mocks.NewMockHouseFlatRepo(ctrl)
+	mockHouseFlatRepo.EXPECT().IsHouseDeveloper(gomock.Any(), 1, testUserID).Return(false, nil).Times(1)
+
+	flatService := service.NewFlatService(mocks.NewMockFlatRepo(ctrl), mockHouseFlatRepo)
+	_, err := flatService.ImportFlats(ctxWithRole(dto.DeveloperUser), "1", []dto.FlatImportItem{{Number: 1, Rooms: 1, Price: 1}}, true)
+
+	require.ErrorIs(t, err, service.ErrForbidden)
+}
+
+func TestDecodeFlatImportCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantItems []dto.FlatImportItem
+		wantRules []string
+	}{
+		{
+			name:      "columns in any order",
+			input:     "Price, number, rooms\n5000, 1, 2\n7000,2,3\n",
+			wantItems: []dto.FlatImportItem{{Number: 1, Rooms: 2, Price: 5000}, {Number: 2, Rooms: 3, Price: 7000}},
+		},
+		{
+			name:  "optional attribute columns",
+			input: "number,rooms,price,floor,total_area,balcony\n1,2,5000,3,\"41,5\",loggia\n2,3,7000,,,\n",
+			wantItems: []dto.FlatImportItem{
+				{Number: 1, Rooms: 2, Price: 5000, Floor: ptr(3), TotalArea: ptr(41.5), Balcony: ptr(dto.WithLoggia)},
+				{Number: 2, Rooms: 3, Price: 7000},
+			},
+		},
+		{
+			name:      "attribute cells that are not numbers",
+			input:     "number,rooms,price,floor,ceiling_height\n1,2,5000,first,high\n",
+			wantRules: []string{"integer", "decimal"},
+		},
+		{
+			name:      "missing column",
+			input:     "number,price\n1,5000\n",
+			wantRules: []string{"csv_header"},
+		},
+		{
+			name:      "cells that are not integers",
+			input:     "number,rooms,price\n1,two,5000\nx,2,5000\n",
+			wantRules: []string{"integer", "integer"},
+		},
+		{
+			name:      "ragged rows",
+			input:     "number,rooms,price\n1,2\n",
+			wantRules: []string{"csv_format"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := service.DecodeFlatImportCSV(strings.NewReader(tt.input))
+			if tt.wantRules == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantItems, items)
+				return
+			}
+
+			var validationErr *service.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			rules := make([]string, 0, len(validationErr.Details))
+			for _, d := range validationErr.Details {
+				rules = append(rules, d.Rule)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+//go:generate mockgen -source ./flat_media.go -destination=./mocks/flat_media.go -package=mocks
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/pkg/logger"
+)
+
+const (
+	// MaxFlatMediaSize bounds an uploaded photo or floor plan.
+	MaxFlatMediaSize = 10 << 20
+	// maxImagePixels refuses images that would take too much memory to decode, whatever their file size. The
+	// decoded image takes up to 8 bytes a pixel, so a photo of a phone camera fits and a crafted one can not
+	// claim gigabytes.
+	maxImagePixels = 16_000_000
+
+	thumbnailContentType = "image/jpeg"
+
+	ruleContentType = "content_type"
+	ruleImage       = "image"
+)
+
+var (
+	ErrInvalidFlatID        = errors.New("invalid flat ID")
+	ErrInvalidFlatMediaID   = errors.New("invalid media ID")
+	ErrFlatMediaTooLarge    = errors.Errorf("file exceeds %d bytes", MaxFlatMediaSize)
+	ErrFlatMediaNoThumbnail = errors.New("media has no thumbnail")
+)
+
+// flatMediaTypes lists the content types accepted for every kind of media.
+var flatMediaTypes = map[dto.FlatMediaKind][]string{
+	dto.MediaPhoto:     {"image/jpeg", "image/png"},
+	dto.MediaFloorPlan: {"image/jpeg", "image/png", "application/pdf"},
+}
+
+type FlatMediaRepo interface {
+	CreateFlatMedia(ctx context.Context, media *repository.FlatMedia) (*repository.FlatMedia, error)
+	ListFlatMedia(ctx context.Context, flatID int) ([]*repository.FlatMedia, error)
+	GetFlatMedia(ctx context.Context, flatID, id int) (*repository.FlatMedia, error)
+	DeleteFlatMedia(ctx context.Context, flatID, id int) (*repository.FlatMedia, error)
+}
+
+type MediaFlatRepo interface {
+	GetFlatByID(ctx context.Context, id int) (*dto.DtoFlat, error)
+}
+
+type HouseOwnerRepo interface {
+	IsHouseDeveloper(ctx context.Context, houseID int, userID string) (bool, error)
+}
+
+// BlobStore keeps the files of flat media. Open returns blobstore.ErrNotFound for missing blobs.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type FlatMediaService struct {
+	mediaRepo FlatMediaRepo
+	flatRepo  MediaFlatRepo
+	houseRepo HouseOwnerRepo
+	blobs     BlobStore
+}
+
+func NewFlatMediaService(mediaRepo FlatMediaRepo, flatRepo MediaFlatRepo, houseRepo HouseOwnerRepo, blobs BlobStore) *FlatMediaService {
+	return &FlatMediaService{
+		mediaRepo: mediaRepo,
+		flatRepo:  flatRepo,
+		houseRepo: houseRepo,
+		blobs:     blobs,
+	}
+}
+
+// FlatMediaContent is an opened media file, the caller closes Body.
+type FlatMediaContent struct {
+	ContentType string
+	Body        io.ReadCloser
+}
+
+// Upload stores a photo or floor plan of the flat. The content type is sniffed from the file itself and
+// must be allowed for the kind and match the declared one, if any. Images get a JPEG thumbnail.
+func (s *FlatMediaService) Upload(ctx context.Context, flatIDStr string, kind dto.FlatMediaKind, contentType string, body io.Reader) (*dto.FlatMedia, error) {
+	if err := Authorize(ctx, policy.FlatMediaManage); err != nil {
+		return nil, err
+	}
+
+	flat, err := s.getFlat(ctx, flatIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorizeOwnHouse(ctx, s.houseRepo, policy.FlatMediaManage, flat.HouseID); err != nil {
+		return nil, err
+	}
+
+	if kind == "" {
+		kind = dto.MediaPhoto
+	}
+	allowed, ok := flatMediaTypes[kind]
+	if !ok {
+		return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: "kind", Rule: ruleEnum, Message: fmt.Sprintf("unknown kind %q", kind)}}}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, MaxFlatMediaSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "read upload")
+	}
+	if len(data) > MaxFlatMediaSize {
+		return nil, ErrFlatMediaTooLarge
+	}
+	if len(data) == 0 {
+		return nil, &ValidationError{Details: []dto.ErrorDetail{{Field: "file", Rule: RuleRequired, Message: "file is empty"}}}
+	}
+
+	detected, err := validateFlatMediaType(data, contentType, allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	media := &repository.FlatMedia{
+		FlatID:      flat.ID,
+		Kind:        string(kind),
+		ContentType: detected,
+		Size:        int64(len(data)),
+		BlobKey:     fmt.Sprintf("flats/%d/%s", flat.ID, uuid.NewString()),
+	}
+
+	var thumb []byte
+	if detected != "application/pdf" {
+		if thumb, err = imageThumbnail(data); err != nil {
+			return nil, err
+		}
+		thumbKey := media.BlobKey + "-thumb.jpg"
+		media.ThumbnailKey = &thumbKey
+	}
+
+	if err = s.blobs.Put(ctx, media.BlobKey, bytes.NewReader(data), media.Size, detected); err != nil {
+		return nil, errors.Wrap(err, "store media")
+	}
+	if thumb != nil {
+		if err = s.blobs.Put(ctx, *media.ThumbnailKey, bytes.NewReader(thumb), int64(len(thumb)), thumbnailContentType); err != nil {
+			s.deleteBlobs(ctx, media.BlobKey)
+			return nil, errors.Wrap(err, "store thumbnail")
+		}
+	}
+
+	created, err := s.mediaRepo.CreateFlatMedia(ctx, media)
+	if err != nil {
+		s.deleteBlobs(ctx, blobKeys(media)...)
+		return nil, errors.Wrap(err, "create flat media")
+	}
+
+	return toDtoFlatMedia(created), nil
+}
+
+// List returns the media of the flat. Media of flats the caller can not see yet are hidden as if the
+// flat did not exist.
+func (s *FlatMediaService) List(ctx context.Context, flatIDStr string) ([]dto.FlatMedia, error) {
+	flat, err := s.getVisibleFlat(ctx, flatIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.mediaRepo.ListFlatMedia(ctx, flat.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list flat media")
+	}
+
+	result := make([]dto.FlatMedia, 0, len(media))
+	for _, m := range media {
+		result = append(result, *toDtoFlatMedia(m))
+	}
+
+	return result, nil
+}
+
+// Open returns the file of the media or its thumbnail, with the visibility rules of List.
+func (s *FlatMediaService) Open(ctx context.Context, flatIDStr, mediaIDStr string, thumbnail bool) (*FlatMediaContent, error) {
+	flat, err := s.getVisibleFlat(ctx, flatIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaID, err := strconv.Atoi(mediaIDStr)
+	if err != nil || mediaID <= 0 {
+		return nil, ErrInvalidFlatMediaID
+	}
+
+	media, err := s.mediaRepo.GetFlatMedia(ctx, flat.ID, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, contentType := media.BlobKey, media.ContentType
+	if thumbnail {
+		if media.ThumbnailKey == nil {
+			return nil, ErrFlatMediaNoThumbnail
+		}
+		key, contentType = *media.ThumbnailKey, thumbnailContentType
+	}
+
+	body, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "open media")
+	}
+
+	return &FlatMediaContent{ContentType: contentType, Body: body}, nil
+}
+
+// Delete removes the media. Its files are removed after the row, a failure to do so is only logged.
+func (s *FlatMediaService) Delete(ctx context.Context, flatIDStr, mediaIDStr string) error {
+	if err := Authorize(ctx, policy.FlatMediaManage); err != nil {
+		return err
+	}
+
+	flat, err := s.getFlat(ctx, flatIDStr)
+	if err != nil {
+		return err
+	}
+
+	if err = authorizeOwnHouse(ctx, s.houseRepo, policy.FlatMediaManage, flat.HouseID); err != nil {
+		return err
+	}
+
+	mediaID, err := strconv.Atoi(mediaIDStr)
+	if err != nil || mediaID <= 0 {
+		return ErrInvalidFlatMediaID
+	}
+
+	media, err := s.mediaRepo.DeleteFlatMedia(ctx, flat.ID, mediaID)
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlobs(ctx, blobKeys(media)...)
+
+	return nil
+}
+
+func (s *FlatMediaService) getFlat(ctx context.Context, flatIDStr string) (*dto.DtoFlat, error) {
+	flatID, err := strconv.Atoi(flatIDStr)
+	if err != nil || flatID <= 0 {
+		return nil, ErrInvalidFlatID
+	}
+
+	return s.flatRepo.GetFlatByID(ctx, flatID)
+}
+
+// getVisibleFlat returns the flat if the caller sees it in listings or may manage its media.
+func (s *FlatMediaService) getVisibleFlat(ctx context.Context, flatIDStr string) (*dto.DtoFlat, error) {
+	if err := Authorize(ctx, policy.FlatRead); err != nil {
+		return nil, err
+	}
+
+	role, err := callerRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	flat, err := s.getFlat(ctx, flatIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if containsStatus(policy.VisibleFlatStatuses(role), dto.Status(flat.Status)) {
+		return flat, nil
+	}
+
+	if Authorize(ctx, policy.FlatMediaManage) == nil {
+		err = authorizeOwnHouse(ctx, s.houseRepo, policy.FlatMediaManage, flat.HouseID)
+		if err == nil {
+			return flat, nil
+		} else if !errors.Is(err, ErrForbidden) {
+			return nil, err
+		}
+	}
+
+	return nil, errors.Wrapf(repository.ErrFlatNotFound, "flat %d is %s", flat.ID, flat.Status)
+}
+
+func (s *FlatMediaService) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			logger.Errorf(ctx, "delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// validateFlatMediaType returns the sniffed content type of data if it is allowed and agrees with the
+// declared one. An empty or generic declared type is not checked.
+func validateFlatMediaType(data []byte, declared string, allowed []string) (string, error) {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+
+	found := false
+	for _, t := range allowed {
+		found = found || t == detected
+	}
+	if !found {
+		return "", &ValidationError{Details: []dto.ErrorDetail{{Field: "file", Rule: ruleContentType, Message: fmt.Sprintf("%s is not accepted, use one of %v", detected, allowed)}}}
+	}
+
+	if declared, _, err := mime.ParseMediaType(declared); err == nil && declared != detected && declared != "application/octet-stream" {
+		return "", &ValidationError{Details: []dto.ErrorDetail{{Field: "file", Rule: ruleContentType, Message: fmt.Sprintf("declared %s, but the file is %s", declared, detected)}}}
+	}
+
+	return detected, nil
+}
+
+// imageThumbnail decodes the image and returns its thumbnail. Images that fail to decode are rejected, and so
+// are images with more than maxImagePixels, judged by their header before decoding.
+func imageThumbnail(data []byte) ([]byte, error) {
+	invalid := func(message string) error {
+		return &ValidationError{Details: []dto.ErrorDetail{{Field: "file", Rule: ruleImage, Message: message}}}
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalid("file is not a valid image")
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, invalid(fmt.Sprintf("image must have at most %d pixels", maxImagePixels))
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalid("file is not a valid image")
+	}
+
+	return thumbnail(img)
+}
+
+func blobKeys(media *repository.FlatMedia) []string {
+	keys := []string{media.BlobKey}
+	if media.ThumbnailKey != nil {
+		keys = append(keys, *media.ThumbnailKey)
+	}
+
+	return keys
+}
+
+func toDtoFlatMedia(m *repository.FlatMedia) *dto.FlatMedia {
+	return &dto.FlatMedia{
+		Id:           m.ID,
+		FlatId:       m.FlatID,
+		Kind:         dto.FlatMediaKind(m.Kind),
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		HasThumbnail: m.ThumbnailKey != nil,
+		CreatedAt:    m.CreatedAt,
+	}
+}
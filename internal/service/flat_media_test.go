@@ -0,0 +1,305 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// pngHeader is the start of a PNG of w by h pixels, enough to read its size but not to decode it.
+func pngHeader(w, h int) []byte {
+	ihdr := binary.BigEndian.AppendUint32([]byte("IHDR"), uint32(w))
+	ihdr = binary.BigEndian.AppendUint32(ihdr, uint32(h))
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	header := []byte("\x89PNG\r\n\x1a\n")
+	header = binary.BigEndian.AppendUint32(header, uint32(len(ihdr)-4))
+	header = append(header, ihdr...)
+	return binary.BigEndian.AppendUint32(header, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestFlatMediaService_Upload(t *testing.T) {
+	photo := pngImage(t, 800, 400)
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		kind          dto.FlatMediaKind
+		contentType   string
+		data          []byte
+		mockSetup     func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore)
+		wantThumbnail bool
+		wantRule      string
+		wantErr       error
+	}{
+		{
+			name:        "photo with thumbnail",
+			ctx:         ctxWithRole(dto.Moderator),
+			contentType: "image/png",
+			data:        photo,
+			mockSetup: func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {
+				b.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(photo)), "image/png").Return(nil).Times(1)
+				b.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "image/jpeg").
+					DoAndReturn(func(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+						assert.True(t, strings.HasSuffix(key, "-thumb.jpg"))
+						thumb, err := jpeg.Decode(r)
+						require.NoError(t, err)
+						assert.Equal(t, image.Rect(0, 0, 320, 160), thumb.Bounds())
+						return nil
+					}).Times(1)
+				m.EXPECT().CreateFlatMedia(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, media *repository.FlatMedia) (*repository.FlatMedia, error) {
+					assert.Equal(t, string(dto.MediaPhoto), media.Kind)
+					assert.True(t, strings.HasPrefix(media.BlobKey, "flats/5/"))
+					media.ID = 1
+					return media, nil
+				}).Times(1)
+			},
+			wantThumbnail: true,
+		},
+		{
+			name:        "floor plan in pdf has no thumbnail",
+			ctx:         ctxWithRole(dto.Moderator),
+			kind:        dto.MediaFloorPlan,
+			contentType: "application/pdf",
+			data:        pdf,
+			mockSetup: func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {
+				b.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(pdf)), "application/pdf").Return(nil).Times(1)
+				m.EXPECT().CreateFlatMedia(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, media *repository.FlatMedia) (*repository.FlatMedia, error) {
+					return media, nil
+				}).Times(1)
+			},
+		},
+		{
+			name:        "pdf is not a photo",
+			ctx:         ctxWithRole(dto.Moderator),
+			contentType: "application/pdf",
+			data:        pdf,
+			mockSetup:   func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {},
+			wantRule:    "content_type",
+		},
+		{
+			name:        "declared type does not match the file",
+			ctx:         ctxWithRole(dto.Moderator),
+			contentType: "image/jpeg",
+			data:        photo,
+			mockSetup:   func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {},
+			wantRule:    "content_type",
+		},
+		{
+			name:        "corrupt image",
+			ctx:         ctxWithRole(dto.Moderator),
+			contentType: "image/png",
+			data:        photo[:100],
+			mockSetup:   func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {},
+			wantRule:    "image",
+		},
+		{
+			name:        "image with too many pixels",
+			ctx:         ctxWithRole(dto.Moderator),
+			contentType: "image/png",
+			data:        pngHeader(5000, 4000),
+			mockSetup:   func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {},
+			wantRule:    "image",
+		},
+		{
+			name:        "file too large",
+			ctx:         ctxWithRole(dto.Moderator),
+			contentType: "image/png",
+			data:        append(photo, make([]byte, service.MaxFlatMediaSize)...),
+			mockSetup:   func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {},
+			wantErr:     service.ErrFlatMediaTooLarge,
+		},
+		{
+			name:        "developer of another house",
+			ctx:         ctxWithRole(dto.DeveloperUser),
+			contentType: "image/png",
+			data:        photo,
+			mockSetup: func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {
+				h.EXPECT().IsHouseDeveloper(gomock.Any(), 3, testUserID).Return(false, nil).Times(1)
+			},
+			wantErr: service.ErrForbidden,
+		},
+		{
+			name:        "client can not upload",
+			ctx:         ctxWithRole(dto.Client),
+			contentType: "image/png",
+			data:        photo,
+			mockSetup:   func(m *mocks.MockFlatMediaRepo, h *mocks.MockHouseOwnerRepo, b *mocks.MockBlobStore) {},
+			wantErr:     service.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMediaRepo := mocks.NewMockFlatMediaRepo(ctrl)
+			mockFlatRepo := mocks.NewMockMediaFlatRepo(ctrl)
+			mockHouseRepo := mocks.NewMockHouseOwnerRepo(ctrl)
+			mockBlobs := mocks.NewMockBlobStore(ctrl)
+			mockFlatRepo.EXPECT().GetFlatByID(gomock.Any(), 5).Return(&dto.DtoFlat{ID: 5, HouseID: 3, Status: string(dto.Created)}, nil).AnyTimes()
+			tt.mockSetup(mockMediaRepo, mockHouseRepo, mockBlobs)
+
+			mediaService := service.NewFlatMediaService(mockMediaRepo, mockFlatRepo, mockHouseRepo, mockBlobs)
+			media, err := mediaService.Upload(tt.ctx, "5", tt.kind, tt.contentType, bytes.NewReader(tt.data))
+
+			switch {
+			case tt.wantRule != "":
+				var validationErr *service.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.wantRule, validationErr.Details[0].Rule)
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, 5, media.FlatId)
+				assert.Equal(t, tt.contentType, media.ContentType)
+				assert.Equal(t, tt.wantThumbnail, media.HasThumbnail)
+			}
+		})
+	}
+}
+
+func TestFlatMediaService_List(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		status    dto.Status
+		mockSetup func(h *mocks.MockHouseOwnerRepo)
+		wantErr   error
+	}{
+		{
+			name:      "approved flat is visible to client",
+			ctx:       ctxWithRole(dto.Client),
+			status:    dto.Approved,
+			mockSetup: func(h *mocks.MockHouseOwnerRepo) {},
+		},
+		{
+			name:      "pending flat is hidden from client",
+			ctx:       ctxWithRole(dto.Client),
+			status:    dto.OnModeration,
+			mockSetup: func(h *mocks.MockHouseOwnerRepo) {},
+			wantErr:   repository.ErrFlatNotFound,
+		},
+		{
+			name:      "pending flat is visible to moderator",
+			ctx:       ctxWithRole(dto.Moderator),
+			status:    dto.Created,
+			mockSetup: func(h *mocks.MockHouseOwnerRepo) {},
+		},
+		{
+			name:   "pending flat is visible to developer of the house",
+			ctx:    ctxWithRole(dto.DeveloperUser),
+			status: dto.Created,
+			mockSetup: func(h *mocks.MockHouseOwnerRepo) {
+				h.EXPECT().IsHouseDeveloper(gomock.Any(), 3, testUserID).Return(true, nil).Times(1)
+			},
+		},
+		{
+			name:   "pending flat is hidden from other developers",
+			ctx:    ctxWithRole(dto.DeveloperUser),
+			status: dto.Created,
+			mockSetup: func(h *mocks.MockHouseOwnerRepo) {
+				h.EXPECT().IsHouseDeveloper(gomock.Any(), 3, testUserID).Return(false, nil).Times(1)
+			},
+			wantErr: repository.ErrFlatNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMediaRepo := mocks.NewMockFlatMediaRepo(ctrl)
+			mockFlatRepo := mocks.NewMockMediaFlatRepo(ctrl)
+			mockHouseRepo := mocks.NewMockHouseOwnerRepo(ctrl)
+			mockFlatRepo.EXPECT().GetFlatByID(gomock.Any(), 5).Return(&dto.DtoFlat{ID: 5, HouseID: 3, Status: string(tt.status)}, nil).Times(1)
+			tt.mockSetup(mockHouseRepo)
+			if tt.wantErr == nil {
+				mockMediaRepo.EXPECT().ListFlatMedia(gomock.Any(), 5).Return([]*repository.FlatMedia{
+					{ID: 1, FlatID: 5, Kind: string(dto.MediaPhoto), ContentType: "image/png", Size: 10, BlobKey: "flats/5/a", ThumbnailKey: ptr("flats/5/a-thumb.jpg")},
+				}, nil).Times(1)
+			}
+
+			mediaService := service.NewFlatMediaService(mockMediaRepo, mockFlatRepo, mockHouseRepo, mocks.NewMockBlobStore(ctrl))
+			media, err := mediaService.List(tt.ctx, "5")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, media, 1)
+			assert.True(t, media[0].HasThumbnail)
+		})
+	}
+}
+
+func TestFlatMediaService_OpenAndDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMediaRepo := mocks.NewMockFlatMediaRepo(ctrl)
+	mockFlatRepo := mocks.NewMockMediaFlatRepo(ctrl)
+	mockBlobs := mocks.NewMockBlobStore(ctrl)
+	mediaService := service.NewFlatMediaService(mockMediaRepo, mockFlatRepo, mocks.NewMockHouseOwnerRepo(ctrl), mockBlobs)
+	ctx := ctxWithRole(dto.Moderator)
+
+	media := &repository.FlatMedia{ID: 1, FlatID: 5, Kind: string(dto.MediaFloorPlan), ContentType: "application/pdf", BlobKey: "flats/5/a"}
+	mockFlatRepo.EXPECT().GetFlatByID(gomock.Any(), 5).Return(&dto.DtoFlat{ID: 5, HouseID: 3, Status: string(dto.Approved)}, nil).AnyTimes()
+	mockMediaRepo.EXPECT().GetFlatMedia(gomock.Any(), 5, 1).Return(media, nil).Times(2)
+	mockBlobs.EXPECT().Open(gomock.Any(), "flats/5/a").Return(io.NopCloser(strings.NewReader("%PDF")), nil).Times(1)
+
+	content, err := mediaService.Open(ctx, "5", "1", false)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", content.ContentType)
+
+	_, err = mediaService.Open(ctx, "5", "1", true)
+	assert.ErrorIs(t, err, service.ErrFlatMediaNoThumbnail)
+
+	_, err = mediaService.Open(ctx, "5", "x", false)
+	assert.ErrorIs(t, err, service.ErrInvalidFlatMediaID)
+
+	mockMediaRepo.EXPECT().DeleteFlatMedia(gomock.Any(), 5, 1).Return(media, nil).Times(1)
+	mockBlobs.EXPECT().Delete(gomock.Any(), "flats/5/a").Return(nil).Times(1)
+	require.NoError(t, mediaService.Delete(ctx, "5", "1"))
+}
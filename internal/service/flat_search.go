@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+const ruleRange = "range"
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// flatSearchCursor is the opaque position handed to clients as next_cursor. It remembers the sort it
+// was issued for, so it can not be replayed against another ordering. Timestamp sorts keep the value in At.
+type flatSearchCursor struct {
+	Sort  dto.FlatSort `json:"s"`
+	Value int          `json:"v"`
+	At    *time.Time   `json:"t,omitempty"`
+	ID    int          `json:"i"`
+}
+
+// SearchFlats finds flats across houses. Callers who can not read pending flats see approved ones only
+// and get ErrForbidden when they ask for another status.
+func (s *FlatService) SearchFlats(ctx context.Context, params dto.GetFlatsSearchParams) (*dto.FlatSearchResult, error) {
+	if err := Authorize(ctx, policy.FlatRead); err != nil {
+		return nil, err
+	}
+
+	if err := validateFlatSearch(params); err != nil {
+		return nil, err
+	}
+
+	role, err := callerRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	search := repository.FlatSearch{
+		Rooms:       params.Rooms,
+		MinPrice:    params.MinPrice,
+		MaxPrice:    params.MaxPrice,
+		MinYear:     params.MinYear,
+		MaxYear:     params.MaxYear,
+		DeveloperID: params.DeveloperId,
+		MinArea:     params.MinArea,
+		MaxArea:     params.MaxArea,
+		MinFloor:    params.MinFloor,
+		MaxFloor:    params.MaxFloor,
+		Balcony:     params.Balcony,
+		Finishing:   params.Finishing,
+		Statuses:    policy.VisibleFlatStatuses(role),
+		Limit:       DefaultSearchLimit,
+	}
+
+	if params.Status != nil {
+		if !containsStatus(search.Statuses, *params.Status) {
+			return nil, errors.Wrapf(ErrForbidden, "flats in status %q are not visible to %s", *params.Status, role)
+		}
+		search.Statuses = []dto.Status{*params.Status}
+	}
+
+	sort := dto.SortByID
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+	search.Desc = strings.HasPrefix(string(sort), "-")
+	search.Sort = strings.TrimPrefix(string(sort), "-")
+
+	if params.Limit != nil {
+		search.Limit = *params.Limit
+	}
+
+	if params.Cursor != nil {
+		if search.After, err = decodeFlatSearchCursor(*params.Cursor, sort); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := search.Limit
+	search.Limit++
+	flats, err := s.flatRepo.SearchFlats(ctx, search)
+	if err != nil {
+		return nil, errors.Wrap(err, "search flats")
+	}
+
+	result := &dto.FlatSearchResult{Flats: flats}
+	if result.Flats == nil {
+		result.Flats = []*dto.DtoFlat{}
+	}
+
+	if len(flats) > limit {
+		result.Flats = flats[:limit]
+		cursor := encodeFlatSearchCursor(sort, search.Sort, flats[limit-1])
+		result.NextCursor = &cursor
+	}
+
+	return result, nil
+}
+
+func validateFlatSearch(params dto.GetFlatsSearchParams) error {
+	var details []dto.ErrorDetail
+
+	nonNegative := func(field string, v *int) {
+		if v != nil && *v < 0 {
+			details = append(details, dto.ErrorDetail{Field: field, Rule: RulePositive, Message: field + " must not be negative"})
+		}
+	}
+	ordered := func(minField, maxField string, min, max *int) {
+		if min != nil && max != nil && *min > *max {
+			details = append(details, dto.ErrorDetail{Field: minField, Rule: ruleRange, Message: fmt.Sprintf("%s must not exceed %s", minField, maxField)})
+		}
+	}
+
+	nonNegative("rooms", params.Rooms)
+	nonNegative("min_price", params.MinPrice)
+	nonNegative("max_price", params.MaxPrice)
+	nonNegative("min_year", params.MinYear)
+	nonNegative("max_year", params.MaxYear)
+	ordered("min_price", "max_price", params.MinPrice, params.MaxPrice)
+	ordered("min_year", "max_year", params.MinYear, params.MaxYear)
+	nonNegative("min_floor", params.MinFloor)
+	nonNegative("max_floor", params.MaxFloor)
+	ordered("min_floor", "max_floor", params.MinFloor, params.MaxFloor)
+
+	for _, area := range []struct {
+		field string
+		value *float64
+	}{{"min_area", params.MinArea}, {"max_area", params.MaxArea}} {
+		if area.value != nil && (math.IsNaN(*area.value) || *area.value < 0) {
+			details = append(details, dto.ErrorDetail{Field: area.field, Rule: RulePositive, Message: area.field + " must not be negative"})
+		}
+	}
+	if params.MinArea != nil && params.MaxArea != nil && *params.MinArea > *params.MaxArea {
+		details = append(details, dto.ErrorDetail{Field: "min_area", Rule: ruleRange, Message: "min_area must not exceed max_area"})
+	}
+
+	details = append(details, flatAttributeViolations(dto.FlatAttributes{Balcony: params.Balcony, Finishing: params.Finishing})...)
+
+	if params.Status != nil && !containsStatus([]dto.Status{dto.Created, dto.Approved, dto.Declined, dto.OnModeration}, *params.Status) {
+		details = append(details, dto.ErrorDetail{Field: "status", Rule: ruleEnum, Message: fmt.Sprintf("unknown status %q", *params.Status)})
+	}
+
+	if params.Sort != nil {
+		switch *params.Sort {
+		case dto.SortByID, dto.SortByPrice, dto.SortByPriceDesc, dto.SortByRooms, dto.SortByRoomsDesc,
+			dto.SortByCreatedAt, dto.SortByCreatedAtDesc, dto.SortByUpdatedAt, dto.SortByUpdatedAtDesc:
+		default:
+			details = append(details, dto.ErrorDetail{Field: "sort", Rule: ruleEnum, Message: fmt.Sprintf("unknown sort %q", *params.Sort)})
+		}
+	}
+
+	if params.Limit != nil && (*params.Limit < 1 || *params.Limit > MaxSearchLimit) {
+		details = append(details, dto.ErrorDetail{Field: "limit", Rule: ruleRange, Message: fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit)})
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
+
+func encodeFlatSearchCursor(sort dto.FlatSort, column string, flat *dto.DtoFlat) string {
+	cursor := flatSearchCursor{Sort: sort, ID: flat.ID}
+	switch column {
+	case "price":
+		cursor.Value = flat.Price
+	case "rooms":
+		cursor.Value = flat.Rooms
+	case "created_at":
+		cursor.At = flat.CreatedAt
+	case "updated_at":
+		cursor.At = flat.UpdatedAt
+	default:
+		cursor.Value = flat.ID
+	}
+
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeFlatSearchCursor(s string, sort dto.FlatSort) (*repository.FlatCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor flatSearchCursor
+	if err = json.Unmarshal(b, &cursor); err != nil || cursor.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+
+	after := &repository.FlatCursor{Value: cursor.Value, ID: cursor.ID}
+	if cursor.At != nil {
+		after.At = *cursor.At
+	}
+
+	return after, nil
+}
+
+func containsStatus(statuses []dto.Status, status dto.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
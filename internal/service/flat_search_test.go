@@ -0,0 +1,181 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlatService_SearchFlats(t *testing.T) {
+	rooms, maxPrice := 2, 10000000
+	created := dto.Created
+	byPriceDesc := dto.SortByPriceDesc
+	minYear, maxYear := 2020, 2010
+
+	tests := []struct {
+		name      string
+		role      dto.UserType
+		params    dto.GetFlatsSearchParams
+		mockSetup func(m *mocks.MockFlatRepo)
+		wantLen   int
+		wantErr   error
+	}{
+		{
+			name:   "client sees approved flats only",
+			role:   dto.Client,
+			params: dto.GetFlatsSearchParams{Rooms: &rooms, MaxPrice: &maxPrice},
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				m.EXPECT().SearchFlats(gomock.Any(), repository.FlatSearch{
+					Rooms:    &rooms,
+					MaxPrice: &maxPrice,
+					Statuses: []dto.Status{dto.Approved},
+					Sort:     "id",
+					Limit:    service.DefaultSearchLimit + 1,
+				}).Return([]*dto.DtoFlat{{ID: 1}, {ID: 2}}, nil).Times(1)
+			},
+			wantLen: 2,
+		},
+		{
+			name:   "moderator filters by status",
+			role:   dto.Moderator,
+			params: dto.GetFlatsSearchParams{Status: &created, Sort: &byPriceDesc},
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				m.EXPECT().SearchFlats(gomock.Any(), repository.FlatSearch{
+					Statuses: []dto.Status{dto.Created},
+					Sort:     "price",
+					Desc:     true,
+					Limit:    service.DefaultSearchLimit + 1,
+				}).Return(nil, nil).Times(1)
+			},
+			wantLen: 0,
+		},
+		{
+			name:      "client can not search pending flats",
+			role:      dto.Client,
+			params:    dto.GetFlatsSearchParams{Status: &created},
+			mockSetup: func(m *mocks.MockFlatRepo) {},
+			wantErr:   service.ErrForbidden,
+		},
+		{
+			name:      "inverted year range",
+			role:      dto.Client,
+			params:    dto.GetFlatsSearchParams{MinYear: &minYear, MaxYear: &maxYear},
+			mockSetup: func(m *mocks.MockFlatRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
+			tt.mockSetup(mockFlatRepo)
+
+			flatService := service.NewFlatService(mockFlatRepo, mocks.NewMockHouseFlatRepo(ctrl))
+			result, err := flatService.SearchFlats(ctxWithRole(tt.role), tt.params)
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+				assert.Len(t, result.Flats, tt.wantLen)
+				assert.NotNil(t, result.Flats)
+				assert.Nil(t, result.NextCursor)
+			case *service.ValidationError:
+				require.ErrorAs(t, err, &want)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlatService_SearchFlatsPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := 2
+	byPrice := dto.SortByPrice
+	byRooms := dto.SortByRooms
+
+	mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
+	first := mockFlatRepo.EXPECT().SearchFlats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, search repository.FlatSearch) ([]*dto.DtoFlat, error) {
+			assert.Nil(t, search.After)
+			assert.Equal(t, limit+1, search.Limit)
+			return []*dto.DtoFlat{{ID: 5, Price: 100}, {ID: 3, Price: 200}, {ID: 4, Price: 200}}, nil
+		}).Times(1)
+	mockFlatRepo.EXPECT().SearchFlats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, search repository.FlatSearch) ([]*dto.DtoFlat, error) {
+			assert.Equal(t, &repository.FlatCursor{Value: 200, ID: 3}, search.After)
+			return []*dto.DtoFlat{{ID: 4, Price: 200}}, nil
+		}).After(first).Times(1)
+
+	flatService := service.NewFlatService(mockFlatRepo, mocks.NewMockHouseFlatRepo(ctrl))
+	ctx := ctxWithRole(dto.Client)
+
+	page, err := flatService.SearchFlats(ctx, dto.GetFlatsSearchParams{Sort: &byPrice, Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, page.Flats, 2)
+	require.NotNil(t, page.NextCursor)
+
+	cursor := page.NextCursor
+	page, err = flatService.SearchFlats(ctx, dto.GetFlatsSearchParams{Sort: &byPrice, Limit: &limit, Cursor: cursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Flats, 1)
+	assert.Nil(t, page.NextCursor)
+
+	_, err = flatService.SearchFlats(ctx, dto.GetFlatsSearchParams{Sort: &byRooms, Cursor: cursor})
+	require.ErrorIs(t, err, service.ErrInvalidCursor, "cursor issued for another sort")
+
+	bad := "not-a-cursor"
+	_, err = flatService.SearchFlats(ctx, dto.GetFlatsSearchParams{Sort: &byPrice, Cursor: &bad})
+	require.ErrorIs(t, err, service.ErrInvalidCursor)
+}
+
+func TestFlatService_SearchFlatsByTimestamp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := 1
+	byCreatedAtDesc := dto.SortByCreatedAtDesc
+	createdAt := time.Date(2024, 8, 31, 10, 0, 0, 123456000, time.UTC)
+
+	mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
+	first := mockFlatRepo.EXPECT().SearchFlats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, search repository.FlatSearch) ([]*dto.DtoFlat, error) {
+			assert.Equal(t, "created_at", search.Sort)
+			assert.True(t, search.Desc)
+			return []*dto.DtoFlat{{ID: 8, CreatedAt: &createdAt}, {ID: 6, CreatedAt: ptr(createdAt.Add(-time.Hour))}}, nil
+		}).Times(1)
+	mockFlatRepo.EXPECT().SearchFlats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, search repository.FlatSearch) ([]*dto.DtoFlat, error) {
+			require.NotNil(t, search.After)
+			assert.Equal(t, 8, search.After.ID)
+			assert.True(t, createdAt.Equal(search.After.At))
+			return nil, nil
+		}).After(first).Times(1)
+
+	flatService := service.NewFlatService(mockFlatRepo, mocks.NewMockHouseFlatRepo(ctrl))
+	ctx := ctxWithRole(dto.Client)
+
+	page, err := flatService.SearchFlats(ctx, dto.GetFlatsSearchParams{Sort: &byCreatedAtDesc, Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, page.Flats, 1)
+	require.NotNil(t, page.NextCursor)
+
+	_, err = flatService.SearchFlats(ctx, dto.GetFlatsSearchParams{Sort: &byCreatedAtDesc, Limit: &limit, Cursor: page.NextCursor})
+	require.NoError(t, err)
+}
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
 	"github.com/shhesterka04/house-service/internal/service"
 	"github.com/shhesterka04/house-service/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,7 @@ import (
 func TestService_CreateFlat(t *testing.T) {
 	tests := []struct {
 		name      string
+		ctx       context.Context
 		req       dto.CreateFlatRequest
 		mockSetup func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo)
 		wantFlat  *dto.DtoFlat
@@ -34,19 +37,61 @@ func TestService_CreateFlat(t *testing.T) {
 			},
 			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
 				m.EXPECT().CreateFlat(gomock.Any(), &dto.DtoFlat{
+					HouseID:   1,
+					Number:    101,
+					Rooms:     3,
+					Price:     100000,
+					Status:    string(dto.Created),
+					CreatedBy: ptr(testUserID),
+				}).Return(&dto.DtoFlat{
 					HouseID: 1,
 					Number:  101,
 					Rooms:   3,
 					Price:   100000,
 					Status:  string(dto.Created),
-				}).Return(&dto.DtoFlat{
+				}, nil).Times(1)
+			},
+			wantFlat: &dto.DtoFlat{
+				HouseID: 1,
+				Number:  101,
+				Rooms:   3,
+				Price:   100000,
+				Status:  string(dto.Created),
+			},
+			wantErr: false,
+		},
+		{
+			name: "error creating flat",
+			req: dto.CreateFlatRequest{
+				HouseID: 1,
+				Number:  101,
+				Rooms:   3,
+				Price:   100000,
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().CreateFlat(gomock.Any(), gomock.Any()).Return(nil, errors.New("create flat error")).Times(1)
+			},
+			wantFlat: nil,
+			wantErr:  true,
+		},
+		{
+			name: "developer creates flat in own house",
+			ctx:  ctxWithRole(dto.DeveloperUser),
+			req: dto.CreateFlatRequest{
+				HouseID: 1,
+				Number:  101,
+				Rooms:   3,
+				Price:   100000,
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				h.EXPECT().IsHouseDeveloper(gomock.Any(), 1, testUserID).Return(true, nil).Times(1)
+				m.EXPECT().CreateFlat(gomock.Any(), gomock.Any()).Return(&dto.DtoFlat{
 					HouseID: 1,
 					Number:  101,
 					Rooms:   3,
 					Price:   100000,
 					Status:  string(dto.Created),
 				}, nil).Times(1)
-				h.EXPECT().UpdateHouse(gomock.Any(), 1, gomock.Any()).Return(nil, nil).Times(1)
 			},
 			wantFlat: &dto.DtoFlat{
 				HouseID: 1,
@@ -58,15 +103,81 @@ func TestService_CreateFlat(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "error creating flat",
+			name: "floor within house floors",
+			req: dto.CreateFlatRequest{
+				HouseID:        1,
+				Number:         101,
+				Rooms:          2,
+				Price:          5000000,
+				FlatAttributes: dto.FlatAttributes{Floor: ptr(9), TotalArea: ptr(50.0), LivingArea: ptr(30.0)},
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				h.EXPECT().GetHouse(gomock.Any(), 1).Return(&dto.House{Id: 1, Floors: ptr(9)}, nil).Times(1)
+				m.EXPECT().CreateFlat(gomock.Any(), gomock.Any()).Return(&dto.DtoFlat{
+					HouseID:        1,
+					Number:         101,
+					Rooms:          2,
+					Price:          5000000,
+					Status:         string(dto.Created),
+					FlatAttributes: dto.FlatAttributes{Floor: ptr(9), TotalArea: ptr(50.0), LivingArea: ptr(30.0)},
+					PricePerSqm:    ptr(100000),
+				}, nil).Times(1)
+			},
+			wantFlat: &dto.DtoFlat{
+				HouseID:        1,
+				Number:         101,
+				Rooms:          2,
+				Price:          5000000,
+				Status:         string(dto.Created),
+				FlatAttributes: dto.FlatAttributes{Floor: ptr(9), TotalArea: ptr(50.0), LivingArea: ptr(30.0)},
+				PricePerSqm:    ptr(100000),
+			},
+			wantErr: false,
+		},
+		{
+			name: "floor above house floors",
+			req: dto.CreateFlatRequest{
+				HouseID:        1,
+				Number:         101,
+				Rooms:          2,
+				Price:          5000000,
+				FlatAttributes: dto.FlatAttributes{Floor: ptr(10)},
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				h.EXPECT().GetHouse(gomock.Any(), 1).Return(&dto.House{Id: 1, Floors: ptr(9)}, nil).Times(1)
+			},
+			wantFlat: nil,
+			wantErr:  true,
+		},
+		{
+			name: "invalid attributes",
 			req: dto.CreateFlatRequest{
 				HouseID: 1,
 				Number:  101,
+				Rooms:   2,
+				Price:   5000000,
+				FlatAttributes: dto.FlatAttributes{
+					TotalArea:     ptr(40.0),
+					LivingArea:    ptr(45.0),
+					CeilingHeight: ptr(1.5),
+					Balcony:       ptr(dto.Balcony("terrace")),
+				},
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {},
+			wantFlat:  nil,
+			wantErr:   true,
+		},
+		{
+			name: "developer can not create flat in another developer's house",
+			ctx:  ctxWithRole(dto.DeveloperUser),
+			req: dto.CreateFlatRequest{
+				HouseID: 2,
+				Number:  101,
 				Rooms:   3,
 				Price:   100000,
 			},
 			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
-				m.EXPECT().CreateFlat(gomock.Any(), gomock.Any()).Return(nil, errors.New("create flat error")).Times(1)
+				h.EXPECT().IsHouseDeveloper(gomock.Any(), 2, testUserID).Return(false, nil).Times(1)
 			},
 			wantFlat: nil,
 			wantErr:  true,
@@ -83,8 +194,12 @@ func TestService_CreateFlat(t *testing.T) {
 			mockHouseFlatRepo := mocks.NewMockHouseFlatRepo(ctrl)
 			tt.mockSetup(mockFlatRepo, mockHouseFlatRepo)
 
+			if tt.ctx == nil {
+				tt.ctx = ctxWithRole(dto.Client)
+			}
+
 			flatService := service.NewFlatService(mockFlatRepo, mockHouseFlatRepo)
-			flat, err := flatService.CreateFlat(context.Background(), tt.req)
+			flat, err := flatService.CreateFlat(tt.ctx, tt.req)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -98,10 +213,13 @@ func TestService_CreateFlat(t *testing.T) {
 
 func TestFlatService_UpdateFlat(t *testing.T) {
 	validStatus := dto.Approved
+	declined := dto.Declined
+	onModeration := dto.OnModeration
 	invalidStatus := dto.Status("invalid")
 
 	tests := []struct {
 		name      string
+		ctx       context.Context
 		req       dto.PostFlatUpdateJSONRequestBody
 		mockSetup func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo)
 		wantFlat  *dto.DtoFlat
@@ -118,14 +236,17 @@ func TestFlatService_UpdateFlat(t *testing.T) {
 					ID:     1,
 					Status: string(dto.Created),
 				}, nil).Times(1)
-				m.EXPECT().UpdateFlat(gomock.Any(), &dto.DtoFlat{
-					ID:     1,
-					Status: string(validStatus),
+				m.EXPECT().ModerateFlat(gomock.Any(), &dto.DtoFlat{
+					ID:      1,
+					Status:  string(validStatus),
+					Version: 1,
+				}, &repository.ModerationDecision{
+					Status:      validStatus,
+					ModeratorID: ptr(testUserID),
 				}).Return(&dto.DtoFlat{
 					ID:     1,
 					Status: string(validStatus),
 				}, nil).Times(1)
-				h.EXPECT().UpdateHouse(gomock.Any(), gomock.Eq(0), gomock.Any()).Return(nil, nil).Times(1)
 			},
 			wantFlat: &dto.DtoFlat{
 				ID:     1,
@@ -133,6 +254,100 @@ func TestFlatService_UpdateFlat(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "decline with reason and comment",
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:      1,
+				Status:  &declined,
+				Reason:  ptr("wrong_price"),
+				Comment: ptr("price is ten times the market"),
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1, Status: string(dto.OnModeration)}, nil).Times(1)
+				m.EXPECT().ModerateFlat(gomock.Any(), &dto.DtoFlat{ID: 1, Status: string(declined), Version: 1}, &repository.ModerationDecision{
+					Status:      declined,
+					Reason:      &dto.DeclineReason{Code: "wrong_price"},
+					Comment:     ptr("price is ten times the market"),
+					ModeratorID: ptr(testUserID),
+				}).Return(&dto.DtoFlat{ID: 1, Status: string(declined)}, nil).Times(1)
+			},
+			wantFlat: &dto.DtoFlat{ID: 1, Status: string(declined)},
+		},
+		{
+			name:      "status missing",
+			req:       dto.PostFlatUpdateJSONRequestBody{Id: 1},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "decline without reason",
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:     1,
+				Status: &declined,
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "decline with unknown reason",
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:     1,
+				Status: &declined,
+				Reason: ptr("no_such_reason"),
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1}, nil).Times(1)
+				m.EXPECT().ModerateFlat(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repository.ErrDeclineReasonNotFound).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reason is not accepted on approval",
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:     1,
+				Status: &validStatus,
+				Reason: ptr("wrong_price"),
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "taking on moderation records no decision",
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:     1,
+				Status: &onModeration,
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1, Status: string(dto.Created)}, nil).Times(1)
+				m.EXPECT().UpdateFlat(gomock.Any(), &dto.DtoFlat{ID: 1, Status: string(onModeration), Version: 1}).
+					Return(&dto.DtoFlat{ID: 1, Status: string(onModeration), Version: 2}, nil).Times(1)
+			},
+			wantFlat: &dto.DtoFlat{ID: 1, Status: string(onModeration), Version: 2},
+		},
+		{
+			name: "flat modified since it was read",
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:     1,
+				Status: &onModeration,
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {
+				m.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1, Status: string(dto.Created), Version: 2}, nil).Times(1)
+				m.EXPECT().UpdateFlat(gomock.Any(), &dto.DtoFlat{ID: 1, Status: string(onModeration), Version: 1}).
+					Return(nil, repository.ErrFlatModified).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "client can not moderate",
+			ctx:  ctxWithRole(dto.Client),
+			req: dto.PostFlatUpdateJSONRequestBody{
+				Id:     1,
+				Status: &validStatus,
+			},
+			mockSetup: func(m *mocks.MockFlatRepo, h *mocks.MockHouseFlatRepo) {},
+			wantFlat:  nil,
+			wantErr:   true,
+		},
 		{
 			name: "invalid status",
 			req: dto.PostFlatUpdateJSONRequestBody{
@@ -167,8 +382,12 @@ func TestFlatService_UpdateFlat(t *testing.T) {
 			mockHouseFlatRepo := mocks.NewMockHouseFlatRepo(ctrl)
 			tt.mockSetup(mockFlatRepo, mockHouseFlatRepo)
 
+			if tt.ctx == nil {
+				tt.ctx = ctxWithRole(dto.Moderator)
+			}
+
 			flatService := service.NewFlatService(mockFlatRepo, mockHouseFlatRepo)
-			flat, err := flatService.UpdateFlat(context.Background(), tt.req)
+			flat, err := flatService.UpdateFlat(tt.ctx, tt.req, 1)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -180,11 +399,122 @@ func TestFlatService_UpdateFlat(t *testing.T) {
 	}
 }
 
+func TestFlatService_DeleteFlat(t *testing.T) {
+	otherUserID := "5d0f3c1a-2b4e-4f6a-8c9d-0e1f2a3b4c5d"
+
+	// lockedFlat makes the repository find flat, locked, and hand it to the check of the service.
+	lockedFlat := func(m *mocks.MockFlatRepo, flat *dto.DtoFlat) {
+		m.EXPECT().DeleteFlat(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(_ context.Context, _ int, allow func(*dto.DtoFlat) error) error {
+			return allow(flat)
+		}).Times(1)
+	}
+
+	tests := []struct {
+		name           string
+		flatID         string
+		role           dto.UserType
+		mockSetup      func(m *mocks.MockFlatRepo)
+		houseMockSetup func(m *mocks.MockHouseFlatRepo)
+		wantErr        error
+	}{
+		{
+			name:   "owner deletes a pending flat",
+			flatID: "1",
+			role:   dto.Client,
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				lockedFlat(m, &dto.DtoFlat{ID: 1, HouseID: 7, Status: string(dto.OnModeration), CreatedBy: ptr(testUserID)})
+			},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {
+			},
+		},
+		{
+			name:   "moderator deletes an approved flat",
+			flatID: "1",
+			role:   dto.Moderator,
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				lockedFlat(m, &dto.DtoFlat{ID: 1, HouseID: 7, Status: string(dto.Approved), CreatedBy: ptr(otherUserID)})
+			},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {
+			},
+		},
+		{
+			name:   "owner can not delete an approved flat",
+			flatID: "1",
+			role:   dto.Client,
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				lockedFlat(m, &dto.DtoFlat{ID: 1, Status: string(dto.Approved), CreatedBy: ptr(testUserID)})
+			},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {},
+			wantErr:        service.ErrForbidden,
+		},
+		{
+			name:   "approved flat of another user",
+			flatID: "1",
+			role:   dto.DeveloperUser,
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				lockedFlat(m, &dto.DtoFlat{ID: 1, Status: string(dto.Approved), CreatedBy: ptr(otherUserID)})
+			},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {},
+			wantErr:        service.ErrForbidden,
+		},
+		{
+			name:   "pending flat of another user is hidden",
+			flatID: "1",
+			role:   dto.Client,
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				lockedFlat(m, &dto.DtoFlat{ID: 1, Status: string(dto.Created)})
+			},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {},
+			wantErr:        repository.ErrFlatNotFound,
+		},
+		{
+			name:   "already deleted",
+			flatID: "1",
+			role:   dto.Moderator,
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				m.EXPECT().DeleteFlat(gomock.Any(), 1, gomock.Any()).Return(repository.ErrFlatNotFound).Times(1)
+			},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {},
+			wantErr:        repository.ErrFlatNotFound,
+		},
+		{
+			name:           "invalid flat ID",
+			flatID:         "abc",
+			role:           dto.Moderator,
+			mockSetup:      func(m *mocks.MockFlatRepo) {},
+			houseMockSetup: func(m *mocks.MockHouseFlatRepo) {},
+			wantErr:        service.ErrInvalidFlatID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
+			mockHouseFlatRepo := mocks.NewMockHouseFlatRepo(ctrl)
+			tt.mockSetup(mockFlatRepo)
+			tt.houseMockSetup(mockHouseFlatRepo)
+
+			flatService := service.NewFlatService(mockFlatRepo, mockHouseFlatRepo)
+			err := flatService.DeleteFlat(ctxWithRole(tt.role), tt.flatID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestFlatService_GetFlatsByHouseID(t *testing.T) {
 	tests := []struct {
 		name      string
 		houseID   string
-		token     string
+		ctx       context.Context
 		mockSetup func(m *mocks.MockFlatRepo)
 		wantFlats []*dto.DtoFlat
 		wantErr   bool
@@ -192,9 +522,9 @@ func TestFlatService_GetFlatsByHouseID(t *testing.T) {
 		{
 			name:    "successful retrieval",
 			houseID: "1",
-			token:   "",
+			ctx:     ctxWithRole(dto.Client),
 			mockSetup: func(m *mocks.MockFlatRepo) {
-				m.EXPECT().GetFlatByHouseID(gomock.Any(), 1, "client").Return([]*dto.DtoFlat{
+				m.EXPECT().GetFlatByHouseID(gomock.Any(), 1, []dto.Status{dto.Approved}).Return([]*dto.DtoFlat{
 					{
 						ID:      1,
 						HouseID: 1,
@@ -217,18 +547,28 @@ func TestFlatService_GetFlatsByHouseID(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "moderator sees pending flats",
+			houseID: "1",
+			ctx:     ctxWithRole(dto.Moderator),
+			mockSetup: func(m *mocks.MockFlatRepo) {
+				m.EXPECT().GetFlatByHouseID(gomock.Any(), 1, []dto.Status{dto.Approved, dto.Created, dto.OnModeration, dto.Declined}).Return(nil, nil).Times(1)
+			},
+			wantFlats: nil,
+			wantErr:   false,
+		},
 		{
 			name:      "invalid house ID",
 			houseID:   "invalid",
-			token:     "",
+			ctx:       ctxWithRole(dto.Client),
 			mockSetup: func(m *mocks.MockFlatRepo) {},
 			wantFlats: nil,
 			wantErr:   true,
 		},
 		{
-			name:      "invalid token",
+			name:      "unauthenticated",
 			houseID:   "1",
-			token:     "invalid-token",
+			ctx:       context.Background(),
 			mockSetup: func(m *mocks.MockFlatRepo) {},
 			wantFlats: nil,
 			wantErr:   true,
@@ -236,9 +576,9 @@ func TestFlatService_GetFlatsByHouseID(t *testing.T) {
 		{
 			name:    "error retrieving flats",
 			houseID: "1",
-			token:   "",
+			ctx:     ctxWithRole(dto.Client),
 			mockSetup: func(m *mocks.MockFlatRepo) {
-				m.EXPECT().GetFlatByHouseID(gomock.Any(), 1, "client").Return(nil, errors.New("error retrieving flats")).Times(1)
+				m.EXPECT().GetFlatByHouseID(gomock.Any(), 1, []dto.Status{dto.Approved}).Return(nil, errors.New("error retrieving flats")).Times(1)
 			},
 			wantFlats: nil,
 			wantErr:   true,
@@ -254,14 +594,8 @@ func TestFlatService_GetFlatsByHouseID(t *testing.T) {
 			mockFlatRepo := mocks.NewMockFlatRepo(ctrl)
 			tt.mockSetup(mockFlatRepo)
 
-			if tt.token == "" {
-				token, err := service.GenerateJWT("client")
-				require.NoError(t, err)
-				tt.token = token
-			}
-
 			flatService := service.NewFlatService(mockFlatRepo, nil)
-			flats, err := flatService.GetFlatsByHouseID(context.Background(), tt.houseID, tt.token)
+			flats, err := flatService.GetFlatsByHouseID(tt.ctx, tt.houseID)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -272,3 +606,63 @@ func TestFlatService_GetFlatsByHouseID(t *testing.T) {
 		})
 	}
 }
+
+func TestFlatService_HouseFlatsUpdatedAt(t *testing.T) {
+	updatedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		houseID   string
+		ctx       context.Context
+		mockSetup func(m *mocks.MockHouseFlatRepo)
+		want      *time.Time
+		wantErr   error
+	}{
+		{
+			name:    "update time of the house",
+			houseID: "1",
+			ctx:     ctxWithRole(dto.Client),
+			mockSetup: func(m *mocks.MockHouseFlatRepo) {
+				m.EXPECT().GetHouse(gomock.Any(), 1).Return(&dto.House{Id: 1, UpdateAt: &updatedAt}, nil).Times(1)
+			},
+			want: &updatedAt,
+		},
+		{
+			name:    "house not found",
+			houseID: "1",
+			ctx:     ctxWithRole(dto.Client),
+			mockSetup: func(m *mocks.MockHouseFlatRepo) {
+				m.EXPECT().GetHouse(gomock.Any(), 1).Return(nil, repository.ErrHouseNotFound).Times(1)
+			},
+			wantErr: repository.ErrHouseNotFound,
+		},
+		{
+			name:      "unauthenticated",
+			houseID:   "1",
+			ctx:       context.Background(),
+			mockSetup: func(m *mocks.MockHouseFlatRepo) {},
+			wantErr:   service.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHouseFlatRepo := mocks.NewMockHouseFlatRepo(ctrl)
+			tt.mockSetup(mockHouseFlatRepo)
+
+			flatService := service.NewFlatService(mocks.NewMockFlatRepo(ctrl), mockHouseFlatRepo)
+			updatedAt, err := flatService.HouseFlatsUpdatedAt(tt.ctx, tt.houseID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, updatedAt)
+			}
+		})
+	}
+}
@@ -3,14 +3,44 @@ package service
 
 import (
 	"context"
-	"time"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
 )
 
+const (
+	// similarHouseScore is the trigram similarity of normalized addresses above which a new house is
+	// reported as a possible duplicate.
+	similarHouseScore = 0.6
+	similarHouseLimit = 5
+
+	searchQueryMinLength = 2
+	DefaultSearchLimit   = 20
+	MaxSearchLimit       = 100
+
+	DefaultNearbyRadiusKm = 5
+	MaxNearbyRadiusKm     = 100
+
+	rulePattern     = "pattern"
+	ruleCoordinates = "coordinates"
+)
+
+var ErrInvalidHouseID = errors.New("invalid house ID")
+
+var postalCodePattern = regexp.MustCompile(`^[0-9A-Za-z -]{3,10}$`)
+
 type HouseRepo interface {
 	CreateHouse(ctx context.Context, house *dto.House) (*dto.House, error)
+	SearchHouses(ctx context.Context, query, city string, limit int) ([]dto.HouseMatch, error)
+	NearbyHouses(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]dto.HouseNearby, error)
+	SimilarHouses(ctx context.Context, address string, minScore float32, limit int) ([]dto.HouseMatch, error)
+	Subscribe(ctx context.Context, houseID int, email string) error
 }
 
 type HouseService struct {
@@ -21,33 +51,215 @@ func NewHouseService(houseRepo HouseRepo) *HouseService {
 	return &HouseService{houseRepo: houseRepo}
 }
 
-func (s *HouseService) CreateHouse(ctx context.Context, req dto.PostHouseCreateJSONRequestBody) (*dto.House, error) {
+// CreateHouse creates the house unless one with the same normalized address exists. Houses with a
+// near-identical address do not block creation, they are returned in Similar for the caller to review.
+func (s *HouseService) CreateHouse(ctx context.Context, req dto.PostHouseCreateJSONRequestBody) (*dto.HouseCreated, error) {
+	if err := Authorize(ctx, policy.HouseCreate); err != nil {
+		return nil, err
+	}
+
 	house := &dto.House{
-		Address:   req.Address,
-		Year:      req.Year,
-		Developer: req.Developer,
+		Address:     req.Address,
+		Year:        req.Year,
+		Developer:   req.Developer,
+		DeveloperId: req.DeveloperId,
+		Floors:      req.Floors,
+		City:        req.City,
+		Street:      req.Street,
+		Building:    req.Building,
+		PostalCode:  req.PostalCode,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
 	}
 
-	if !validateHouseRequest(*house) {
-		return nil, errors.New("invalid request")
+	if err := validateHouseRequest(*house); err != nil {
+		return nil, err
 	}
 
-	house, err := s.houseRepo.CreateHouse(ctx, house)
+	similar, err := s.houseRepo.SimilarHouses(ctx, house.Address, similarHouseScore, similarHouseLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "find similar houses")
+	}
+
+	house, err = s.houseRepo.CreateHouse(ctx, house)
 	if err != nil {
 		return nil, errors.Wrap(err, "create house")
 	}
 
-	return house, nil
+	return &dto.HouseCreated{
+		Id:          house.Id,
+		Address:     house.Address,
+		Year:        house.Year,
+		Developer:   house.Developer,
+		DeveloperId: house.DeveloperId,
+		Floors:      house.Floors,
+		City:        house.City,
+		Street:      house.Street,
+		Building:    house.Building,
+		PostalCode:  house.PostalCode,
+		Latitude:    house.Latitude,
+		Longitude:   house.Longitude,
+		CreatedAt:   house.CreatedAt,
+		UpdateAt:    house.UpdateAt,
+		Similar:     similar,
+	}, nil
 }
 
-func validateHouseRequest(h dto.House) bool {
-	if h.Address == "" {
-		return false
+// SearchHouses returns houses whose address matches the query, best matches first, optionally only in
+// the city. A zero limit means DefaultSearchLimit.
+func (s *HouseService) SearchHouses(ctx context.Context, query, city string, limit int) ([]dto.HouseMatch, error) {
+	if err := Authorize(ctx, policy.HouseRead); err != nil {
+		return nil, err
+	}
+
+	var details []dto.ErrorDetail
+
+	query = strings.TrimSpace(query)
+	if len([]rune(query)) < searchQueryMinLength {
+		details = append(details, dto.ErrorDetail{Field: "q", Rule: RuleMinLength, Message: fmt.Sprintf("query must be at least %d characters long", searchQueryMinLength)})
+	}
+
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	} else if limit < 0 || limit > MaxSearchLimit {
+		details = append(details, dto.ErrorDetail{Field: "limit", Rule: ruleRange, Message: fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit)})
+	}
+
+	if len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+
+	matches, err := s.houseRepo.SearchHouses(ctx, query, strings.TrimSpace(city), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "search houses")
+	}
+
+	if matches == nil {
+		matches = []dto.HouseMatch{}
+	}
+
+	return matches, nil
+}
+
+// NearbyHouses returns houses with coordinates within the radius of the point, nearest first. Zero radius
+// and limit mean DefaultNearbyRadiusKm and DefaultSearchLimit.
+func (s *HouseService) NearbyHouses(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]dto.HouseNearby, error) {
+	if err := Authorize(ctx, policy.HouseRead); err != nil {
+		return nil, err
+	}
+
+	details := coordinateViolations(&lat, &lon)
+
+	if radiusKm == 0 {
+		radiusKm = DefaultNearbyRadiusKm
+	} else if math.IsNaN(radiusKm) || radiusKm < 0 || radiusKm > MaxNearbyRadiusKm {
+		details = append(details, dto.ErrorDetail{Field: "radius_km", Rule: ruleRange, Message: fmt.Sprintf("radius_km must be between 0 and %d", MaxNearbyRadiusKm)})
+	}
+
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	} else if limit < 0 || limit > MaxSearchLimit {
+		details = append(details, dto.ErrorDetail{Field: "limit", Rule: ruleRange, Message: fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit)})
+	}
+
+	if len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+
+	houses, err := s.houseRepo.NearbyHouses(ctx, lat, lon, radiusKm, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "nearby houses")
+	}
+
+	if houses == nil {
+		houses = []dto.HouseNearby{}
+	}
+
+	return houses, nil
+}
+
+// Subscribe subscribes email to news about new flats in the house.
+func (s *HouseService) Subscribe(ctx context.Context, houseIDStr, email string) error {
+	if err := Authorize(ctx, policy.HouseSubscribe); err != nil {
+		return err
+	}
+
+	houseID, err := strconv.Atoi(houseIDStr)
+	if err != nil || houseID <= 0 {
+		return ErrInvalidHouseID
+	}
+
+	email = strings.TrimSpace(email)
+	if !isValidEmail(email) {
+		return &ValidationError{Details: []dto.ErrorDetail{{Field: "email", Rule: rulePattern, Message: "email is invalid"}}}
+	}
+
+	return s.houseRepo.Subscribe(ctx, houseID, email)
+}
+
+// validateHouseRequest checks the house fields. Year is not capped, houses under construction are sold too.
+func validateHouseRequest(h dto.House) error {
+	var details []dto.ErrorDetail
+	violation := func(field, rule, message string) {
+		details = append(details, dto.ErrorDetail{Field: field, Rule: rule, Message: message})
+	}
+
+	if strings.TrimSpace(h.Address) == "" {
+		violation("address", RuleRequired, "address is required")
+	}
+
+	if h.Year <= 0 {
+		violation("year", RulePositive, "year must be positive")
+	}
+
+	if h.DeveloperId != nil && *h.DeveloperId <= 0 {
+		violation("developer_id", RulePositive, "developer_id must be positive")
+	}
+
+	if h.Floors != nil && *h.Floors <= 0 {
+		violation("floors", RulePositive, "floors must be positive")
+	}
+
+	for _, f := range []struct {
+		name   string
+		value  *string
+		maxLen int
+	}{
+		{"city", h.City, 255},
+		{"street", h.Street, 255},
+		{"building", h.Building, 64},
+	} {
+		if f.value != nil && len([]rune(*f.value)) > f.maxLen {
+			violation(f.name, RuleMaxLength, fmt.Sprintf("%s must be at most %d characters long", f.name, f.maxLen))
+		}
+	}
+
+	if h.PostalCode != nil && !postalCodePattern.MatchString(*h.PostalCode) {
+		violation("postal_code", rulePattern, "postal_code must be 3 to 10 letters, digits, spaces or dashes")
+	}
+
+	if (h.Latitude == nil) != (h.Longitude == nil) {
+		violation("latitude", ruleCoordinates, "latitude and longitude must be given together")
+	}
+	details = append(details, coordinateViolations(h.Latitude, h.Longitude)...)
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
+
+func coordinateViolations(lat, lon *float64) []dto.ErrorDetail {
+	var details []dto.ErrorDetail
+
+	if lat != nil && (math.IsNaN(*lat) || *lat < -90 || *lat > 90) {
+		details = append(details, dto.ErrorDetail{Field: "latitude", Rule: ruleRange, Message: "latitude must be between -90 and 90"})
 	}
 
-	if h.Year <= 0 && h.Year > time.Now().Year() {
-		return false
+	if lon != nil && (math.IsNaN(*lon) || *lon < -180 || *lon > 180) {
+		details = append(details, dto.ErrorDetail{Field: "longitude", Rule: ruleRange, Message: "longitude must be between -180 and 180"})
 	}
 
-	return true
+	return details
 }
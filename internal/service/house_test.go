@@ -18,12 +18,14 @@ import (
 
 func Test_ServiceCreateHouse(t *testing.T) {
 	developer := "Developer Inc."
+	lat, lon, badLat := 55.7558, 37.6173, 91.0
 
 	tests := []struct {
 		name      string
+		ctx       context.Context
 		req       dto.PostHouseCreateJSONRequestBody
 		mockSetup func(m *mocks.MockHouseRepo)
-		wantHouse *dto.House
+		wantHouse *dto.HouseCreated
 		wantErr   bool
 	}{
 		{
@@ -34,6 +36,7 @@ func Test_ServiceCreateHouse(t *testing.T) {
 				Developer: &developer,
 			},
 			mockSetup: func(m *mocks.MockHouseRepo) {
+				m.EXPECT().SimilarHouses(gomock.Any(), "123 Main St", gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				m.EXPECT().CreateHouse(gomock.Any(), &dto.House{
 					Address:   "123 Main St",
 					Year:      2020,
@@ -44,13 +47,90 @@ func Test_ServiceCreateHouse(t *testing.T) {
 					Developer: &developer,
 				}, nil).Times(1)
 			},
-			wantHouse: &dto.House{
+			wantHouse: &dto.HouseCreated{
 				Address:   "123 Main St",
 				Year:      2020,
 				Developer: &developer,
 			},
 			wantErr: false,
 		},
+		{
+			name: "near-identical address is reported",
+			req: dto.PostHouseCreateJSONRequestBody{
+				Address: "Ленина ул, 1",
+				Year:    2020,
+			},
+			mockSetup: func(m *mocks.MockHouseRepo) {
+				m.EXPECT().SimilarHouses(gomock.Any(), "Ленина ул, 1", gomock.Any(), gomock.Any()).Return([]dto.HouseMatch{
+					{House: dto.House{Id: 7, Address: "ул. Ленина 1а", Year: 2001}, Score: 0.7},
+				}, nil).Times(1)
+				m.EXPECT().CreateHouse(gomock.Any(), gomock.Any()).Return(&dto.House{Id: 8, Address: "Ленина ул, 1", Year: 2020}, nil).Times(1)
+			},
+			wantHouse: &dto.HouseCreated{
+				Id:      8,
+				Address: "Ленина ул, 1",
+				Year:    2020,
+				Similar: []dto.HouseMatch{
+					{House: dto.House{Id: 7, Address: "ул. Ленина 1а", Year: 2001}, Score: 0.7},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "structured address with coordinates",
+			req: dto.PostHouseCreateJSONRequestBody{
+				Address:    "Москва, ул. Ленина, 1",
+				Year:       2020,
+				City:       ptr("Москва"),
+				Street:     ptr("ул. Ленина"),
+				Building:   ptr("1"),
+				PostalCode: ptr("101000"),
+				Latitude:   &lat,
+				Longitude:  &lon,
+			},
+			mockSetup: func(m *mocks.MockHouseRepo) {
+				m.EXPECT().SimilarHouses(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				m.EXPECT().CreateHouse(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, h *dto.House) (*dto.House, error) {
+					h.Id = 9
+					return h, nil
+				}).Times(1)
+			},
+			wantHouse: &dto.HouseCreated{
+				Id:         9,
+				Address:    "Москва, ул. Ленина, 1",
+				Year:       2020,
+				City:       ptr("Москва"),
+				Street:     ptr("ул. Ленина"),
+				Building:   ptr("1"),
+				PostalCode: ptr("101000"),
+				Latitude:   &lat,
+				Longitude:  &lon,
+			},
+			wantErr: false,
+		},
+		{
+			name: "latitude without longitude",
+			req: dto.PostHouseCreateJSONRequestBody{
+				Address:  "Москва, ул. Ленина, 1",
+				Year:     2020,
+				Latitude: &badLat,
+			},
+			mockSetup: func(m *mocks.MockHouseRepo) {},
+			wantHouse: nil,
+			wantErr:   true,
+		},
+		{
+			name: "client can not create house",
+			ctx:  ctxWithRole(dto.Client),
+			req: dto.PostHouseCreateJSONRequestBody{
+				Address:   "123 Main St",
+				Year:      2020,
+				Developer: &developer,
+			},
+			mockSetup: func(m *mocks.MockHouseRepo) {},
+			wantHouse: nil,
+			wantErr:   true,
+		},
 		{
 			name: "house already exists",
 			req: dto.PostHouseCreateJSONRequestBody{
@@ -59,6 +139,7 @@ func Test_ServiceCreateHouse(t *testing.T) {
 				Developer: &developer,
 			},
 			mockSetup: func(m *mocks.MockHouseRepo) {
+				m.EXPECT().SimilarHouses(gomock.Any(), "123 Main St", gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				m.EXPECT().CreateHouse(gomock.Any(), &dto.House{
 					Address:   "123 Main St",
 					Year:      2020,
@@ -79,8 +160,12 @@ func Test_ServiceCreateHouse(t *testing.T) {
 			mockHouseRepo := mocks.NewMockHouseRepo(ctrl)
 			tt.mockSetup(mockHouseRepo)
 
+			if tt.ctx == nil {
+				tt.ctx = ctxWithRole(dto.Moderator)
+			}
+
 			houseService := service.NewHouseService(mockHouseRepo)
-			house, err := houseService.CreateHouse(context.Background(), tt.req)
+			house, err := houseService.CreateHouse(tt.ctx, tt.req)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -91,3 +176,115 @@ func Test_ServiceCreateHouse(t *testing.T) {
 		})
 	}
 }
+
+func Test_ServiceSearchHouses(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		city      string
+		limit     int
+		mockSetup func(m *mocks.MockHouseRepo)
+		wantLen   int
+		wantRule  string
+	}{
+		{
+			name:  "default limit",
+			query: " Ленина 1 ",
+			mockSetup: func(m *mocks.MockHouseRepo) {
+				m.EXPECT().SearchHouses(gomock.Any(), "Ленина 1", "", service.DefaultSearchLimit).Return([]dto.HouseMatch{
+					{House: dto.House{Id: 1, Address: "ул. Ленина 1"}, Score: 0.9},
+				}, nil).Times(1)
+			},
+			wantLen: 1,
+		},
+		{
+			name:  "no matches in city",
+			query: "Ленина",
+			city:  " Москва ",
+			limit: 5,
+			mockSetup: func(m *mocks.MockHouseRepo) {
+				m.EXPECT().SearchHouses(gomock.Any(), "Ленина", "Москва", 5).Return(nil, nil).Times(1)
+			},
+			wantLen: 0,
+		},
+		{
+			name:      "query too short",
+			query:     "Л",
+			mockSetup: func(m *mocks.MockHouseRepo) {},
+			wantRule:  service.RuleMinLength,
+		},
+		{
+			name:      "limit too big",
+			query:     "Ленина",
+			limit:     service.MaxSearchLimit + 1,
+			mockSetup: func(m *mocks.MockHouseRepo) {},
+			wantRule:  "range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHouseRepo := mocks.NewMockHouseRepo(ctrl)
+			tt.mockSetup(mockHouseRepo)
+
+			houseService := service.NewHouseService(mockHouseRepo)
+			matches, err := houseService.SearchHouses(ctxWithRole(dto.Client), tt.query, tt.city, tt.limit)
+
+			if tt.wantRule != "" {
+				var validationErr *service.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.wantRule, validationErr.Details[0].Rule)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, matches)
+				assert.Len(t, matches, tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_ServiceNearbyHouses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseRepo := mocks.NewMockHouseRepo(ctrl)
+	mockHouseRepo.EXPECT().NearbyHouses(gomock.Any(), 55.75, 37.61, float64(service.DefaultNearbyRadiusKm), service.DefaultSearchLimit).
+		Return([]dto.HouseNearby{{House: dto.House{Id: 1}, DistanceKm: 0.4}}, nil).Times(1)
+
+	houseService := service.NewHouseService(mockHouseRepo)
+
+	houses, err := houseService.NearbyHouses(ctxWithRole(dto.Client), 55.75, 37.61, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, houses, 1)
+
+	_, err = houseService.NearbyHouses(ctxWithRole(dto.Client), 95, 200, service.MaxNearbyRadiusKm+1, 0)
+	var validationErr *service.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Details, 3)
+}
+
+func Test_ServiceSubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseRepo := mocks.NewMockHouseRepo(ctrl)
+	mockHouseRepo.EXPECT().Subscribe(gomock.Any(), 1, "buyer@example.com").Return(nil).Times(1)
+
+	houseService := service.NewHouseService(mockHouseRepo)
+
+	require.NoError(t, houseService.Subscribe(ctxWithRole(dto.Client), "1", " buyer@example.com "))
+
+	err := houseService.Subscribe(ctxWithRole(dto.Client), "0", "buyer@example.com")
+	require.ErrorIs(t, err, service.ErrInvalidHouseID)
+
+	err = houseService.Subscribe(ctxWithRole(dto.Client), "1", "not-an-email")
+	var validationErr *service.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	err = houseService.Subscribe(context.Background(), "1", "buyer@example.com")
+	require.ErrorIs(t, err, service.ErrUnauthorized)
+}
@@ -1,22 +1,107 @@
 package service
 
 import (
+	"context"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/audit"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
 )
 
 var JwtKey = []byte("your_secret_key")
 
+var ErrInvalidToken = errors.New("invalid token")
+
 const loginTime = 3 * time.Hour
 
-func GenerateJWT(userType string) (string, error) {
-	claims := &jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(loginTime)),
-		Issuer:    "house-service",
-		Subject:   userType,
+// dummyNamespace seeds the synthetic user IDs handed out by /dummyLogin, so a dummy identity
+// is stable per role and never collides with a real (random v4) user ID.
+var dummyNamespace = uuid.MustParse("6f1c1b9e-2c39-4a8e-9d0b-2f6a7c1d5e00")
+
+// Claims is the payload of tokens issued by the service. Subject holds the user type.
+// Callers authenticated with an API key get the same claims, narrowed down by the key's scopes.
+type Claims struct {
+	UserID   string   `json:"user_id,omitempty"`
+	Dummy    bool     `json:"dummy,omitempty"`
+	APIKeyID string   `json:"-"`
+	Scopes   []string `json:"-"`
+	jwt.RegisteredClaims
+}
+
+// Allows reports whether the caller may perform action: the role must permit it and,
+// for API keys, the action must be among the key's scopes.
+func (c *Claims) Allows(action policy.Action) bool {
+	if !policy.Allowed(dto.UserType(c.Subject), action) {
+		return false
+	}
+
+	if c.APIKeyID == "" {
+		return true
+	}
+
+	return slices.Contains(c.Scopes, string(action))
+}
+
+// Principal identifies the caller across requests: the account, or for a dummy token the token itself, since
+// the dummy tokens of a role share one synthetic user.
+func (c *Claims) Principal() string {
+	if c.Dummy {
+		return "dummy:" + c.ID
 	}
 
+	return "user:" + c.UserID
+}
+
+type claimsCtxKey struct{}
+
+func GenerateJWT(userID, userType string) (string, error) {
+	return signClaims(&Claims{UserID: userID}, userType)
+}
+
+// GenerateDummyJWT issues a token for a synthetic user, marked with dummy=true so audit logs
+// and ownership checks can tell it apart from a real account. Each token gets an ID of its own,
+// which tells its holder apart from other holders of dummy tokens of the role.
+func GenerateDummyJWT(userType string) (string, error) {
+	userID := uuid.NewSHA1(dummyNamespace, []byte(userType)).String()
+	claims := &Claims{UserID: userID, Dummy: true}
+	claims.ID = uuid.NewString()
+
+	return signClaims(claims, userType)
+}
+
+func signClaims(claims *Claims, userType string) (string, error) {
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(loginTime))
+	claims.Issuer = "house-service"
+	claims.Subject = userType
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(JwtKey)
 }
+
+func ParseJWT(tokenStr string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		return JwtKey, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// ContextWithClaims stores the caller in ctx, for the policy checks and as the actor of audited changes.
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	ctx = audit.WithActor(ctx, audit.Actor{UserID: claims.UserID, Role: claims.Subject, APIKeyID: claims.APIKeyID, Dummy: claims.Dummy})
+	return context.WithValue(ctx, claimsCtxKey{}, claims)
+}
+
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsCtxKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./audit.go -destination=./mocks/audit.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shhesterka04/house-service/internal/dto"
+	repository "github.com/shhesterka04/house-service/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditRepo is a mock of AuditRepo interface.
+type MockAuditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepoMockRecorder
+}
+
+// MockAuditRepoMockRecorder is the mock recorder for MockAuditRepo.
+type MockAuditRepoMockRecorder struct {
+	mock *MockAuditRepo
+}
+
+// NewMockAuditRepo creates a new mock instance.
+func NewMockAuditRepo(ctrl *gomock.Controller) *MockAuditRepo {
+	mock := &MockAuditRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepo) EXPECT() *MockAuditRepoMockRecorder {
+	return m.recorder
+}
+
+// AuditLog mocks base method.
+func (m *MockAuditRepo) AuditLog(ctx context.Context, filter repository.AuditFilter) ([]*dto.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog", ctx, filter)
+	ret0, _ := ret[0].([]*dto.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockAuditRepoMockRecorder) AuditLog(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockAuditRepo)(nil).AuditLog), ctx, filter)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./developers.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./developers.go -destination=./mocks/developers.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shhesterka04/house-service/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeveloperRepo is a mock of DeveloperRepo interface.
+type MockDeveloperRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeveloperRepoMockRecorder
+}
+
+// MockDeveloperRepoMockRecorder is the mock recorder for MockDeveloperRepo.
+type MockDeveloperRepoMockRecorder struct {
+	mock *MockDeveloperRepo
+}
+
+// NewMockDeveloperRepo creates a new mock instance.
+func NewMockDeveloperRepo(ctrl *gomock.Controller) *MockDeveloperRepo {
+	mock := &MockDeveloperRepo{ctrl: ctrl}
+	mock.recorder = &MockDeveloperRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeveloperRepo) EXPECT() *MockDeveloperRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateDeveloper mocks base method.
+func (m *MockDeveloperRepo) CreateDeveloper(ctx context.Context, developer *dto.DeveloperProfile) (*dto.DeveloperProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeveloper", ctx, developer)
+	ret0, _ := ret[0].(*dto.DeveloperProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeveloper indicates an expected call of CreateDeveloper.
+func (mr *MockDeveloperRepoMockRecorder) CreateDeveloper(ctx, developer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeveloper", reflect.TypeOf((*MockDeveloperRepo)(nil).CreateDeveloper), ctx, developer)
+}
+
+// GetHousesByDeveloperID mocks base method.
+func (m *MockDeveloperRepo) GetHousesByDeveloperID(ctx context.Context, developerID int) ([]*dto.House, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHousesByDeveloperID", ctx, developerID)
+	ret0, _ := ret[0].([]*dto.House)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHousesByDeveloperID indicates an expected call of GetHousesByDeveloperID.
+func (mr *MockDeveloperRepoMockRecorder) GetHousesByDeveloperID(ctx, developerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHousesByDeveloperID", reflect.TypeOf((*MockDeveloperRepo)(nil).GetHousesByDeveloperID), ctx, developerID)
+}
+
+// ListDevelopers mocks base method.
+func (m *MockDeveloperRepo) ListDevelopers(ctx context.Context) ([]*dto.DeveloperProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDevelopers", ctx)
+	ret0, _ := ret[0].([]*dto.DeveloperProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDevelopers indicates an expected call of ListDevelopers.
+func (mr *MockDeveloperRepoMockRecorder) ListDevelopers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDevelopers", reflect.TypeOf((*MockDeveloperRepo)(nil).ListDevelopers), ctx)
+}
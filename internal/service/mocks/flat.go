@@ -12,9 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	dto "github.com/shhesterka04/house-service/internal/dto"
+	repository "github.com/shhesterka04/house-service/internal/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -56,19 +56,33 @@ func (mr *MockFlatRepoMockRecorder) CreateFlat(ctx, flat any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlat", reflect.TypeOf((*MockFlatRepo)(nil).CreateFlat), ctx, flat)
 }
 
+// DeleteFlat mocks base method.
+func (m *MockFlatRepo) DeleteFlat(ctx context.Context, id int, allow func(*dto.DtoFlat) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlat", ctx, id, allow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlat indicates an expected call of DeleteFlat.
+func (mr *MockFlatRepoMockRecorder) DeleteFlat(ctx, id, allow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlat", reflect.TypeOf((*MockFlatRepo)(nil).DeleteFlat), ctx, id, allow)
+}
+
 // GetFlatByHouseID mocks base method.
-func (m *MockFlatRepo) GetFlatByHouseID(ctx context.Context, houseID int, userType string) ([]*dto.DtoFlat, error) {
+func (m *MockFlatRepo) GetFlatByHouseID(ctx context.Context, houseID int, statuses []dto.Status) ([]*dto.DtoFlat, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFlatByHouseID", ctx, houseID, userType)
+	ret := m.ctrl.Call(m, "GetFlatByHouseID", ctx, houseID, statuses)
 	ret0, _ := ret[0].([]*dto.DtoFlat)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFlatByHouseID indicates an expected call of GetFlatByHouseID.
-func (mr *MockFlatRepoMockRecorder) GetFlatByHouseID(ctx, houseID, userType any) *gomock.Call {
+func (mr *MockFlatRepoMockRecorder) GetFlatByHouseID(ctx, houseID, statuses any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlatByHouseID", reflect.TypeOf((*MockFlatRepo)(nil).GetFlatByHouseID), ctx, houseID, userType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlatByHouseID", reflect.TypeOf((*MockFlatRepo)(nil).GetFlatByHouseID), ctx, houseID, statuses)
 }
 
 // GetFlatByID mocks base method.
@@ -86,6 +100,65 @@ func (mr *MockFlatRepoMockRecorder) GetFlatByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlatByID", reflect.TypeOf((*MockFlatRepo)(nil).GetFlatByID), ctx, id)
 }
 
+// ImportFlats mocks base method.
+func (m *MockFlatRepo) ImportFlats(ctx context.Context, flats []*dto.DtoFlat, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportFlats", ctx, flats, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportFlats indicates an expected call of ImportFlats.
+func (mr *MockFlatRepoMockRecorder) ImportFlats(ctx, flats, atomic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportFlats", reflect.TypeOf((*MockFlatRepo)(nil).ImportFlats), ctx, flats, atomic)
+}
+
+// ModerateFlat mocks base method.
+func (m *MockFlatRepo) ModerateFlat(ctx context.Context, flat *dto.DtoFlat, decision *repository.ModerationDecision) (*dto.DtoFlat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateFlat", ctx, flat, decision)
+	ret0, _ := ret[0].(*dto.DtoFlat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateFlat indicates an expected call of ModerateFlat.
+func (mr *MockFlatRepoMockRecorder) ModerateFlat(ctx, flat, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateFlat", reflect.TypeOf((*MockFlatRepo)(nil).ModerateFlat), ctx, flat, decision)
+}
+
+// SearchFlats mocks base method.
+func (m *MockFlatRepo) SearchFlats(ctx context.Context, search repository.FlatSearch) ([]*dto.DtoFlat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFlats", ctx, search)
+	ret0, _ := ret[0].([]*dto.DtoFlat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFlats indicates an expected call of SearchFlats.
+func (mr *MockFlatRepoMockRecorder) SearchFlats(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFlats", reflect.TypeOf((*MockFlatRepo)(nil).SearchFlats), ctx, search)
+}
+
+// StreamFlats mocks base method.
+func (m *MockFlatRepo) StreamFlats(ctx context.Context, houseID int, statuses []dto.Status, fn func(*dto.DtoFlat) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamFlats", ctx, houseID, statuses, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamFlats indicates an expected call of StreamFlats.
+func (mr *MockFlatRepoMockRecorder) StreamFlats(ctx, houseID, statuses, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamFlats", reflect.TypeOf((*MockFlatRepo)(nil).StreamFlats), ctx, houseID, statuses, fn)
+}
+
 // UpdateFlat mocks base method.
 func (m *MockFlatRepo) UpdateFlat(ctx context.Context, flat *dto.DtoFlat) (*dto.DtoFlat, error) {
 	m.ctrl.T.Helper()
@@ -124,17 +197,32 @@ func (m *MockHouseFlatRepo) EXPECT() *MockHouseFlatRepoMockRecorder {
 	return m.recorder
 }
 
-// UpdateHouse mocks base method.
-func (m *MockHouseFlatRepo) UpdateHouse(ctx context.Context, id int, updAt time.Time) (*dto.House, error) {
+// GetHouse mocks base method.
+func (m *MockHouseFlatRepo) GetHouse(ctx context.Context, id int) (*dto.House, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateHouse", ctx, id, updAt)
+	ret := m.ctrl.Call(m, "GetHouse", ctx, id)
 	ret0, _ := ret[0].(*dto.House)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateHouse indicates an expected call of UpdateHouse.
-func (mr *MockHouseFlatRepoMockRecorder) UpdateHouse(ctx, id, updAt any) *gomock.Call {
+// GetHouse indicates an expected call of GetHouse.
+func (mr *MockHouseFlatRepoMockRecorder) GetHouse(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouse", reflect.TypeOf((*MockHouseFlatRepo)(nil).GetHouse), ctx, id)
+}
+
+// IsHouseDeveloper mocks base method.
+func (m *MockHouseFlatRepo) IsHouseDeveloper(ctx context.Context, houseID int, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHouseDeveloper", ctx, houseID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsHouseDeveloper indicates an expected call of IsHouseDeveloper.
+func (mr *MockHouseFlatRepoMockRecorder) IsHouseDeveloper(ctx, houseID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHouse", reflect.TypeOf((*MockHouseFlatRepo)(nil).UpdateHouse), ctx, id, updAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHouseDeveloper", reflect.TypeOf((*MockHouseFlatRepo)(nil).IsHouseDeveloper), ctx, houseID, userID)
 }
@@ -0,0 +1,245 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./flat_media.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./flat_media.go -destination=./mocks/flat_media.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	dto "github.com/shhesterka04/house-service/internal/dto"
+	repository "github.com/shhesterka04/house-service/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFlatMediaRepo is a mock of FlatMediaRepo interface.
+type MockFlatMediaRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlatMediaRepoMockRecorder
+}
+
+// MockFlatMediaRepoMockRecorder is the mock recorder for MockFlatMediaRepo.
+type MockFlatMediaRepoMockRecorder struct {
+	mock *MockFlatMediaRepo
+}
+
+// NewMockFlatMediaRepo creates a new mock instance.
+func NewMockFlatMediaRepo(ctrl *gomock.Controller) *MockFlatMediaRepo {
+	mock := &MockFlatMediaRepo{ctrl: ctrl}
+	mock.recorder = &MockFlatMediaRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlatMediaRepo) EXPECT() *MockFlatMediaRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateFlatMedia mocks base method.
+func (m *MockFlatMediaRepo) CreateFlatMedia(ctx context.Context, media *repository.FlatMedia) (*repository.FlatMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFlatMedia", ctx, media)
+	ret0, _ := ret[0].(*repository.FlatMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFlatMedia indicates an expected call of CreateFlatMedia.
+func (mr *MockFlatMediaRepoMockRecorder) CreateFlatMedia(ctx, media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlatMedia", reflect.TypeOf((*MockFlatMediaRepo)(nil).CreateFlatMedia), ctx, media)
+}
+
+// DeleteFlatMedia mocks base method.
+func (m *MockFlatMediaRepo) DeleteFlatMedia(ctx context.Context, flatID, id int) (*repository.FlatMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlatMedia", ctx, flatID, id)
+	ret0, _ := ret[0].(*repository.FlatMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFlatMedia indicates an expected call of DeleteFlatMedia.
+func (mr *MockFlatMediaRepoMockRecorder) DeleteFlatMedia(ctx, flatID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlatMedia", reflect.TypeOf((*MockFlatMediaRepo)(nil).DeleteFlatMedia), ctx, flatID, id)
+}
+
+// GetFlatMedia mocks base method.
+func (m *MockFlatMediaRepo) GetFlatMedia(ctx context.Context, flatID, id int) (*repository.FlatMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlatMedia", ctx, flatID, id)
+	ret0, _ := ret[0].(*repository.FlatMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlatMedia indicates an expected call of GetFlatMedia.
+func (mr *MockFlatMediaRepoMockRecorder) GetFlatMedia(ctx, flatID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlatMedia", reflect.TypeOf((*MockFlatMediaRepo)(nil).GetFlatMedia), ctx, flatID, id)
+}
+
+// ListFlatMedia mocks base method.
+func (m *MockFlatMediaRepo) ListFlatMedia(ctx context.Context, flatID int) ([]*repository.FlatMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFlatMedia", ctx, flatID)
+	ret0, _ := ret[0].([]*repository.FlatMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFlatMedia indicates an expected call of ListFlatMedia.
+func (mr *MockFlatMediaRepoMockRecorder) ListFlatMedia(ctx, flatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlatMedia", reflect.TypeOf((*MockFlatMediaRepo)(nil).ListFlatMedia), ctx, flatID)
+}
+
+// MockMediaFlatRepo is a mock of MediaFlatRepo interface.
+type MockMediaFlatRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaFlatRepoMockRecorder
+}
+
+// MockMediaFlatRepoMockRecorder is the mock recorder for MockMediaFlatRepo.
+type MockMediaFlatRepoMockRecorder struct {
+	mock *MockMediaFlatRepo
+}
+
+// NewMockMediaFlatRepo creates a new mock instance.
+func NewMockMediaFlatRepo(ctrl *gomock.Controller) *MockMediaFlatRepo {
+	mock := &MockMediaFlatRepo{ctrl: ctrl}
+	mock.recorder = &MockMediaFlatRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaFlatRepo) EXPECT() *MockMediaFlatRepoMockRecorder {
+	return m.recorder
+}
+
+// GetFlatByID mocks base method.
+func (m *MockMediaFlatRepo) GetFlatByID(ctx context.Context, id int) (*dto.DtoFlat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlatByID", ctx, id)
+	ret0, _ := ret[0].(*dto.DtoFlat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlatByID indicates an expected call of GetFlatByID.
+func (mr *MockMediaFlatRepoMockRecorder) GetFlatByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlatByID", reflect.TypeOf((*MockMediaFlatRepo)(nil).GetFlatByID), ctx, id)
+}
+
+// MockHouseOwnerRepo is a mock of HouseOwnerRepo interface.
+type MockHouseOwnerRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHouseOwnerRepoMockRecorder
+}
+
+// MockHouseOwnerRepoMockRecorder is the mock recorder for MockHouseOwnerRepo.
+type MockHouseOwnerRepoMockRecorder struct {
+	mock *MockHouseOwnerRepo
+}
+
+// NewMockHouseOwnerRepo creates a new mock instance.
+func NewMockHouseOwnerRepo(ctrl *gomock.Controller) *MockHouseOwnerRepo {
+	mock := &MockHouseOwnerRepo{ctrl: ctrl}
+	mock.recorder = &MockHouseOwnerRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHouseOwnerRepo) EXPECT() *MockHouseOwnerRepoMockRecorder {
+	return m.recorder
+}
+
+// IsHouseDeveloper mocks base method.
+func (m *MockHouseOwnerRepo) IsHouseDeveloper(ctx context.Context, houseID int, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHouseDeveloper", ctx, houseID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsHouseDeveloper indicates an expected call of IsHouseDeveloper.
+func (mr *MockHouseOwnerRepoMockRecorder) IsHouseDeveloper(ctx, houseID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHouseDeveloper", reflect.TypeOf((*MockHouseOwnerRepo)(nil).IsHouseDeveloper), ctx, houseID, userID)
+}
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), ctx, key)
+}
+
+// Open mocks base method.
+func (m *MockBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockBlobStoreMockRecorder) Open(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockBlobStore)(nil).Open), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r, size, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(ctx, key, r, size, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), ctx, key, r, size, contentType)
+}
@@ -54,3 +54,62 @@ func (mr *MockHouseRepoMockRecorder) CreateHouse(ctx, house any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHouse", reflect.TypeOf((*MockHouseRepo)(nil).CreateHouse), ctx, house)
 }
+
+// NearbyHouses mocks base method.
+func (m *MockHouseRepo) NearbyHouses(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]dto.HouseNearby, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NearbyHouses", ctx, lat, lon, radiusKm, limit)
+	ret0, _ := ret[0].([]dto.HouseNearby)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NearbyHouses indicates an expected call of NearbyHouses.
+func (mr *MockHouseRepoMockRecorder) NearbyHouses(ctx, lat, lon, radiusKm, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NearbyHouses", reflect.TypeOf((*MockHouseRepo)(nil).NearbyHouses), ctx, lat, lon, radiusKm, limit)
+}
+
+// SearchHouses mocks base method.
+func (m *MockHouseRepo) SearchHouses(ctx context.Context, query, city string, limit int) ([]dto.HouseMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchHouses", ctx, query, city, limit)
+	ret0, _ := ret[0].([]dto.HouseMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchHouses indicates an expected call of SearchHouses.
+func (mr *MockHouseRepoMockRecorder) SearchHouses(ctx, query, city, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchHouses", reflect.TypeOf((*MockHouseRepo)(nil).SearchHouses), ctx, query, city, limit)
+}
+
+// SimilarHouses mocks base method.
+func (m *MockHouseRepo) SimilarHouses(ctx context.Context, address string, minScore float32, limit int) ([]dto.HouseMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimilarHouses", ctx, address, minScore, limit)
+	ret0, _ := ret[0].([]dto.HouseMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimilarHouses indicates an expected call of SimilarHouses.
+func (mr *MockHouseRepoMockRecorder) SimilarHouses(ctx, address, minScore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimilarHouses", reflect.TypeOf((*MockHouseRepo)(nil).SimilarHouses), ctx, address, minScore, limit)
+}
+
+// Subscribe mocks base method.
+func (m *MockHouseRepo) Subscribe(ctx context.Context, houseID int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, houseID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockHouseRepoMockRecorder) Subscribe(ctx, houseID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockHouseRepo)(nil).Subscribe), ctx, houseID, email)
+}
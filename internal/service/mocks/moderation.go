@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./moderation.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./moderation.go -destination=./mocks/moderation.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shhesterka04/house-service/internal/dto"
+	repository "github.com/shhesterka04/house-service/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModerationRepo is a mock of ModerationRepo interface.
+type MockModerationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationRepoMockRecorder
+}
+
+// MockModerationRepoMockRecorder is the mock recorder for MockModerationRepo.
+type MockModerationRepoMockRecorder struct {
+	mock *MockModerationRepo
+}
+
+// NewMockModerationRepo creates a new mock instance.
+func NewMockModerationRepo(ctrl *gomock.Controller) *MockModerationRepo {
+	mock := &MockModerationRepo{ctrl: ctrl}
+	mock.recorder = &MockModerationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationRepo) EXPECT() *MockModerationRepoMockRecorder {
+	return m.recorder
+}
+
+// DeclineReasons mocks base method.
+func (m *MockModerationRepo) DeclineReasons(ctx context.Context) ([]dto.DeclineReason, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineReasons", ctx)
+	ret0, _ := ret[0].([]dto.DeclineReason)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineReasons indicates an expected call of DeclineReasons.
+func (mr *MockModerationRepoMockRecorder) DeclineReasons(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineReasons", reflect.TypeOf((*MockModerationRepo)(nil).DeclineReasons), ctx)
+}
+
+// FlatDecisions mocks base method.
+func (m *MockModerationRepo) FlatDecisions(ctx context.Context, flatID int) ([]*repository.ModerationDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlatDecisions", ctx, flatID)
+	ret0, _ := ret[0].([]*repository.ModerationDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlatDecisions indicates an expected call of FlatDecisions.
+func (mr *MockModerationRepoMockRecorder) FlatDecisions(ctx, flatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlatDecisions", reflect.TypeOf((*MockModerationRepo)(nil).FlatDecisions), ctx, flatID)
+}
+
+// ModerationQueue mocks base method.
+func (m *MockModerationRepo) ModerationQueue(ctx context.Context, filter repository.ModerationQueueFilter) ([]*dto.ModerationQueueItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerationQueue", ctx, filter)
+	ret0, _ := ret[0].([]*dto.ModerationQueueItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerationQueue indicates an expected call of ModerationQueue.
+func (mr *MockModerationRepoMockRecorder) ModerationQueue(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerationQueue", reflect.TypeOf((*MockModerationRepo)(nil).ModerationQueue), ctx, filter)
+}
+
+// ModerationQueueCounts mocks base method.
+func (m *MockModerationRepo) ModerationQueueCounts(ctx context.Context, filter repository.ModerationQueueFilter) (map[dto.Status]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerationQueueCounts", ctx, filter)
+	ret0, _ := ret[0].(map[dto.Status]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerationQueueCounts indicates an expected call of ModerationQueueCounts.
+func (mr *MockModerationRepoMockRecorder) ModerationQueueCounts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerationQueueCounts", reflect.TypeOf((*MockModerationRepo)(nil).ModerationQueueCounts), ctx, filter)
+}
+
+// SaveDeclineReason mocks base method.
+func (m *MockModerationRepo) SaveDeclineReason(ctx context.Context, reason dto.DeclineReason) (*dto.DeclineReason, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeclineReason", ctx, reason)
+	ret0, _ := ret[0].(*dto.DeclineReason)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveDeclineReason indicates an expected call of SaveDeclineReason.
+func (mr *MockModerationRepoMockRecorder) SaveDeclineReason(ctx, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeclineReason", reflect.TypeOf((*MockModerationRepo)(nil).SaveDeclineReason), ctx, reason)
+}
+
+// MockModerationFlatRepo is a mock of ModerationFlatRepo interface.
+type MockModerationFlatRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationFlatRepoMockRecorder
+}
+
+// MockModerationFlatRepoMockRecorder is the mock recorder for MockModerationFlatRepo.
+type MockModerationFlatRepoMockRecorder struct {
+	mock *MockModerationFlatRepo
+}
+
+// NewMockModerationFlatRepo creates a new mock instance.
+func NewMockModerationFlatRepo(ctrl *gomock.Controller) *MockModerationFlatRepo {
+	mock := &MockModerationFlatRepo{ctrl: ctrl}
+	mock.recorder = &MockModerationFlatRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationFlatRepo) EXPECT() *MockModerationFlatRepoMockRecorder {
+	return m.recorder
+}
+
+// GetFlatByID mocks base method.
+func (m *MockModerationFlatRepo) GetFlatByID(ctx context.Context, id int) (*dto.DtoFlat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlatByID", ctx, id)
+	ret0, _ := ret[0].(*dto.DtoFlat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlatByID indicates an expected call of GetFlatByID.
+func (mr *MockModerationFlatRepoMockRecorder) GetFlatByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlatByID", reflect.TypeOf((*MockModerationFlatRepo)(nil).GetFlatByID), ctx, id)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./users.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./users.go -destination=./mocks/users.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/shhesterka04/house-service/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserAdminRepo is a mock of UserAdminRepo interface.
+type MockUserAdminRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAdminRepoMockRecorder
+}
+
+// MockUserAdminRepoMockRecorder is the mock recorder for MockUserAdminRepo.
+type MockUserAdminRepoMockRecorder struct {
+	mock *MockUserAdminRepo
+}
+
+// NewMockUserAdminRepo creates a new mock instance.
+func NewMockUserAdminRepo(ctrl *gomock.Controller) *MockUserAdminRepo {
+	mock := &MockUserAdminRepo{ctrl: ctrl}
+	mock.recorder = &MockUserAdminRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAdminRepo) EXPECT() *MockUserAdminRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserAdminRepo) GetUserByID(ctx context.Context, id string) (repository.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(repository.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserAdminRepoMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserAdminRepo)(nil).GetUserByID), ctx, id)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockUserAdminRepo) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", ctx, id, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockUserAdminRepoMockRecorder) SetUserDisabled(ctx, id, disabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockUserAdminRepo)(nil).SetUserDisabled), ctx, id, disabled)
+}
+
+// UpdateUserType mocks base method.
+func (m *MockUserAdminRepo) UpdateUserType(ctx context.Context, id, userType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserType", ctx, id, userType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserType indicates an expected call of UpdateUserType.
+func (mr *MockUserAdminRepoMockRecorder) UpdateUserType(ctx, id, userType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserType", reflect.TypeOf((*MockUserAdminRepo)(nil).UpdateUserType), ctx, id, userType)
+}
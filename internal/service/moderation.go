@@ -0,0 +1,264 @@
+//go:generate mockgen -source ./moderation.go -destination=./mocks/moderation.go -package=mocks
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+const maxDeclineReasonTitleLen = 255
+
+var (
+	// pendingStatuses are the statuses of flats waiting for a moderator.
+	pendingStatuses = []dto.Status{dto.Created, dto.OnModeration}
+
+	declineReasonCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,63}$`)
+)
+
+type ModerationRepo interface {
+	ModerationQueue(ctx context.Context, filter repository.ModerationQueueFilter) ([]*dto.ModerationQueueItem, error)
+	ModerationQueueCounts(ctx context.Context, filter repository.ModerationQueueFilter) (map[dto.Status]int, error)
+	DeclineReasons(ctx context.Context) ([]dto.DeclineReason, error)
+	SaveDeclineReason(ctx context.Context, reason dto.DeclineReason) (*dto.DeclineReason, error)
+	FlatDecisions(ctx context.Context, flatID int) ([]*repository.ModerationDecision, error)
+}
+
+type ModerationFlatRepo interface {
+	GetFlatByID(ctx context.Context, id int) (*dto.DtoFlat, error)
+}
+
+type ModerationService struct {
+	moderationRepo ModerationRepo
+	flatRepo       ModerationFlatRepo
+}
+
+func NewModerationService(moderationRepo ModerationRepo, flatRepo ModerationFlatRepo) *ModerationService {
+	return &ModerationService{
+		moderationRepo: moderationRepo,
+		flatRepo:       flatRepo,
+	}
+}
+
+// moderationQueueCursor is the opaque position handed to clients as next_cursor.
+type moderationQueueCursor struct {
+	QueuedAt time.Time `json:"t"`
+	ID       int       `json:"i"`
+}
+
+// Queue returns a page of flats waiting for moderation across all houses, the longest waiting first.
+func (s *ModerationService) Queue(ctx context.Context, params dto.GetModerationQueueParams) (*dto.ModerationQueue, error) {
+	if err := Authorize(ctx, policy.FlatModerate); err != nil {
+		return nil, err
+	}
+
+	if err := validateModerationQueue(params); err != nil {
+		return nil, err
+	}
+
+	filter := repository.ModerationQueueFilter{
+		HouseID:     params.HouseId,
+		DeveloperID: params.DeveloperId,
+		Statuses:    pendingStatuses,
+		Limit:       DefaultSearchLimit,
+	}
+	if params.Status != nil {
+		filter.Statuses = []dto.Status{*params.Status}
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	if params.Cursor != nil {
+		after, err := decodeModerationQueueCursor(*params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	counts, err := s.moderationRepo.ModerationQueueCounts(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "moderation queue counts")
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	items, err := s.moderationRepo.ModerationQueue(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "moderation queue")
+	}
+
+	queue := &dto.ModerationQueue{
+		Flats: items,
+		Counts: dto.ModerationQueueCounts{
+			Created:      counts[dto.Created],
+			OnModeration: counts[dto.OnModeration],
+		},
+	}
+	if queue.Flats == nil {
+		queue.Flats = []*dto.ModerationQueueItem{}
+	}
+
+	if len(items) > limit {
+		queue.Flats = items[:limit]
+		cursor := encodeModerationQueueCursor(items[limit-1])
+		queue.NextCursor = &cursor
+	}
+
+	return queue, nil
+}
+
+func validateModerationQueue(params dto.GetModerationQueueParams) error {
+	var details []dto.ErrorDetail
+
+	if params.HouseId != nil && *params.HouseId <= 0 {
+		details = append(details, dto.ErrorDetail{Field: "house_id", Rule: RulePositive, Message: "house_id must be positive"})
+	}
+
+	if params.DeveloperId != nil && *params.DeveloperId <= 0 {
+		details = append(details, dto.ErrorDetail{Field: "developer_id", Rule: RulePositive, Message: "developer_id must be positive"})
+	}
+
+	if params.Status != nil && !containsStatus(pendingStatuses, *params.Status) {
+		details = append(details, dto.ErrorDetail{Field: "status", Rule: ruleEnum, Message: fmt.Sprintf("status must be %q or %q", dto.Created, dto.OnModeration)})
+	}
+
+	if params.Limit != nil && (*params.Limit < 1 || *params.Limit > MaxSearchLimit) {
+		details = append(details, dto.ErrorDetail{Field: "limit", Rule: ruleRange, Message: fmt.Sprintf("limit must be between 1 and %d", MaxSearchLimit)})
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
+
+func encodeModerationQueueCursor(item *dto.ModerationQueueItem) string {
+	b, _ := json.Marshal(moderationQueueCursor{QueuedAt: item.QueuedAt, ID: item.ID})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeModerationQueueCursor(s string) (*repository.QueueCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor moderationQueueCursor
+	if err = json.Unmarshal(b, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repository.QueueCursor{QueuedAt: cursor.QueuedAt, ID: cursor.ID}, nil
+}
+
+// DeclineReasons returns the catalog of decline reasons, inactive ones included.
+func (s *ModerationService) DeclineReasons(ctx context.Context) ([]dto.DeclineReason, error) {
+	if err := Authorize(ctx, policy.FlatModerate); err != nil {
+		return nil, err
+	}
+
+	reasons, err := s.moderationRepo.DeclineReasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if reasons == nil {
+		reasons = []dto.DeclineReason{}
+	}
+
+	return reasons, nil
+}
+
+// SaveDeclineReason adds a reason to the catalog or changes an existing one. Reasons are deactivated
+// rather than deleted, past decisions keep referring to them.
+func (s *ModerationService) SaveDeclineReason(ctx context.Context, code string, req dto.PutModerationDeclineReasonsCodeJSONRequestBody) (*dto.DeclineReason, error) {
+	if err := Authorize(ctx, policy.DeclineReasonManage); err != nil {
+		return nil, err
+	}
+
+	reason := dto.DeclineReason{Code: code, Title: strings.TrimSpace(req.Title), Active: true}
+	if req.Active != nil {
+		reason.Active = *req.Active
+	}
+
+	var details []dto.ErrorDetail
+	if !declineReasonCodePattern.MatchString(reason.Code) {
+		details = append(details, dto.ErrorDetail{Field: "code", Rule: rulePattern, Message: "code must be 2 to 64 lowercase letters, digits or underscores starting with a letter"})
+	}
+	if reason.Title == "" {
+		details = append(details, dto.ErrorDetail{Field: "title", Rule: RuleRequired, Message: "title is required"})
+	} else if len([]rune(reason.Title)) > maxDeclineReasonTitleLen {
+		details = append(details, dto.ErrorDetail{Field: "title", Rule: RuleMaxLength, Message: fmt.Sprintf("title must be at most %d characters long", maxDeclineReasonTitleLen)})
+	}
+	if len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+
+	return s.moderationRepo.SaveDeclineReason(ctx, reason)
+}
+
+// FlatDecisions returns the moderation decisions on the flat, latest first. Besides moderators only the
+// user who created the flat sees them, to anyone else the flat does not exist.
+func (s *ModerationService) FlatDecisions(ctx context.Context, flatIDStr string) ([]dto.ModerationDecision, error) {
+	if err := Authorize(ctx, policy.FlatRead); err != nil {
+		return nil, err
+	}
+
+	flatID, err := strconv.Atoi(flatIDStr)
+	if err != nil || flatID <= 0 {
+		return nil, ErrInvalidFlatID
+	}
+
+	if Authorize(ctx, policy.FlatModerate) != nil {
+		flat, err := s.flatRepo.GetFlatByID(ctx, flatID)
+		if err != nil {
+			return nil, err
+		}
+
+		account := callerAccountID(ctx)
+		if account == nil || flat.CreatedBy == nil || *flat.CreatedBy != *account {
+			return nil, repository.ErrFlatNotFound
+		}
+	}
+
+	decisions, err := s.moderationRepo.FlatDecisions(ctx, flatID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ModerationDecision, 0, len(decisions))
+	for _, d := range decisions {
+		decision := dto.ModerationDecision{
+			Id:        d.ID,
+			FlatId:    d.FlatID,
+			Status:    d.Status,
+			Reason:    d.Reason,
+			Comment:   d.Comment,
+			CreatedAt: d.CreatedAt,
+		}
+		if d.ModeratorID != nil {
+			id, err := uuid.Parse(*d.ModeratorID)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse moderator ID")
+			}
+			decision.ModeratorId = &id
+		}
+		result = append(result, decision)
+	}
+
+	return result, nil
+}
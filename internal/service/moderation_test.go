@@ -0,0 +1,326 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestModerationService_Queue(t *testing.T) {
+	houseID := 7
+	onModeration := dto.OnModeration
+	approved := dto.Approved
+
+	tests := []struct {
+		name       string
+		role       dto.UserType
+		params     dto.GetModerationQueueParams
+		mockSetup  func(m *mocks.MockModerationRepo)
+		wantLen    int
+		wantCounts dto.ModerationQueueCounts
+		wantErr    error
+	}{
+		{
+			name:   "moderator sees both pending statuses",
+			role:   dto.Moderator,
+			params: dto.GetModerationQueueParams{HouseId: &houseID},
+			mockSetup: func(m *mocks.MockModerationRepo) {
+				filter := repository.ModerationQueueFilter{
+					HouseID:  &houseID,
+					Statuses: []dto.Status{dto.Created, dto.OnModeration},
+					Limit:    service.DefaultSearchLimit,
+				}
+				m.EXPECT().ModerationQueueCounts(gomock.Any(), filter).Return(map[dto.Status]int{dto.Created: 3, dto.OnModeration: 1}, nil).Times(1)
+				filter.Limit++
+				m.EXPECT().ModerationQueue(gomock.Any(), filter).Return([]*dto.ModerationQueueItem{{}, {}}, nil).Times(1)
+			},
+			wantLen:    2,
+			wantCounts: dto.ModerationQueueCounts{Created: 3, OnModeration: 1},
+		},
+		{
+			name:   "moderator filters by status",
+			role:   dto.Moderator,
+			params: dto.GetModerationQueueParams{Status: &onModeration},
+			mockSetup: func(m *mocks.MockModerationRepo) {
+				m.EXPECT().ModerationQueueCounts(gomock.Any(), gomock.Any()).Return(map[dto.Status]int{}, nil).Times(1)
+				m.EXPECT().ModerationQueue(gomock.Any(), repository.ModerationQueueFilter{
+					Statuses: []dto.Status{dto.OnModeration},
+					Limit:    service.DefaultSearchLimit + 1,
+				}).Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name:      "client can not see the queue",
+			role:      dto.Client,
+			mockSetup: func(m *mocks.MockModerationRepo) {},
+			wantErr:   service.ErrForbidden,
+		},
+		{
+			name:      "status outside the queue",
+			role:      dto.Moderator,
+			params:    dto.GetModerationQueueParams{Status: &approved},
+			mockSetup: func(m *mocks.MockModerationRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+		{
+			name:      "limit out of range",
+			role:      dto.Moderator,
+			params:    dto.GetModerationQueueParams{Limit: ptr(service.MaxSearchLimit + 1)},
+			mockSetup: func(m *mocks.MockModerationRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+		{
+			name:      "invalid cursor",
+			role:      dto.Moderator,
+			params:    dto.GetModerationQueueParams{Cursor: ptr("not-a-cursor")},
+			mockSetup: func(m *mocks.MockModerationRepo) {},
+			wantErr:   service.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockModerationRepo := mocks.NewMockModerationRepo(ctrl)
+			tt.mockSetup(mockModerationRepo)
+
+			moderationService := service.NewModerationService(mockModerationRepo, mocks.NewMockModerationFlatRepo(ctrl))
+			result, err := moderationService.Queue(ctxWithRole(tt.role), tt.params)
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+				assert.Len(t, result.Flats, tt.wantLen)
+				assert.NotNil(t, result.Flats)
+				assert.Equal(t, tt.wantCounts, result.Counts)
+				assert.Nil(t, result.NextCursor)
+			case *service.ValidationError:
+				require.ErrorAs(t, err, &want)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModerationService_QueuePagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limit := 2
+	queuedAt := time.Date(2024, 8, 28, 10, 0, 0, 0, time.UTC)
+	item := func(id int, queued time.Time) *dto.ModerationQueueItem {
+		return &dto.ModerationQueueItem{DtoFlat: dto.DtoFlat{ID: id}, QueuedAt: queued}
+	}
+
+	mockModerationRepo := mocks.NewMockModerationRepo(ctrl)
+	mockModerationRepo.EXPECT().ModerationQueueCounts(gomock.Any(), gomock.Any()).Return(map[dto.Status]int{dto.Created: 3}, nil).Times(2)
+	first := mockModerationRepo.EXPECT().ModerationQueue(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, filter repository.ModerationQueueFilter) ([]*dto.ModerationQueueItem, error) {
+			assert.Nil(t, filter.After)
+			assert.Equal(t, limit+1, filter.Limit)
+			return []*dto.ModerationQueueItem{item(4, queuedAt), item(2, queuedAt.Add(time.Minute)), item(9, queuedAt.Add(time.Hour))}, nil
+		}).Times(1)
+	mockModerationRepo.EXPECT().ModerationQueue(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, filter repository.ModerationQueueFilter) ([]*dto.ModerationQueueItem, error) {
+			require.NotNil(t, filter.After)
+			assert.Equal(t, 2, filter.After.ID)
+			assert.True(t, queuedAt.Add(time.Minute).Equal(filter.After.QueuedAt))
+			return []*dto.ModerationQueueItem{item(9, queuedAt.Add(time.Hour))}, nil
+		}).After(first).Times(1)
+
+	moderationService := service.NewModerationService(mockModerationRepo, mocks.NewMockModerationFlatRepo(ctrl))
+	ctx := ctxWithRole(dto.Moderator)
+
+	page, err := moderationService.Queue(ctx, dto.GetModerationQueueParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, page.Flats, 2)
+	require.NotNil(t, page.NextCursor)
+
+	page, err = moderationService.Queue(ctx, dto.GetModerationQueueParams{Limit: &limit, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Flats, 1)
+	assert.Nil(t, page.NextCursor)
+	assert.Equal(t, 3, page.Counts.Created)
+}
+
+func TestModerationService_FlatDecisions(t *testing.T) {
+	otherUserID := "0b7c4d2e-8a1f-4c3b-9e5d-6f7a8b9c0d1e"
+	decidedAt := time.Date(2024, 8, 29, 12, 0, 0, 0, time.UTC)
+	decisions := []*repository.ModerationDecision{{
+		ID:          2,
+		FlatID:      1,
+		Status:      dto.Declined,
+		Reason:      &dto.DeclineReason{Code: "wrong_price", Title: "Цена не соответствует рынку", Active: true},
+		Comment:     ptr("check the price"),
+		ModeratorID: ptr(testUserID),
+		CreatedAt:   decidedAt,
+	}}
+
+	tests := []struct {
+		name      string
+		role      dto.UserType
+		flatID    string
+		mockSetup func(m *mocks.MockModerationRepo, f *mocks.MockModerationFlatRepo)
+		wantLen   int
+		wantErr   error
+	}{
+		{
+			name:   "moderator sees decisions on any flat",
+			role:   dto.Moderator,
+			flatID: "1",
+			mockSetup: func(m *mocks.MockModerationRepo, f *mocks.MockModerationFlatRepo) {
+				m.EXPECT().FlatDecisions(gomock.Any(), 1).Return(decisions, nil).Times(1)
+			},
+			wantLen: 1,
+		},
+		{
+			name:   "creator sees decisions on own flat",
+			role:   dto.DeveloperUser,
+			flatID: "1",
+			mockSetup: func(m *mocks.MockModerationRepo, f *mocks.MockModerationFlatRepo) {
+				f.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1, CreatedBy: ptr(testUserID)}, nil).Times(1)
+				m.EXPECT().FlatDecisions(gomock.Any(), 1).Return(decisions, nil).Times(1)
+			},
+			wantLen: 1,
+		},
+		{
+			name:   "flat of another user is not found",
+			role:   dto.Client,
+			flatID: "1",
+			mockSetup: func(m *mocks.MockModerationRepo, f *mocks.MockModerationFlatRepo) {
+				f.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1, CreatedBy: &otherUserID}, nil).Times(1)
+			},
+			wantErr: repository.ErrFlatNotFound,
+		},
+		{
+			name:   "flat without creator is not found",
+			role:   dto.Client,
+			flatID: "1",
+			mockSetup: func(m *mocks.MockModerationRepo, f *mocks.MockModerationFlatRepo) {
+				f.EXPECT().GetFlatByID(gomock.Any(), 1).Return(&dto.DtoFlat{ID: 1}, nil).Times(1)
+			},
+			wantErr: repository.ErrFlatNotFound,
+		},
+		{
+			name:      "invalid flat ID",
+			role:      dto.Moderator,
+			flatID:    "abc",
+			mockSetup: func(m *mocks.MockModerationRepo, f *mocks.MockModerationFlatRepo) {},
+			wantErr:   service.ErrInvalidFlatID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockModerationRepo := mocks.NewMockModerationRepo(ctrl)
+			mockFlatRepo := mocks.NewMockModerationFlatRepo(ctrl)
+			tt.mockSetup(mockModerationRepo, mockFlatRepo)
+
+			moderationService := service.NewModerationService(mockModerationRepo, mockFlatRepo)
+			result, err := moderationService.FlatDecisions(ctxWithRole(tt.role), tt.flatID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result, tt.wantLen)
+			assert.Equal(t, dto.Declined, result[0].Status)
+			assert.Equal(t, decisions[0].Reason, result[0].Reason)
+			require.NotNil(t, result[0].ModeratorId)
+			assert.Equal(t, testUserID, result[0].ModeratorId.String())
+			assert.Equal(t, decidedAt, result[0].CreatedAt)
+		})
+	}
+}
+
+func TestModerationService_SaveDeclineReason(t *testing.T) {
+	tests := []struct {
+		name      string
+		role      dto.UserType
+		code      string
+		req       dto.PutModerationDeclineReasonsCodeJSONRequestBody
+		mockSetup func(m *mocks.MockModerationRepo)
+		wantErr   error
+	}{
+		{
+			name: "admin adds an active reason",
+			role: dto.Admin,
+			code: "bad_photos",
+			req:  dto.PutModerationDeclineReasonsCodeJSONRequestBody{Title: " Плохие фотографии "},
+			mockSetup: func(m *mocks.MockModerationRepo) {
+				reason := dto.DeclineReason{Code: "bad_photos", Title: "Плохие фотографии", Active: true}
+				m.EXPECT().SaveDeclineReason(gomock.Any(), reason).Return(&reason, nil).Times(1)
+			},
+		},
+		{
+			name: "admin deactivates a reason",
+			role: dto.Admin,
+			code: "other",
+			req:  dto.PutModerationDeclineReasonsCodeJSONRequestBody{Title: "Другая причина", Active: ptr(false)},
+			mockSetup: func(m *mocks.MockModerationRepo) {
+				reason := dto.DeclineReason{Code: "other", Title: "Другая причина"}
+				m.EXPECT().SaveDeclineReason(gomock.Any(), reason).Return(&reason, nil).Times(1)
+			},
+		},
+		{
+			name:      "moderator can not change the catalog",
+			role:      dto.Moderator,
+			code:      "other",
+			req:       dto.PutModerationDeclineReasonsCodeJSONRequestBody{Title: "Другая причина"},
+			mockSetup: func(m *mocks.MockModerationRepo) {},
+			wantErr:   service.ErrForbidden,
+		},
+		{
+			name:      "invalid code and empty title",
+			role:      dto.Admin,
+			code:      "Bad Code",
+			req:       dto.PutModerationDeclineReasonsCodeJSONRequestBody{Title: " "},
+			mockSetup: func(m *mocks.MockModerationRepo) {},
+			wantErr:   &service.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockModerationRepo := mocks.NewMockModerationRepo(ctrl)
+			tt.mockSetup(mockModerationRepo)
+
+			moderationService := service.NewModerationService(mockModerationRepo, mocks.NewMockModerationFlatRepo(ctrl))
+			_, err := moderationService.SaveDeclineReason(ctxWithRole(tt.role), tt.code, tt.req)
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+			case *service.ValidationError:
+				require.ErrorAs(t, err, &want)
+				assert.Len(t, want.Details, 2)
+			default:
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
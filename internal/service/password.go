@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+)
+
+// bcryptMaxLength is the number of bytes bcrypt actually hashes, everything after it is ignored.
+const bcryptMaxLength = 72
+
+const (
+	RuleRequired      = "required"
+	RuleMinLength     = "min_length"
+	RuleMaxLength     = "max_length"
+	RuleUpper         = "uppercase"
+	RuleLower         = "lowercase"
+	RuleDigit         = "digit"
+	RuleSpecial       = "special"
+	RuleNotEmail      = "not_email"
+	RuleNotBreached   = "not_breached"
+	passwordFieldName = "password"
+)
+
+//go:embed passwords/common_sha1.txt
+var bundledBreachedPasswords []byte
+
+// ValidationError lists every rule a request violated, so the client can fix all of them at once.
+type ValidationError struct {
+	Details []dto.ErrorDetail
+}
+
+func (e *ValidationError) Error() string {
+	rules := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		rules = append(rules, d.Rule)
+	}
+
+	return "validation failed: " + strings.Join(rules, ", ")
+}
+
+type PasswordPolicy struct {
+	MinLength      int
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	// Breached holds upper-case hex SHA-1 hashes of passwords that must not be used.
+	Breached map[string]struct{}
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	breached, _ := parseBreachedPasswords(bytes.NewReader(bundledBreachedPasswords))
+
+	return PasswordPolicy{
+		MinLength:    8,
+		MaxLength:    bcryptMaxLength,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+		Breached:     breached,
+	}
+}
+
+// LoadBreachedPasswords reads SHA-1 hashes (one per line, optionally followed by ":count" as in
+// the HIBP dumps) from path. An empty path returns the list bundled with the service.
+func LoadBreachedPasswords(path string) (map[string]struct{}, error) {
+	if path == "" {
+		return parseBreachedPasswords(bytes.NewReader(bundledBreachedPasswords))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open breached passwords list")
+	}
+	defer f.Close()
+
+	return parseBreachedPasswords(f)
+}
+
+func parseBreachedPasswords(r io.Reader) (map[string]struct{}, error) {
+	hashes := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		hashes[strings.ToUpper(hash)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read breached passwords list")
+	}
+
+	return hashes, nil
+}
+
+// Validate returns a *ValidationError describing every violated rule, or nil if the password is acceptable.
+func (p PasswordPolicy) Validate(password, email string) error {
+	if password == "" {
+		return &ValidationError{Details: []dto.ErrorDetail{passwordViolation(RuleRequired, "password is required")}}
+	}
+
+	var details []dto.ErrorDetail
+
+	if n := len([]rune(password)); p.MinLength > 0 && n < p.MinLength {
+		details = append(details, passwordViolation(RuleMinLength, fmt.Sprintf("password must be at least %d characters long", p.MinLength)))
+	}
+
+	maxLength := p.MaxLength
+	if maxLength <= 0 || maxLength > bcryptMaxLength {
+		maxLength = bcryptMaxLength
+	}
+	if len(password) > maxLength {
+		details = append(details, passwordViolation(RuleMaxLength, fmt.Sprintf("password must not be longer than %d bytes", maxLength)))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			hasUpper = true
+		case unicode.IsLower(c):
+			hasLower = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c) || unicode.IsSpace(c):
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		details = append(details, passwordViolation(RuleUpper, "password must contain an upper-case letter"))
+	}
+	if p.RequireLower && !hasLower {
+		details = append(details, passwordViolation(RuleLower, "password must contain a lower-case letter"))
+	}
+	if p.RequireDigit && !hasDigit {
+		details = append(details, passwordViolation(RuleDigit, "password must contain a digit"))
+	}
+	if p.RequireSpecial && !hasSpecial {
+		details = append(details, passwordViolation(RuleSpecial, "password must contain a special character"))
+	}
+
+	if email != "" {
+		local, _, _ := strings.Cut(email, "@")
+		if strings.EqualFold(password, email) || strings.EqualFold(password, local) {
+			details = append(details, passwordViolation(RuleNotEmail, "password must not match the email"))
+		}
+	}
+
+	if _, ok := p.Breached[sha1Hex(password)]; ok {
+		details = append(details, passwordViolation(RuleNotBreached, "password is too common or has appeared in a data breach"))
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Details: details}
+	}
+
+	return nil
+}
+
+func passwordViolation(rule, message string) dto.ErrorDetail {
+	return dto.ErrorDetail{Field: passwordFieldName, Rule: rule, Message: message}
+}
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,92 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := service.DefaultPasswordPolicy()
+	policy.RequireSpecial = true
+
+	tests := []struct {
+		name      string
+		password  string
+		email     string
+		wantRules []string
+	}{
+		{
+			name:      "valid password",
+			password:  "Str0ng!House",
+			email:     "test@example.com",
+			wantRules: nil,
+		},
+		{
+			name:      "empty password",
+			password:  "",
+			email:     "test@example.com",
+			wantRules: []string{service.RuleRequired},
+		},
+		{
+			name:      "every class violated",
+			password:  "1",
+			email:     "test@example.com",
+			wantRules: []string{service.RuleMinLength, service.RuleUpper, service.RuleLower, service.RuleSpecial},
+		},
+		{
+			name:      "longer than bcrypt limit",
+			password:  "Aa1!" + strings.Repeat("x", 70),
+			email:     "test@example.com",
+			wantRules: []string{service.RuleMaxLength},
+		},
+		{
+			name:      "email as password",
+			password:  "Test1!@Example.com",
+			email:     "test1!@example.com",
+			wantRules: []string{service.RuleNotEmail},
+		},
+		{
+			name:      "breached password",
+			password:  "P@ssw0rd",
+			email:     "test@example.com",
+			wantRules: []string{service.RuleNotBreached},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := policy.Validate(tt.password, tt.email)
+
+			if tt.wantRules == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *service.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+
+			var rules []string
+			for _, d := range validationErr.Details {
+				rules = append(rules, d.Rule)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func TestLoadBreachedPasswords(t *testing.T) {
+	hashes, err := service.LoadBreachedPasswords("")
+	require.NoError(t, err)
+	assert.NotEmpty(t, hashes)
+
+	_, err = service.LoadBreachedPasswords("does-not-exist.txt")
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	thumbnailSide    = 320
+	thumbnailQuality = 80
+)
+
+// thumbnail scales img down to fit a thumbnailSide square, keeping the aspect ratio, and encodes it as JPEG.
+// Every target pixel is the average of the source pixels it covers, which keeps the result sharp without an
+// imaging dependency. Transparent areas become white and images that already fit are not enlarged. The source
+// is converted a band of rows at a time, so no full-size copy of it is made.
+func thumbnail(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return nil, errors.New("empty image")
+	}
+
+	tw, th := w, h
+	switch {
+	case w >= h && w > thumbnailSide:
+		tw, th = thumbnailSide, max(1, h*thumbnailSide/w)
+	case h > w && h > thumbnailSide:
+		tw, th = max(1, w*thumbnailSide/h), thumbnailSide
+	}
+
+	// A target row covers at most this many source rows.
+	band := image.NewRGBA(image.Rect(0, 0, w, (h+th-1)/th+1))
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := y*h/th, max((y+1)*h/th, y*h/th+1)
+		rows := image.Rect(0, 0, w, y1-y0)
+		draw.Draw(band, rows, image.White, image.Point{}, draw.Src)
+		draw.Draw(band, rows, img, image.Pt(bounds.Min.X, bounds.Min.Y+y0), draw.Over)
+
+		for x := 0; x < tw; x++ {
+			x0, x1 := x*w/tw, max((x+1)*w/tw, x*w/tw+1)
+
+			var sum [4]int
+			for sy := 0; sy < y1-y0; sy++ {
+				row := band.Pix[sy*band.Stride+x0*4 : sy*band.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			p := dst.Pix[dst.PixOffset(x, y):]
+			for c := range sum {
+				p[c] = uint8(sum[c] / n)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, errors.Wrap(err, "encode thumbnail")
+	}
+
+	return buf.Bytes(), nil
+}
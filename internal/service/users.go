@@ -0,0 +1,103 @@
+//go:generate mockgen -source ./users.go -destination=./mocks/users.go -package=mocks
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/policy"
+	"github.com/shhesterka04/house-service/internal/repository"
+)
+
+var (
+	ErrInvalidUserID  = errors.New("invalid user id")
+	ErrAdminImmutable = errors.New("admin accounts can not be changed through the API")
+)
+
+type UserAdminRepo interface {
+	GetUserByID(ctx context.Context, id string) (repository.User, error)
+	UpdateUserType(ctx context.Context, id, userType string) error
+	SetUserDisabled(ctx context.Context, id string, disabled bool) error
+}
+
+type UserService struct {
+	userRepo UserAdminRepo
+}
+
+func NewUserService(userRepo UserAdminRepo) *UserService {
+	return &UserService{userRepo: userRepo}
+}
+
+// SetRole promotes a client to moderator or demotes a moderator back to client.
+func (s *UserService) SetRole(ctx context.Context, id string, userType dto.UserType) (*dto.User, error) {
+	if err := Authorize(ctx, policy.UserManage); err != nil {
+		return nil, err
+	}
+
+	if userType != dto.Client && userType != dto.Moderator {
+		return nil, ErrInvalidUserType
+	}
+
+	user, err := s.getMutableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.userRepo.UpdateUserType(ctx, user.UUID, string(userType)); err != nil {
+		return nil, errors.Wrap(err, "update user type")
+	}
+
+	user.Type = string(userType)
+	return toDtoUser(user)
+}
+
+func (s *UserService) SetDisabled(ctx context.Context, id string, disabled bool) (*dto.User, error) {
+	if err := Authorize(ctx, policy.UserManage); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getMutableUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.userRepo.SetUserDisabled(ctx, user.UUID, disabled); err != nil {
+		return nil, errors.Wrap(err, "set user disabled")
+	}
+
+	user.Disabled = disabled
+	return toDtoUser(user)
+}
+
+func (s *UserService) getMutableUser(ctx context.Context, id string) (repository.User, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return repository.User{}, ErrInvalidUserID
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return repository.User{}, err
+	}
+
+	if user.Type == string(dto.Admin) {
+		return repository.User{}, ErrAdminImmutable
+	}
+
+	return user, nil
+}
+
+func toDtoUser(user repository.User) (*dto.User, error) {
+	id, err := uuid.Parse(user.UUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse user id")
+	}
+
+	return &dto.User{
+		Id:       id,
+		Email:    dto.Email(user.Email),
+		UserType: dto.UserType(user.Type),
+		Disabled: user.Disabled,
+	}, nil
+}
@@ -0,0 +1,121 @@
+//go:build unit
+// +build unit
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/shhesterka04/house-service/internal/dto"
+	"github.com/shhesterka04/house-service/internal/repository"
+	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testUserID = "cae36e0f-69e5-4fa8-a179-a52d083c5549"
+
+func TestUserService_SetRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		userType  dto.UserType
+		mockSetup func(m *mocks.MockUserAdminRepo)
+		wantType  dto.UserType
+		wantErr   error
+	}{
+		{
+			name:     "promote client to moderator",
+			id:       testUserID,
+			userType: dto.Moderator,
+			mockSetup: func(m *mocks.MockUserAdminRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "client"}, nil).Times(1)
+				m.EXPECT().UpdateUserType(gomock.Any(), testUserID, "moderator").Return(nil).Times(1)
+			},
+			wantType: dto.Moderator,
+		},
+		{
+			name:     "demote moderator to client",
+			id:       testUserID,
+			userType: dto.Client,
+			mockSetup: func(m *mocks.MockUserAdminRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "moderator"}, nil).Times(1)
+				m.EXPECT().UpdateUserType(gomock.Any(), testUserID, "client").Return(nil).Times(1)
+			},
+			wantType: dto.Client,
+		},
+		{
+			name:      "promote to admin",
+			id:        testUserID,
+			userType:  dto.Admin,
+			mockSetup: func(m *mocks.MockUserAdminRepo) {},
+			wantErr:   service.ErrInvalidUserType,
+		},
+		{
+			name:      "invalid id",
+			id:        "not-a-uuid",
+			userType:  dto.Moderator,
+			mockSetup: func(m *mocks.MockUserAdminRepo) {},
+			wantErr:   service.ErrInvalidUserID,
+		},
+		{
+			name:     "admin is immutable",
+			id:       testUserID,
+			userType: dto.Client,
+			mockSetup: func(m *mocks.MockUserAdminRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "admin"}, nil).Times(1)
+			},
+			wantErr: service.ErrAdminImmutable,
+		},
+		{
+			name:     "user not found",
+			id:       testUserID,
+			userType: dto.Moderator,
+			mockSetup: func(m *mocks.MockUserAdminRepo) {
+				m.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{}, repository.ErrUserNotFound).Times(1)
+			},
+			wantErr: repository.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserAdminRepo(ctrl)
+			tt.mockSetup(mockUserRepo)
+
+			userService := service.NewUserService(mockUserRepo)
+			user, err := userService.SetRole(ctxWithRole(dto.Admin), tt.id, tt.userType)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantType, user.UserType)
+			}
+		})
+	}
+}
+
+func TestUserService_SetDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserAdminRepo(ctrl)
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(repository.User{UUID: testUserID, Type: "moderator"}, nil).Times(1)
+	mockUserRepo.EXPECT().SetUserDisabled(gomock.Any(), testUserID, true).Return(nil).Times(1)
+
+	userService := service.NewUserService(mockUserRepo)
+	user, err := userService.SetDisabled(ctxWithRole(dto.Admin), testUserID, true)
+
+	require.NoError(t, err)
+	assert.True(t, user.Disabled)
+
+	_, err = userService.SetDisabled(ctxWithRole(dto.Moderator), testUserID, true)
+	require.ErrorIs(t, err, service.ErrForbidden)
+}
@@ -0,0 +1,14 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE user_type ADD VALUE IF NOT EXISTS 'admin';
+
+ALTER TABLE users ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN disabled;
+
+UPDATE users SET type = 'moderator' WHERE type = 'admin';
+ALTER TYPE user_type RENAME TO user_type_old;
+CREATE TYPE user_type AS ENUM ('client', 'moderator');
+ALTER TABLE users ALTER COLUMN type TYPE user_type USING type::text::user_type;
+DROP TYPE user_type_old;
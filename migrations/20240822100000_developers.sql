@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE user_type ADD VALUE IF NOT EXISTS 'developer';
+
+-- normalize_developer_name folds the ways one developer gets spelled ("ООО «Мэрия»", "мэрия  ооо")
+-- into a single key. It is used both to deduplicate existing houses and when new houses are created.
+CREATE FUNCTION normalize_developer_name(name TEXT) RETURNS TEXT AS $$
+    SELECT NULLIF(btrim(regexp_replace(
+        regexp_replace(
+            ' ' || regexp_replace(lower(name), '[^[:alnum:]]+', ' ', 'g') || ' ',
+            ' (ооо|оао|зао|пао|ао|ип|гк|llc|inc|ltd|co|corp|group) ', ' ', 'g'),
+        '\s+', ' ', 'g')), '')
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE TABLE developers
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL UNIQUE,
+    website VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(64),
+    description TEXT,
+    user_id UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- The most frequent spelling of each developer becomes its display name.
+INSERT INTO developers (name, normalized_name)
+SELECT DISTINCT ON (normalized) spelling, normalized
+FROM (
+    SELECT btrim(developer) AS spelling, normalize_developer_name(developer) AS normalized, count(*) AS uses
+    FROM house
+    WHERE normalize_developer_name(developer) IS NOT NULL
+    GROUP BY btrim(developer), normalize_developer_name(developer)
+) spellings
+ORDER BY normalized, uses DESC, spelling;
+
+ALTER TABLE house ADD COLUMN developer_id INT REFERENCES developers(id);
+
+UPDATE house h SET developer_id = d.id
+FROM developers d
+WHERE d.normalized_name = normalize_developer_name(h.developer);
+
+ALTER TABLE house DROP COLUMN developer;
+
+CREATE INDEX idx_house_developer_id ON house(developer_id);
+CREATE INDEX idx_developers_user_id ON developers(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE house ADD COLUMN developer VARCHAR(255);
+
+UPDATE house h SET developer = d.name
+FROM developers d
+WHERE d.id = h.developer_id;
+
+DROP INDEX idx_house_developer_id;
+ALTER TABLE house DROP COLUMN developer_id;
+
+DROP TABLE developers;
+DROP FUNCTION normalize_developer_name(TEXT);
+
+UPDATE users SET type = 'client' WHERE type = 'developer';
+ALTER TYPE user_type RENAME TO user_type_old;
+CREATE TYPE user_type AS ENUM ('client', 'moderator', 'admin');
+ALTER TABLE users ALTER COLUMN type TYPE user_type USING type::text::user_type;
+DROP TYPE user_type_old;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- normalize_address folds the ways one address gets written ("ул. Ленина 1", "Ленина ул, 1") into a single
+-- key: lower case, ё as е, no punctuation or street type words, tokens in sorted order.
+CREATE FUNCTION normalize_address(address TEXT) RETURNS TEXT AS $$
+    SELECT array_to_string(ARRAY(
+        SELECT token
+        FROM regexp_split_to_table(regexp_replace(replace(lower(address), 'ё', 'е'), '[^[:alnum:]]+', ' ', 'g'), ' ') AS token
+        WHERE token <> ''
+          AND token NOT IN ('ул', 'улица', 'пр', 'просп', 'проспект', 'пер', 'переулок', 'бул', 'бульвар',
+                            'ш', 'шоссе', 'наб', 'набережная', 'пл', 'площадь', 'д', 'дом', 'г', 'город')
+        ORDER BY token
+    ), ' ')
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE house ADD COLUMN normalized_address TEXT GENERATED ALWAYS AS (normalize_address(address)) STORED;
+ALTER TABLE house ADD COLUMN address_search TSVECTOR GENERATED ALWAYS AS (to_tsvector('russian', address)) STORED;
+
+CREATE INDEX idx_house_normalized_address ON house(normalized_address);
+CREATE INDEX idx_house_normalized_address_trgm ON house USING GIN (normalized_address gin_trgm_ops);
+CREATE INDEX idx_house_address_search ON house USING GIN (address_search);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_house_address_search;
+DROP INDEX idx_house_normalized_address_trgm;
+DROP INDEX idx_house_normalized_address;
+
+ALTER TABLE house DROP COLUMN address_search;
+ALTER TABLE house DROP COLUMN normalized_address;
+
+DROP FUNCTION normalize_address(TEXT);
+DROP EXTENSION IF EXISTS pg_trgm;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Indexes for GET /flats/search: every search filters on status and pages by (sort column, id).
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_flats_status_price_id ON flats(status, price, id);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_flats_status_rooms_price_id ON flats(status, rooms, price, id);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_flats_status_id ON flats(status, id);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_house_year ON house(year);
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS idx_house_year;
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_flats_status_id;
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_flats_status_rooms_price_id;
+
+DROP INDEX CONCURRENTLY IF EXISTS idx_flats_status_price_id;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE house
+    ADD COLUMN city VARCHAR(255),
+    ADD COLUMN street VARCHAR(255),
+    ADD COLUMN building VARCHAR(64),
+    ADD COLUMN postal_code VARCHAR(10),
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION,
+    ADD CONSTRAINT house_latitude_range CHECK (latitude BETWEEN -90 AND 90),
+    ADD CONSTRAINT house_longitude_range CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT house_coordinates_pair CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- Radius search narrows candidates with a bounding box on these columns before computing distances.
+CREATE INDEX idx_house_coordinates ON house(latitude, longitude) WHERE latitude IS NOT NULL;
+CREATE INDEX idx_house_city ON house(lower(city));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_house_city;
+DROP INDEX idx_house_coordinates;
+
+ALTER TABLE house
+    DROP CONSTRAINT house_coordinates_pair,
+    DROP CONSTRAINT house_longitude_range,
+    DROP CONSTRAINT house_latitude_range,
+    DROP COLUMN longitude,
+    DROP COLUMN latitude,
+    DROP COLUMN postal_code,
+    DROP COLUMN building,
+    DROP COLUMN street,
+    DROP COLUMN city;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE flat_balcony AS ENUM ('none', 'balcony', 'loggia', 'balcony_and_loggia');
+CREATE TYPE flat_finishing AS ENUM ('none', 'rough', 'pre_finish', 'finished');
+
+ALTER TABLE house
+    ADD COLUMN floors INT,
+    ADD CONSTRAINT house_floors_positive CHECK (floors > 0);
+
+ALTER TABLE flats
+    ADD COLUMN floor INT,
+    ADD COLUMN total_area NUMERIC(7, 2),
+    ADD COLUMN living_area NUMERIC(7, 2),
+    ADD COLUMN section VARCHAR(32),
+    ADD COLUMN ceiling_height NUMERIC(4, 2),
+    ADD COLUMN balcony flat_balcony,
+    ADD COLUMN finishing flat_finishing,
+    ADD CONSTRAINT flats_floor_positive CHECK (floor > 0),
+    ADD CONSTRAINT flats_total_area_positive CHECK (total_area > 0),
+    ADD CONSTRAINT flats_living_area_range CHECK (living_area > 0 AND living_area <= total_area),
+    ADD CONSTRAINT flats_ceiling_height_range CHECK (ceiling_height BETWEEN 2 AND 6);
+
+-- Area and floor filters of GET /flats/search.
+CREATE INDEX idx_flats_total_area ON flats(total_area) WHERE total_area IS NOT NULL;
+CREATE INDEX idx_flats_floor ON flats(floor) WHERE floor IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_flats_floor;
+DROP INDEX idx_flats_total_area;
+
+ALTER TABLE flats
+    DROP CONSTRAINT flats_ceiling_height_range,
+    DROP CONSTRAINT flats_living_area_range,
+    DROP CONSTRAINT flats_total_area_positive,
+    DROP CONSTRAINT flats_floor_positive,
+    DROP COLUMN finishing,
+    DROP COLUMN balcony,
+    DROP COLUMN ceiling_height,
+    DROP COLUMN section,
+    DROP COLUMN living_area,
+    DROP COLUMN total_area,
+    DROP COLUMN floor;
+
+ALTER TABLE house
+    DROP CONSTRAINT house_floors_positive,
+    DROP COLUMN floors;
+
+DROP TYPE flat_finishing;
+DROP TYPE flat_balcony;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE flat_media_kind AS ENUM ('photo', 'floor_plan');
+
+-- Files themselves live in the blob store, rows keep their keys. Blobs of deleted rows are removed by the service.
+CREATE TABLE flat_media
+(
+    id BIGSERIAL PRIMARY KEY,
+    flat_id INT NOT NULL REFERENCES flats(id) ON DELETE CASCADE,
+    kind flat_media_kind NOT NULL,
+    content_type VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    blob_key VARCHAR(255) NOT NULL UNIQUE,
+    thumbnail_key VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT flat_media_size_positive CHECK (size > 0)
+);
+
+CREATE INDEX idx_flat_media_flat_id ON flat_media(flat_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_flat_media_flat_id;
+
+DROP TABLE flat_media;
+
+DROP TYPE flat_media_kind;
+-- +goose StatementEnd
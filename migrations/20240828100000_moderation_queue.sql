@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing flats have no history, they enter the queue at the time of the migration.
+ALTER TABLE flats
+    ADD COLUMN status_changed_at TIMESTAMP NOT NULL DEFAULT now();
+
+-- GET /moderation/queue pages through pending flats by (status_changed_at, id).
+CREATE INDEX idx_flats_moderation_queue ON flats(status_changed_at, id) WHERE status IN ('created', 'on moderation');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_flats_moderation_queue;
+
+ALTER TABLE flats
+    DROP COLUMN status_changed_at;
+-- +goose StatementEnd
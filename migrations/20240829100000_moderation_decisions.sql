@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reasons are never deleted, inactive ones can not be chosen but stay referenced by past decisions.
+CREATE TABLE decline_reasons
+(
+    code VARCHAR(64) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    CONSTRAINT decline_reasons_code_format CHECK (code ~ '^[a-z][a-z0-9_]{1,63}$')
+);
+
+INSERT INTO decline_reasons (code, title) VALUES
+    ('wrong_price', 'Цена не соответствует рынку'),
+    ('wrong_attributes', 'Характеристики квартиры указаны неверно'),
+    ('duplicate', 'Квартира уже опубликована'),
+    ('missing_media', 'Нет фотографий или планировки'),
+    ('not_for_sale', 'Квартира не продается'),
+    ('other', 'Другая причина');
+
+ALTER TABLE flats ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE moderation_decisions
+(
+    id BIGSERIAL PRIMARY KEY,
+    flat_id INT NOT NULL REFERENCES flats(id) ON DELETE CASCADE,
+    status flat_status NOT NULL,
+    reason_code VARCHAR(64) REFERENCES decline_reasons(code),
+    comment VARCHAR(1000),
+    moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT moderation_decisions_status CHECK (status IN ('approved', 'declined')),
+    CONSTRAINT moderation_decisions_declined_reason CHECK ((status = 'declined') = (reason_code IS NOT NULL))
+);
+
+CREATE INDEX idx_moderation_decisions_flat_id ON moderation_decisions(flat_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_moderation_decisions_flat_id;
+
+DROP TABLE moderation_decisions;
+
+ALTER TABLE flats DROP COLUMN created_by;
+
+DROP TABLE decline_reasons;
+-- +goose StatementEnd
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+-- actor_id has no foreign key: the log outlives the users and keeps the synthetic IDs of dummy logins.
+CREATE TABLE audit_log
+(
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP NOT NULL DEFAULT now(),
+    actor_id UUID,
+    actor_role VARCHAR(32),
+    api_key_id UUID,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(64)
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, id);
+CREATE INDEX idx_audit_log_actor_id ON audit_log(actor_id, id);
+CREATE INDEX idx_audit_log_request_id ON audit_log(request_id);
+CREATE INDEX idx_audit_log_occurred_at ON audit_log(occurred_at);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER audit_log_no_truncate ON audit_log;
+
+DROP TRIGGER audit_log_append_only ON audit_log;
+
+DROP FUNCTION audit_log_append_only();
+
+DROP INDEX idx_audit_log_occurred_at;
+
+DROP INDEX idx_audit_log_request_id;
+
+DROP INDEX idx_audit_log_actor_id;
+
+DROP INDEX idx_audit_log_entity;
+
+DROP TABLE audit_log;
+-- +goose StatementEnd
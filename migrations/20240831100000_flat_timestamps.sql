@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing flats get the time of the migration, their real creation time is not known.
+ALTER TABLE flats
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN deleted_at TIMESTAMP;
+
+-- Soft-deleted flats are hidden from every listing, GET /flats/search pages through the rest by (timestamp, id).
+CREATE INDEX idx_flats_status_created_at_id ON flats(status, created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_flats_status_updated_at_id ON flats(status, updated_at, id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_flats_status_updated_at_id;
+DROP INDEX idx_flats_status_created_at_id;
+
+ALTER TABLE flats
+    DROP COLUMN deleted_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
+-- +goose StatementEnd
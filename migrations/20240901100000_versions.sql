@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every change of a row increments its version, updates of a flat are applied only to the version the
+-- client has read.
+ALTER TABLE flats
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE house
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE house
+    DROP COLUMN version;
+
+ALTER TABLE flats
+    DROP COLUMN version;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Responses of create requests by Idempotency-Key, per user. status is 0 while the first request is in progress.
+CREATE TABLE idempotency_keys
+(
+    scope VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status INT NOT NULL DEFAULT 0,
+    headers JSONB,
+    body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at TIMESTAMP NOT NULL,
+    CONSTRAINT idempotency_keys_pkey PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_idempotency_keys_expires_at;
+
+DROP TABLE idempotency_keys;
+-- +goose StatementEnd
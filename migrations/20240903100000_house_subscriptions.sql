@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Emails to notify about new flats in a house.
+CREATE TABLE house_subscriptions
+(
+    house_id INT NOT NULL REFERENCES house(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    CONSTRAINT house_subscriptions_pkey PRIMARY KEY (house_id, email)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE house_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Marks the entries made with the tokens of /dummyLogin, whose actor is a synthetic user.
+ALTER TABLE audit_log ADD COLUMN actor_dummy BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE audit_log DROP COLUMN actor_dummy;
+-- +goose StatementEnd
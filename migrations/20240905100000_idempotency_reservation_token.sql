@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Identifies the reservation of a key, so that a request that lost its key to a later one can not change it.
+ALTER TABLE idempotency_keys ADD COLUMN token UUID NOT NULL DEFAULT gen_random_uuid();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE idempotency_keys DROP COLUMN token;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+// Package blobstore keeps binary objects, such as flat photos, outside of the database. Objects are
+// addressed by slash-separated keys and stored either on the local filesystem or in an S3-compatible bucket.
+package blobstore
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// validateKey accepts keys of letters, digits, dots, dashes and underscores separated by slashes. Empty
+// and dot-only segments are refused, so a key can not escape the root of a store.
+func validateKey(key string) error {
+	if key == "" || len(key) > 1024 {
+		return errors.Wrapf(ErrInvalidKey, "%q", key)
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || strings.Trim(segment, ".") == "" {
+			return errors.Wrapf(ErrInvalidKey, "%q", key)
+		}
+		for _, c := range segment {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '-', c == '_':
+			default:
+				return errors.Wrapf(ErrInvalidKey, "%q", key)
+			}
+		}
+	}
+
+	return nil
+}
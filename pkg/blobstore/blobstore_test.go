@@ -0,0 +1,158 @@
+//go:build unit
+// +build unit
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func TestStores(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	stores := map[string]store{
+		"local": local,
+		"s3":    newS3StandIn(t),
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := "flats/7/photo.jpg"
+			data := []byte("not really a jpeg")
+
+			require.NoError(t, s.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/jpeg"))
+
+			rc, err := s.Open(ctx, key)
+			require.NoError(t, err)
+			got, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			require.NoError(t, rc.Close())
+			assert.Equal(t, data, got)
+
+			require.NoError(t, s.Delete(ctx, key))
+			_, err = s.Open(ctx, key)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			assert.NoError(t, s.Delete(ctx, key), "deleting a missing blob")
+			assert.ErrorIs(t, s.Put(ctx, "../escape", strings.NewReader("x"), 1, ""), ErrInvalidKey)
+		})
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"a", "flats/1/2c1f.jpg", "a_b-c.d/e"} {
+		assert.NoError(t, validateKey(key), key)
+	}
+	for _, key := range []string{"", "/a", "a/", "a//b", "../a", "a/./b", "a b", "a?b", "a%2fb"} {
+		assert.ErrorIs(t, validateKey(key), ErrInvalidKey, key)
+	}
+}
+
+// TestSignV4 checks the signer against the GET Object example of the AWS Signature Version 4 documentation.
+func TestSignV4(t *testing.T) {
+	signedHeaders, signature := signV4(
+		"wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
+		"20130524/us-east-1/s3/aws4_request",
+		"20130524T000000Z",
+		http.MethodGet, "/test.txt", "",
+		map[string]string{
+			"host":                 "examplebucket.s3.amazonaws.com",
+			"range":                "bytes=0-9",
+			"x-amz-content-sha256": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			"x-amz-date":           "20130524T000000Z",
+		},
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	)
+
+	assert.Equal(t, "host;range;x-amz-content-sha256;x-amz-date", signedHeaders)
+	assert.Equal(t, "f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41", signature)
+}
+
+var authorizationPattern = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/([^,]+), SignedHeaders=([^,]+), Signature=([0-9a-f]{64})$`)
+
+// newS3StandIn starts an in-memory S3 stand-in for bucket "photos" that checks request signatures and
+// returns a store talking to it.
+func newS3StandIn(t *testing.T) *S3 {
+	const (
+		accessKey = "test-access"
+		secretKey = "test-secret"
+	)
+
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m := authorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+		if m == nil || m[1] != accessKey {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+			return
+		}
+
+		headers := map[string]string{}
+		for _, name := range strings.Split(m[3], ";") {
+			if name == "host" {
+				headers[name] = r.Host
+				continue
+			}
+			headers[name] = r.Header.Get(name)
+		}
+		if _, want := signV4(secretKey, m[2], r.Header.Get("X-Amz-Date"), r.Method, r.URL.EscapedPath(), r.URL.RawQuery, headers, r.Header.Get("X-Amz-Content-Sha256")); want != m[4] {
+			http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+			return
+		}
+
+		key, ok := strings.CutPrefix(r.URL.Path, "/photos/")
+		if !ok {
+			http.Error(w, "NoSuchBucket", http.StatusNotFound)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[key] = body
+		case http.MethodGet:
+			body, ok := objects[key]
+			if !ok {
+				http.Error(w, "NoSuchKey", http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3(S3Config{Endpoint: srv.URL, Bucket: "photos", Region: "ru-central1", AccessKey: accessKey, SecretKey: secretKey}, srv.Client())
+	require.NoError(t, err)
+	s.now = func() time.Time { return time.Date(2024, 8, 27, 12, 0, 0, 0, time.UTC) }
+
+	return s
+}
@@ -0,0 +1,125 @@
+// Package ratelimit limits how often a caller may repeat a request. Every caller has a token bucket, which
+// holds as many tokens as the limit allows requests and refills evenly over the period of the limit.
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Limit allows Requests requests per Period. The zero Limit allows any number of requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Unlimited reports whether the limit allows any number of requests.
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0
+}
+
+// perSecond is the rate at which the bucket refills.
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// ParseLimit reads a limit such as "10/m", "300/h" or "5/30s". The period is s, m, h or a duration. An
+// empty string is the zero Limit.
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Limit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, errors.Errorf("rate limit %q is not of the form requests/period", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return Limit{}, errors.Errorf("rate limit %q must allow a positive number of requests", s)
+	}
+
+	var d time.Duration
+	switch period = strings.TrimSpace(period); period {
+	case "s":
+		d = time.Second
+	case "m":
+		d = time.Minute
+	case "h":
+		d = time.Hour
+	default:
+		if d, err = time.ParseDuration(period); err != nil || d <= 0 {
+			return Limit{}, errors.Errorf("rate limit %q has an invalid period", s)
+		}
+	}
+
+	return Limit{Requests: n, Period: d}, nil
+}
+
+// Limits are the limits of the routes of a server, such as "POST /flat/create". Routes without their own
+// limit share Default.
+type Limits struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+// ParseLimits reads the default limit and a comma-separated list of route limits, such as
+// "POST /login=10/m,POST /register=5/m".
+func ParseLimits(def, routes string) (Limits, error) {
+	defLimit, err := ParseLimit(def)
+	if err != nil {
+		return Limits{}, err
+	}
+
+	limits := Limits{Default: defLimit, Routes: map[string]Limit{}}
+	for _, entry := range strings.Split(routes, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		route, limit, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(route) == "" {
+			return Limits{}, errors.Errorf("route rate limit %q is not of the form route=limit", entry)
+		}
+
+		if limits.Routes[strings.TrimSpace(route)], err = ParseLimit(limit); err != nil {
+			return Limits{}, err
+		}
+	}
+
+	return limits, nil
+}
+
+// For returns the limit of route.
+func (l Limits) For(route string) Limit {
+	if limit, ok := l.Routes[route]; ok {
+		return limit
+	}
+
+	return l.Default
+}
+
+// Result is the state of a bucket after a request took a token from it.
+type Result struct {
+	// Allowed reports whether there was a token for the request.
+	Allowed bool
+	// Remaining is the number of requests that may follow at once.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, zero if the request was allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. Memory keeps them in the process, a store shared by all instances of the
+// service makes the limits hold across them.
+type Store interface {
+	// Take takes a token for a request from the bucket of key, which holds tokens by limit.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
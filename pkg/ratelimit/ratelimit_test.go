@@ -0,0 +1,129 @@
+//go:build unit
+// +build unit
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "", want: Limit{}},
+		{in: "10/s", want: Limit{Requests: 10, Period: time.Second}},
+		{in: "10/m", want: Limit{Requests: 10, Period: time.Minute}},
+		{in: " 300 / h ", want: Limit{Requests: 300, Period: time.Hour}},
+		{in: "5/30s", want: Limit{Requests: 5, Period: 30 * time.Second}},
+		{in: "10", wantErr: true},
+		{in: "0/m", wantErr: true},
+		{in: "-1/m", wantErr: true},
+		{in: "ten/m", wantErr: true},
+		{in: "10/week", wantErr: true},
+		{in: "10/-1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseLimit(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := ParseLimits("300/m", "POST /login=10/m, POST /register=5/m,")
+	require.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 10, Period: time.Minute}, limits.For("POST /login"))
+	assert.Equal(t, Limit{Requests: 5, Period: time.Minute}, limits.For("POST /register"))
+	assert.Equal(t, Limit{Requests: 300, Period: time.Minute}, limits.For("GET /flats/search"))
+
+	_, err = ParseLimits("300/m", "POST /login")
+	assert.Error(t, err)
+	_, err = ParseLimits("300/m", "POST /login=often")
+	assert.Error(t, err)
+}
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		result, err := m.Take(ctx, "a", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, remaining, result.Remaining)
+		assert.Zero(t, result.RetryAfter)
+	}
+
+	result, err := m.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.Reset)
+
+	result, err = m.Take(ctx, "b", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "buckets are per key")
+
+	now = now.Add(1500 * time.Millisecond)
+	result, err = m.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = m.Take(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	result, err = m.Take(ctx, "unlimited", Limit{})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemorySweep(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	_, err := m.Take(ctx, "short", Limit{Requests: 1, Period: time.Second})
+	require.NoError(t, err)
+	_, err = m.Take(ctx, "long", Limit{Requests: 1, Period: time.Hour})
+	require.NoError(t, err)
+
+	now = now.Add(sweepInterval)
+	_, err = m.Take(ctx, "other", Limit{Requests: 1, Period: time.Second})
+	require.NoError(t, err)
+
+	assert.NotContains(t, m.buckets, "short")
+	assert.Contains(t, m.buckets, "long")
+	assert.Contains(t, m.buckets, "other")
+}
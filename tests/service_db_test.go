@@ -13,13 +13,17 @@ import (
 	"testing"
 
 	"github.com/pkg/errors"
+	"github.com/shhesterka04/house-service/api"
 	"github.com/shhesterka04/house-service/internal/config"
 	"github.com/shhesterka04/house-service/internal/handlers"
+	"github.com/shhesterka04/house-service/internal/middleware"
 	"github.com/shhesterka04/house-service/internal/repository"
 	"github.com/shhesterka04/house-service/internal/routes"
 	"github.com/shhesterka04/house-service/internal/service"
+	"github.com/shhesterka04/house-service/pkg/blobstore"
 	"github.com/shhesterka04/house-service/pkg/db"
 	"github.com/shhesterka04/house-service/pkg/logger"
+	"github.com/shhesterka04/house-service/pkg/ratelimit"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,9 +66,16 @@ func TestIntegration(t *testing.T) {
 	defer pgClient.Close()
 
 	userRepo := repository.NewUserRepository(dbConn.Cluster)
-	authService := service.NewAuthService(userRepo)
+	authService := service.NewAuthService(userRepo, service.DefaultPasswordPolicy())
 	authHandlers := handlers.NewAuthHandlers(authService)
 
+	userService := service.NewUserService(userRepo)
+	userHandlers := handlers.NewUserHandler(userService)
+
+	apiKeyRepo := repository.NewAPIKeyRepository(dbConn.Cluster)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	apiKeyHandlers := handlers.NewAPIKeyHandler(apiKeyService)
+
 	houseRepo := repository.NewHouseRepository(dbConn.Cluster)
 	houseService := service.NewHouseService(houseRepo)
 	houseHandlers := handlers.NewHouseHandler(houseService)
@@ -73,11 +84,29 @@ func TestIntegration(t *testing.T) {
 	flatService := service.NewFlatService(flatRepo, houseRepo)
 	flatHandlers := handlers.NewFlatHandler(flatService)
 
-	mux := routes.NewRouter(authHandlers, houseHandlers, flatHandlers)
+	blobs, err := blobstore.NewLocal(t.TempDir())
+	assert.NoError(t, err)
+	flatMediaService := service.NewFlatMediaService(repository.NewFlatMediaRepository(dbConn.Cluster), flatRepo, houseRepo, blobs)
+	flatMediaHandlers := handlers.NewFlatMediaHandler(flatMediaService)
+
+	moderationService := service.NewModerationService(repository.NewModerationRepository(dbConn.Cluster), flatRepo)
+	moderationHandlers := handlers.NewModerationHandler(moderationService)
+
+	auditHandlers := handlers.NewAuditHandler(service.NewAuditService(repository.NewAuditRepository(dbConn.Cluster)))
+
+	developerRepo := repository.NewDeveloperRepository(dbConn.Cluster)
+	developerService := service.NewDeveloperService(developerRepo)
+	developerHandlers := handlers.NewDeveloperHandler(developerService)
+
+	docsHandlers, err := handlers.NewDocsHandler(api.Spec, api.DocsPage)
+	assert.NoError(t, err)
+
+	apiServer := handlers.NewServer(authHandlers, houseHandlers, flatHandlers, flatMediaHandlers, moderationHandlers, auditHandlers, userHandlers, apiKeyHandlers, developerHandlers, docsHandlers)
+	mux := routes.NewRouter(cfg, apiServer, apiKeyService, authService, repository.NewIdempotencyRepository(dbConn.Cluster), ratelimit.NewMemory())
 
 	server := &http.Server{
 		Addr:    cfg.HostAddr,
-		Handler: mux,
+		Handler: middleware.RequestID(mux),
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -100,38 +129,46 @@ func TestIntegration(t *testing.T) {
 	// Step 1: Register user
 	registerPayload := map[string]string{
 		"email":     "abaac@lmao.com",
-		"password":  "qwerty",
-		"user_type": "moderator",
+		"password":  "Qwerty-House-2024",
+		"user_type": "client",
 	}
 
 	registerBody, _ := json.Marshal(registerPayload)
-	resp, err := client.Post("http://localhost:8080/register", "application/json", bytes.NewBuffer(registerBody))
+	resp, err := client.Post("http://localhost:8080/v1/register", "application/json", bytes.NewBuffer(registerBody))
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-	// Step 2: Login user
-	loginPayload := map[string]string{
-		"email":    "abaac@lmao.com",
-		"password": "qwerty",
+	var registered struct {
+		UserID string `json:"user_id"`
 	}
-	loginBody, _ := json.Marshal(loginPayload)
-	resp, err = client.Post("http://localhost:8080/login", "application/json", bytes.NewBuffer(loginBody))
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&registered))
+	assert.NotEmpty(t, registered.UserID)
+
+	// Step 2: Bootstrap admin and promote user to moderator
+	adminID, err := authService.BootstrapAdmin(ctx, "admin@lmao.com", "Admin-House-2024")
+	assert.NoError(t, err)
+	adminToken := login(t, client, adminID, "Admin-House-2024")
+
+	roleBody, _ := json.Marshal(map[string]string{"user_type": "moderator"})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/v1/admin/users/%s/role", registered.UserID), bytes.NewBuffer(roleBody))
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err = client.Do(req)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-	tokenBytes, _ := io.ReadAll(resp.Body)
-	token := string(tokenBytes)
-	token = token[1 : len(token)-2]
+	// Step 3: Login user
+	token := login(t, client, registered.UserID, "Qwerty-House-2024")
 
-	// Step 3: Create house
+	// Step 4: Create house
 	housePayload := map[string]interface{}{
 		"address":   "123 Main St",
 		"year":      2020,
 		"developer": "Developer Inc.",
 	}
 	houseBody, _ := json.Marshal(housePayload)
-	req, _ := http.NewRequest("POST", "http://localhost:8080/house/create", bytes.NewBuffer(houseBody))
+	req, _ = http.NewRequest("POST", "http://localhost:8080/v1/house/create", bytes.NewBuffer(houseBody))
 	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", "create-house-1")
 	resp, err = client.Do(req)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
@@ -143,7 +180,27 @@ func TestIntegration(t *testing.T) {
 	houseIDd := house["id"].(float64)
 	houseID := int(houseIDd)
 
-	// Step 4: Create flats
+	// A retry with the same key gets the stored response instead of a second house
+	req, _ = http.NewRequest("POST", "http://localhost:8080/v1/house/create", bytes.NewBuffer(houseBody))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", "create-house-1")
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	respRetry, _ := io.ReadAll(resp.Body)
+	assert.JSONEq(t, string(respHouse), string(respRetry))
+
+	// The key can not be reused for another house
+	otherHouseBody, _ := json.Marshal(map[string]interface{}{"address": "125 Main St", "year": 2021})
+	req, _ = http.NewRequest("POST", "http://localhost:8080/v1/house/create", bytes.NewBuffer(otherHouseBody))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", "create-house-1")
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	// Step 5: Create flats
 	for i := 1; i <= 3; i++ {
 		flatPayload := map[string]interface{}{
 			"house_id": houseID,
@@ -152,7 +209,7 @@ func TestIntegration(t *testing.T) {
 			"price":    100000 * i,
 		}
 		flatBody, _ := json.Marshal(flatPayload)
-		req, _ = http.NewRequest("POST", "http://localhost:8080/flat/create", bytes.NewBuffer(flatBody))
+		req, _ = http.NewRequest("POST", "http://localhost:8080/v1/flat/create", bytes.NewBuffer(flatBody))
 		req.Header.Set("Authorization", "Bearer "+token)
 		resp, err = client.Do(req)
 		assert.NoError(t, err)
@@ -162,28 +219,52 @@ func TestIntegration(t *testing.T) {
 	var flat map[string]interface{}
 	json.Unmarshal(respFlat, &flat)
 	flatId := int(flat["id"].(float64))
+	flatETag := resp.Header.Get("ETag")
+	assert.Equal(t, `"1"`, flatETag)
 
-	// Step 5: Update flat status
+	// Step 6: Update flat status
 	updatePayload := map[string]interface{}{
 		"id":     flatId,
 		"status": "approved",
 	}
 	updateBody, _ := json.Marshal(updatePayload)
-	req, _ = http.NewRequest("POST", "http://localhost:8080/flat/update", bytes.NewBuffer(updateBody))
+	req, _ = http.NewRequest("POST", "http://localhost:8080/v1/flat/update", bytes.NewBuffer(updateBody))
 	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", flatETag)
 	resp, err = client.Do(req)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	// The same ETag is stale after the update
+	req, _ = http.NewRequest("POST", "http://localhost:8080/v1/flat/update", bytes.NewBuffer(updateBody))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", flatETag)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
 
-	// Step 6: Get all flats
-	req, _ = http.NewRequest("GET", fmt.Sprintf("http://localhost:8080/house/%v", houseID), nil)
+	// Step 7: Get all flats
+	req, _ = http.NewRequest("GET", fmt.Sprintf("http://localhost:8080/v1/house/%v", houseID), nil)
 	req.Header.Set("Authorization", "Bearer "+token)
 	resp, err = client.Do(req)
 	readAll, _ := io.ReadAll(resp.Body)
-	var h []map[string]interface{}
+	var h struct {
+		Flats []map[string]interface{} `json:"flats"`
+	}
 	json.Unmarshal(readAll, &h)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	houseETag := resp.Header.Get("ETag")
+	assert.NotEmpty(t, houseETag)
+
+	// The unchanged list is not sent again
+	req, _ = http.NewRequest("GET", fmt.Sprintf("http://localhost:8080/v1/house/%v", houseID), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-None-Match", houseETag)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
 
 	// Define the expected data structure
 	expectedData := []map[string]interface{}{
@@ -193,11 +274,36 @@ func TestIntegration(t *testing.T) {
 	}
 
 	// Check for expected values
-	for i, flat := range h {
+	assert.Len(t, h.Flats, len(expectedData))
+	for i, flat := range h.Flats {
 		assert.Equal(t, expectedData[i]["house_id"], flat["house_id"])
 		assert.Equal(t, expectedData[i]["number"], flat["number"])
 		assert.Equal(t, expectedData[i]["price"], flat["price"])
 		assert.Equal(t, expectedData[i]["rooms"], flat["rooms"])
 		assert.Equal(t, expectedData[i]["status"], flat["status"])
 	}
+
+	// Step 8: Subscribe to news about the house
+	subscribeBody, _ := json.Marshal(map[string]string{"email": "buyer@lmao.com"})
+	req, _ = http.NewRequest("POST", fmt.Sprintf("http://localhost:8080/v1/house/%v/subscribe", houseID), bytes.NewBuffer(subscribeBody))
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func login(t *testing.T, client *http.Client, id, password string) string {
+	loginBody, _ := json.Marshal(map[string]string{
+		"id":       id,
+		"password": password,
+	})
+	resp, err := client.Post("http://localhost:8080/v1/login", "application/json", bytes.NewBuffer(loginBody))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Token string `json:"token"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body.Token
 }